@@ -1,18 +1,29 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"zatrano/configs/csrfconfig"
 	"zatrano/configs/databaseconfig"
+	"zatrano/configs/envconfig"
 	"zatrano/configs/fileconfig"
+	"zatrano/configs/limiterconfig"
 	"zatrano/configs/logconfig"
+	"zatrano/configs/notifierconfig"
+	"zatrano/configs/oauthconfig"
+	"zatrano/configs/secretconfig"
 	"zatrano/configs/sessionconfig"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/scheduler"
 	"zatrano/pkg/templatehelpers"
 	"zatrano/routes"
+	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/template/html/v2"
@@ -33,12 +44,21 @@ func main() {
 	databaseconfig.InitDB()
 	defer databaseconfig.CloseDB()
 
+	limiterconfig.InitLimiterStorage()
+	defer limiterconfig.CloseLimiterStorage()
+
 	sessionconfig.InitSession()
+	defer sessionconfig.CloseSession()
+
+	secretconfig.InitSecret()
+	oauthconfig.InitOAuth()
+	notifierconfig.InitNotifier()
 
 	fileconfig.InitFileConfig()
 
 	fileconfig.Config.SetAllowedExtensions("cards", []string{"jpg", "png", "webp"})
 	fileconfig.Config.SetAllowedExtensions("invitations", []string{"jpeg", "png"})
+	fileconfig.Config.SetAllowedExtensions(services.MediaContentType, []string{"jpg", "jpeg", "png", "webp"})
 
 	engine := html.New("./views", ".html")
 	engine.AddFunc("getFlashMessages", flashmessages.GetFlashMessages)
@@ -46,6 +66,16 @@ func main() {
 
 	app := fiber.New(fiber.Config{
 		Views: engine,
+		// Galeriye tek istekte birden fazla fotoğraf yüklenebildiği için
+		// varsayılan 4 MB sınırı yetmez.
+		BodyLimit: envconfig.GetEnvAsInt("APP_BODY_LIMIT_MB", 25) * 1024 * 1024,
+		// c.IP() giriş kilitleri ve hız sınırlarında anahtar olarak kullanılır.
+		// Başlık yalnızca güvenilen vekil sunuculardan gelirse okunur; liste boşsa
+		// her zaman bağlantının adresi kullanılır.
+		ProxyHeader:             envconfig.GetEnvWithDefault("APP_PROXY_HEADER", ""),
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies(),
+		EnableIPValidation:      true,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			message := "Internal Server Error"
@@ -72,7 +102,57 @@ func main() {
 	app.Use(csrfconfig.SetupCSRF())
 	routes.SetupRoutes(app)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startJobs(ctx)
+
 	startServer(app)
+
+	// Tamponda kalan ziyaret istatistikleri kapanmadan önce yazılır.
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer flushCancel()
+	_ = services.NewVisitService().Flush(flushCtx)
+}
+
+// trustedProxies, APP_TRUSTED_PROXIES içindeki virgülle ayrılmış IP ve CIDR
+// değerlerini döndürür.
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(envconfig.GetEnvWithDefault("APP_TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+func startJobs(ctx context.Context) {
+	accountService := services.NewAccountService()
+	purgeInterval := time.Duration(envconfig.GetEnvAsInt("ACCOUNT_PURGE_INTERVAL_MINUTES", 60)) * time.Minute
+	scheduler.Every(ctx, "account-purge", purgeInterval, func(ctx context.Context) {
+		accountService.PurgeDueAccounts(ctx)
+	})
+
+	invitationService := services.NewInvitationService()
+	archiveInterval := time.Duration(envconfig.GetEnvAsInt("INVITATION_ARCHIVE_INTERVAL_MINUTES", 60)) * time.Minute
+	scheduler.Every(ctx, "invitation-archive", archiveInterval, func(ctx context.Context) {
+		_, _ = invitationService.ArchiveEnded(ctx)
+	})
+
+	visitService := services.NewVisitService()
+	flushInterval := time.Duration(envconfig.GetEnvAsInt("ANALYTICS_FLUSH_SECONDS", 10)) * time.Second
+	scheduler.Every(ctx, "visit-flush", flushInterval, func(ctx context.Context) {
+		_ = visitService.Flush(ctx)
+	})
+	scheduler.Every(ctx, "visit-cleanup", time.Hour, func(ctx context.Context) {
+		_ = visitService.Cleanup(ctx)
+	})
+
+	reminderService := services.NewReminderService()
+	reminderInterval := time.Duration(envconfig.GetEnvAsInt("REMINDER_INTERVAL_MINUTES", 5)) * time.Minute
+	scheduler.Every(ctx, "invitation-reminders", reminderInterval, func(ctx context.Context) {
+		reminderService.SendDue(ctx)
+	})
 }
 
 func startServer(app *fiber.App) {
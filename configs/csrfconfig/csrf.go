@@ -15,6 +15,8 @@ import (
 
 var csrfExemptPaths = []string{
 	// "rotalar",
+	// Apple yanıtı form_post ile gönderir; istek state ile doğrulanır.
+	"/auth/apple/callback",
 }
 
 func SetupCSRF() fiber.Handler {
@@ -26,6 +26,19 @@ func GetEnvAsInt(key string, defaultValue int) int {
 	return valueInt
 }
 
+func GetEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	valueBool, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return valueBool
+}
+
 func IsProduction() bool {
 	return os.Getenv("APP_ENV") == "production"
 }
@@ -1,12 +1,124 @@
 package limiterconfig
 
 import (
+	"strings"
+	"sync"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/pkg/dbstorage"
+
+	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"go.uber.org/zap"
+)
+
+const StorageTable = "limiter_storage"
+
+type Policy string
+
+const (
+	PolicyAuth      Policy = "auth"
+	PolicyPublic    Policy = "public"
+	PolicyRSVP      Policy = "rsvp"
+	PolicyGuestbook Policy = "guestbook"
+	PolicyAPI       Policy = "api"
+	PolicyUpload    Policy = "upload"
+	PolicyGift      Policy = "gift"
+	PolicyPIN       Policy = "pin"
+)
+
+type policyDefaults struct {
+	max        int
+	expiration int
+}
+
+// Ortam değişkenleri ile ezilebilir: LIMITER_<POLICY>_MAX ve
+// LIMITER_<POLICY>_EXPIRATION_SECONDS (ör. LIMITER_AUTH_MAX=20).
+var defaultPolicies = map[Policy]policyDefaults{
+	PolicyAuth:      {max: 20, expiration: 60},
+	PolicyPublic:    {max: 300, expiration: 60},
+	PolicyRSVP:      {max: 10, expiration: 600},
+	PolicyGuestbook: {max: 5, expiration: 600},
+	PolicyAPI:       {max: 600, expiration: 60},
+	PolicyUpload:    {max: 30, expiration: 600},
+	PolicyGift:      {max: 5, expiration: 600},
+	PolicyPIN:       {max: 10, expiration: 600},
+}
+
+var (
+	storage fiber.Storage
+
+	handlersMu sync.Mutex
+	handlers   = map[Policy]fiber.Handler{}
 )
 
-func GetLimiterConfig() limiter.Config {
+// InitLimiterStorage, LIMITER_STORAGE=postgres (varsayılan) ise limiter
+// sayaçlarını veritabanında tutar; memory seçilirse fiber'ın bellek içi
+// deposu kullanılır.
+func InitLimiterStorage() {
+	driver := envconfig.GetEnvWithDefault("LIMITER_STORAGE", "postgres")
+	if driver == "memory" {
+		logconfig.SLog.Info("Limiter bellek içi depolama ile yapılandırıldı.")
+		return
+	}
+
+	storage = dbstorage.New(databaseconfig.GetDB(), StorageTable, dbstorage.DefaultGCInterval)
+	logconfig.SLog.Info("Limiter veritabanı depolaması ile yapılandırıldı.")
+}
+
+func CloseLimiterStorage() {
+	if storage == nil {
+		return
+	}
+	if err := storage.Close(); err != nil {
+		logconfig.Log.Warn("Limiter depolaması kapatılamadı", zap.Error(err))
+	}
+}
+
+func GetLimiterConfig(policy Policy) limiter.Config {
+	defaults, ok := defaultPolicies[policy]
+	if !ok {
+		logconfig.Log.Warn("Tanımsız limiter politikası, public kullanılıyor", zap.String("policy", string(policy)))
+		policy = PolicyPublic
+		defaults = defaultPolicies[PolicyPublic]
+	}
+
+	prefix := "LIMITER_" + strings.ToUpper(string(policy))
 	return limiter.Config{
-		Max:        1000,
-		Expiration: 60,
+		Max:        envconfig.GetEnvAsInt(prefix+"_MAX", defaults.max),
+		Expiration: time.Duration(envconfig.GetEnvAsInt(prefix+"_EXPIRATION_SECONDS", defaults.expiration)) * time.Second,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return string(policy) + ":" + c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			logconfig.Log.Warn("İstek limiti aşıldı",
+				zap.String("policy", string(policy)),
+				zap.String("ip", c.IP()),
+				zap.String("path", c.Path()),
+			)
+			message := "Çok fazla istek gönderildi. Lütfen biraz sonra tekrar deneyin."
+			if strings.Contains(c.Get("Accept"), "application/json") {
+				return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": message})
+			}
+			return c.Status(fiber.StatusTooManyRequests).SendString(message)
+		},
+		Storage: storage,
+	}
+}
+
+// New, aynı politika için her çağrıda aynı middleware'i döner; böylece farklı
+// route gruplarına bağlansa da politika tek bir sayaç üzerinden işler.
+func New(policy Policy) fiber.Handler {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+
+	if handler, ok := handlers[policy]; ok {
+		return handler
 	}
+	handler := limiter.New(GetLimiterConfig(policy))
+	handlers[policy] = handler
+	return handler
 }
@@ -0,0 +1,98 @@
+package notifierconfig
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/pkg/notifier"
+
+	"go.uber.org/zap"
+)
+
+var (
+	mu      sync.RWMutex
+	current notifier.Notifier
+)
+
+// InitNotifier, NOTIFIER_DRIVER değerine göre bildirim sağlayıcısını kurar:
+// "log" mesajları yalnızca loglar, "fake" bellekte biriktirir, "webhook"
+// NOTIFIER_WEBHOOK_URL adresine iletir. NOTIFIER_CHANNELS hangi kanalların
+// bu sağlayıcıya yönlendirileceğini belirler.
+func InitNotifier() {
+	driver := strings.ToLower(envconfig.GetEnvWithDefault("NOTIFIER_DRIVER", "log"))
+
+	var n notifier.Notifier
+	switch driver {
+	case "webhook":
+		url := envconfig.GetEnvWithDefault("NOTIFIER_WEBHOOK_URL", "")
+		if url == "" {
+			logconfig.Log.Warn("NOTIFIER_WEBHOOK_URL tanımlı değil, bildirimler yalnızca loglanacak")
+			n = logNotifier()
+			driver = "log"
+		} else {
+			n = notifier.NewWebhook(url, envconfig.GetEnvWithDefault("NOTIFIER_WEBHOOK_TOKEN", ""))
+		}
+	case "fake":
+		n = notifier.NewFake()
+	default:
+		n = logNotifier()
+		driver = "log"
+	}
+
+	router := notifier.Router{}
+	for _, channel := range strings.Split(envconfig.GetEnvWithDefault("NOTIFIER_CHANNELS", "sms,whatsapp"), ",") {
+		channel = strings.TrimSpace(strings.ToLower(channel))
+		if channel != "" {
+			router[notifier.Channel(channel)] = n
+		}
+	}
+
+	Set(router)
+	logconfig.Log.Info("Bildirim sağlayıcısı hazır",
+		zap.String("driver", driver),
+		zap.Int("channels", len(router)),
+	)
+}
+
+func Get() notifier.Notifier {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return logNotifier()
+	}
+	return current
+}
+
+// Set, etkin bildirim sağlayıcısını değiştirir; testlerde notifier.Fake ile
+// kullanılır.
+func Set(n notifier.Notifier) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = n
+}
+
+// logNotifier, mesajları göndermek yerine loglar. Alıcı maskelenir; kişisel
+// veri içeren mesaj metni yalnızca Debug seviyesinde yazılır.
+func logNotifier() notifier.Notifier {
+	return notifier.Func(func(_ context.Context, msg notifier.Message) error {
+		logconfig.Log.Info("Bildirim (log)",
+			zap.String("channel", string(msg.Channel)),
+			zap.String("to", maskRecipient(msg.To)),
+			zap.Int("body_length", len(msg.Body)),
+		)
+		logconfig.Log.Debug("Bildirim metni (log)", zap.String("body", msg.Body))
+		return nil
+	})
+}
+
+// maskRecipient, alıcının yalnızca son dört karakterini bırakır.
+func maskRecipient(to string) string {
+	runes := []rune(to)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
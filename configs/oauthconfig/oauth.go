@@ -0,0 +1,116 @@
+package oauthconfig
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/pkg/oauth"
+
+	"go.uber.org/zap"
+)
+
+const googleIssuer = "https://accounts.google.com"
+
+var providers = map[string]oauth.Provider{}
+
+func redirectURL(name, envKey string) string {
+	if value := os.Getenv(envKey); value != "" {
+		return value
+	}
+	return strings.TrimSuffix(os.Getenv("APP_BASE_URL"), "/") + "/auth/" + name + "/callback"
+}
+
+// InitOAuth, istemci kimliği tanımlanmış sağlayıcıları kaydeder. Ek OIDC
+// sağlayıcıları OIDC_PROVIDERS=keycloak,azure gibi tanımlanır ve her biri için
+// OIDC_<AD>_ISSUER, OIDC_<AD>_CLIENT_ID, OIDC_<AD>_CLIENT_SECRET ve isteğe
+// bağlı OIDC_<AD>_DISPLAY_NAME, OIDC_<AD>_REDIRECT_URI okunur.
+func InitOAuth() {
+	providers = map[string]oauth.Provider{}
+
+	if clientID := os.Getenv("GOOGLE_CLIENT_ID"); clientID != "" {
+		register(oauth.NewOIDCProvider(oauth.OIDCConfig{
+			Name:         "google",
+			DisplayName:  "Google",
+			Issuer:       googleIssuer,
+			ClientID:     clientID,
+			ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
+			RedirectURL:  redirectURL("google", "GOOGLE_REDIRECT_URI"),
+		}))
+	}
+
+	if clientID := os.Getenv("FACEBOOK_CLIENT_ID"); clientID != "" {
+		register(oauth.NewFacebookProvider(oauth.FacebookConfig{
+			ClientID:     clientID,
+			ClientSecret: os.Getenv("FACEBOOK_CLIENT_SECRET"),
+			RedirectURL:  redirectURL("facebook", "FACEBOOK_REDIRECT_URI"),
+			GraphVersion: envconfig.GetEnvWithDefault("FACEBOOK_GRAPH_VERSION", "v19.0"),
+		}))
+	}
+
+	if clientID := os.Getenv("APPLE_CLIENT_ID"); clientID != "" {
+		provider, err := oauth.NewAppleProvider(oauth.AppleConfig{
+			ClientID:    clientID,
+			TeamID:      os.Getenv("APPLE_TEAM_ID"),
+			KeyID:       os.Getenv("APPLE_KEY_ID"),
+			PrivateKey:  os.Getenv("APPLE_PRIVATE_KEY"),
+			RedirectURL: redirectURL("apple", "APPLE_REDIRECT_URI"),
+		})
+		if err != nil {
+			logconfig.Log.Error("Apple sağlayıcısı yapılandırılamadı", zap.Error(err))
+		} else {
+			register(provider)
+		}
+	}
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, exists := providers[name]; exists {
+			logconfig.Log.Warn("OIDC sağlayıcısı zaten tanımlı, atlanıyor", zap.String("provider", name))
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		issuer := os.Getenv(prefix + "ISSUER")
+		clientID := os.Getenv(prefix + "CLIENT_ID")
+		if issuer == "" || clientID == "" {
+			logconfig.Log.Warn("OIDC sağlayıcısı için issuer veya client id eksik", zap.String("provider", name))
+			continue
+		}
+
+		register(oauth.NewOIDCProvider(oauth.OIDCConfig{
+			Name:         name,
+			DisplayName:  envconfig.GetEnvWithDefault(prefix+"DISPLAY_NAME", name),
+			Issuer:       issuer,
+			ClientID:     clientID,
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  redirectURL(name, prefix+"REDIRECT_URI"),
+		}))
+	}
+}
+
+func register(provider oauth.Provider) {
+	providers[provider.Name()] = provider
+	logconfig.SLog.Infof("OAuth sağlayıcısı kaydedildi: %s", provider.Name())
+}
+
+func Get(name string) (oauth.Provider, bool) {
+	provider, ok := providers[name]
+	return provider, ok
+}
+
+// Providers, giriş ve profil sayfalarında gösterilmek üzere kayıtlı
+// sağlayıcıları ada göre sıralı döner.
+func Providers() []oauth.Provider {
+	list := make([]oauth.Provider, 0, len(providers))
+	for _, provider := range providers {
+		list = append(list, provider)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
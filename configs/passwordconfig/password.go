@@ -0,0 +1,22 @@
+package passwordconfig
+
+import (
+	"zatrano/configs/envconfig"
+	"zatrano/pkg/passwordpolicy"
+)
+
+// GetPolicy, şifre politikasını ortam değişkenlerinden okur. Tüm şifre
+// belirleme noktaları (kayıt, sıfırlama, profil ve yönetim paneli) bu
+// politikayı kullanır.
+func GetPolicy() passwordpolicy.Policy {
+	return passwordpolicy.Policy{
+		MinLength:          envconfig.GetEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+		RequireUpper:       envconfig.GetEnvAsBool("PASSWORD_REQUIRE_UPPER", true),
+		RequireLower:       envconfig.GetEnvAsBool("PASSWORD_REQUIRE_LOWER", true),
+		RequireDigit:       envconfig.GetEnvAsBool("PASSWORD_REQUIRE_DIGIT", true),
+		RequireSymbol:      envconfig.GetEnvAsBool("PASSWORD_REQUIRE_SYMBOL", false),
+		ForbidPersonalInfo: envconfig.GetEnvAsBool("PASSWORD_FORBID_PERSONAL_INFO", true),
+		CheckCommon:        envconfig.GetEnvAsBool("PASSWORD_CHECK_COMMON", true),
+		HistorySize:        envconfig.GetEnvAsInt("PASSWORD_HISTORY_SIZE", 5),
+	}
+}
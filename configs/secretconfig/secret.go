@@ -0,0 +1,79 @@
+package secretconfig
+
+import (
+	"crypto/rand"
+	"sync"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+
+	"go.uber.org/zap"
+)
+
+const minSecretLength = 32
+
+var (
+	mu         sync.RWMutex
+	key        []byte
+	configured bool
+)
+
+// InitSecret, imzalı bağlantı ve çerezlerde kullanılan APP_SECRET değerini
+// yükler. Üretimde zorunludur; geliştirmede tanımlı değilse her açılışta
+// rastgele üretilir ve önceki imzalar geçersiz olur.
+func InitSecret() {
+	secret := envconfig.GetEnvWithDefault("APP_SECRET", "")
+	if len(secret) >= minSecretLength {
+		Set([]byte(secret))
+		mu.Lock()
+		configured = true
+		mu.Unlock()
+		return
+	}
+
+	if envconfig.IsProduction() {
+		logconfig.Log.Fatal("APP_SECRET tanımlı değil veya çok kısa", zap.Int("min_length", minSecretLength))
+	}
+	logconfig.Log.Warn("APP_SECRET tanımlı değil, geçici anahtar üretiliyor; imzalı bağlantılar yeniden başlatmada geçersiz olur")
+	Set(randomKey())
+}
+
+// Key, imzalama anahtarını döndürür. InitSecret çağrılmadıysa geçici anahtar
+// üretilir.
+func Key() []byte {
+	mu.RLock()
+	k := key
+	mu.RUnlock()
+	if k != nil {
+		return k
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if key == nil {
+		key = randomKey()
+	}
+	return key
+}
+
+// Configured, anahtarın APP_SECRET değerinden yüklenip yüklenmediğini
+// bildirir. Geçici anahtarla kalıcı veri şifrelenmemelidir.
+func Configured() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return configured
+}
+
+func Set(k []byte) {
+	mu.Lock()
+	defer mu.Unlock()
+	key = k
+}
+
+func randomKey() []byte {
+	k := make([]byte, minSecretLength)
+	if _, err := rand.Read(k); err != nil {
+		panic("secretconfig: rastgele anahtar üretilemedi: " + err.Error())
+	}
+	return k
+}
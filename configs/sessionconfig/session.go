@@ -4,15 +4,29 @@ import (
 	"encoding/gob"
 	"time"
 
+	"zatrano/configs/databaseconfig"
 	"zatrano/configs/envconfig"
 	"zatrano/configs/logconfig"
 	"zatrano/models"
+	"zatrano/pkg/dbstorage"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
+	"go.uber.org/zap"
 )
 
-var Session *session.Store
+const (
+	StorageTable       = "session_storage"
+	RememberCookieName = "remember_me"
+	// InvitationAccessCookieName, şifreli davetiyeye erişimi hatırlayan
+	// çerezdir; yolu davetiyeyle sınırlı olduğundan her davetiye ayrı tutulur.
+	InvitationAccessCookieName = "invitation_access"
+)
+
+var (
+	Session *session.Store
+	storage fiber.Storage
+)
 
 func InitSession() {
 	Session = createSessionStore()
@@ -28,22 +42,53 @@ func SetupSession() *session.Store {
 	return Session
 }
 
+func Expiration() time.Duration {
+	return time.Duration(envconfig.GetEnvAsInt("SESSION_EXPIRATION_HOURS", 24)) * time.Hour
+}
+
+// createSessionStore, SESSION_STORAGE=postgres (varsayılan) ise oturum
+// verisini veritabanında tutar; böylece yeniden başlatmalarda oturumlar
+// korunur ve birden fazla instance aynı oturumları paylaşır.
 func createSessionStore() *session.Store {
-	sessionExpirationHours := envconfig.GetEnvAsInt("SESSION_EXPIRATION_HOURS", 24)
 	cookieSecure := envconfig.IsProduction()
 
-	store := session.New(session.Config{
-		CookieHTTPOnly: false,
+	config := session.Config{
+		CookieHTTPOnly: true,
 		CookieSecure:   cookieSecure,
-		Expiration:     time.Duration(sessionExpirationHours) * time.Hour,
+		Expiration:     Expiration(),
 		KeyLookup:      "cookie:session_id",
 		CookieSameSite: "Lax",
-	})
+	}
 
-	logconfig.SLog.Info("Cookie tabanlı session sistemi %d saatlik süreyle yapılandırıldı.", sessionExpirationHours)
+	if envconfig.GetEnvWithDefault("SESSION_STORAGE", "postgres") != "memory" {
+		storage = dbstorage.New(databaseconfig.GetDB(), StorageTable, dbstorage.DefaultGCInterval)
+		config.Storage = storage
+		logconfig.SLog.Info("Oturumlar veritabanında saklanacak.")
+	}
+
+	store := session.New(config)
+
+	logconfig.SLog.Infof("Session sistemi %s süreyle yapılandırıldı.", config.Expiration)
 	return store
 }
 
+func CloseSession() {
+	if storage == nil {
+		return
+	}
+	if err := storage.Close(); err != nil {
+		logconfig.Log.Warn("Oturum depolaması kapatılamadı", zap.Error(err))
+	}
+}
+
+// DeleteSessionByID, başka bir cihazdaki oturumu depodan silerek sonlandırır.
+func DeleteSessionByID(id string) error {
+	if Session == nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "session store not initialized")
+	}
+	return Session.Delete(id)
+}
+
 func registerGobTypes() {
 	gob.Register(models.UserType(""))
 	gob.Register(&models.User{})
@@ -65,6 +110,45 @@ func DestroySession(c *fiber.Ctx) error {
 	return sess.Destroy()
 }
 
+// SetRememberCookie, "beni hatırla" tokenını oturum çereziyle aynı güvenlik
+// ayarlarıyla ancak uzun ömürlü olarak yazar.
+func SetRememberCookie(c *fiber.Ctx, value string, expires time.Time) {
+	c.Cookie(&fiber.Cookie{
+		Name:     RememberCookieName,
+		Value:    value,
+		Path:     "/",
+		Expires:  expires,
+		HTTPOnly: true,
+		Secure:   envconfig.IsProduction(),
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+func ClearRememberCookie(c *fiber.Ctx) {
+	c.Cookie(&fiber.Cookie{
+		Name:     RememberCookieName,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HTTPOnly: true,
+		Secure:   envconfig.IsProduction(),
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+func SetInvitationAccessCookie(c *fiber.Ctx, invitationKey, value string, expires time.Time) {
+	c.Cookie(&fiber.Cookie{
+		Name:     InvitationAccessCookieName,
+		Value:    value,
+		Path:     "/" + invitationKey,
+		Expires:  expires,
+		HTTPOnly: true,
+		Secure:   envconfig.IsProduction(),
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
 func GetUserTypeFromSession(sess *session.Session) (models.UserType, error) {
 	userType, ok := sess.Get("user_type").(models.UserType)
 	if !ok {
@@ -5,6 +5,7 @@ import (
 
 	"zatrano/configs/databaseconfig"
 	"zatrano/configs/logconfig"
+	"zatrano/configs/secretconfig"
 	"zatrano/database"
 )
 
@@ -16,6 +17,7 @@ func main() {
 	flag.Parse()
 
 	databaseconfig.InitDB()
+	secretconfig.InitSecret()
 	defer databaseconfig.CloseDB()
 
 	db := databaseconfig.GetDB()
@@ -64,6 +64,30 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	if err := migrations.MigrateUsersTable(db); err != nil {
 		return err
 	}
+	if err := migrations.MigrateUserTokensTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateUserRecoveryCodesTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateUserSessionsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateRememberTokensTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigratePasswordHistoriesTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateAuthThrottlesTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateLimiterStorageTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateSessionStorageTable(db); err != nil {
+		return err
+	}
 	if err := migrations.MigrateInvitationCategoriesTable(db); err != nil {
 		return err
 	}
@@ -76,6 +100,18 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	if err := migrations.MigrateInvitationParticipantsTable(db); err != nil {
 		return err
 	}
+	if err := migrations.MigrateInvitationQuestionsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationEventsTables(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationGuestbookTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationMediaTable(db); err != nil {
+		return err
+	}
 	if err := migrations.MigrateCardsTable(db); err != nil {
 		return err
 	}
@@ -88,9 +124,21 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	if err := migrations.MigrateCardBanksTable(db); err != nil {
 		return err
 	}
+	if err := migrations.MigrateInvitationGiftTables(db); err != nil {
+		return err
+	}
 	if err := migrations.MigrateCardSocialMediaTable(db); err != nil {
 		return err
 	}
+	if err := migrations.MigrateVisitTables(db); err != nil {
+		return err
+	}
+	if err := migrations.NormalizePhoneNumbers(db); err != nil {
+		return err
+	}
+	if err := migrations.EncryptTwoFactorSecrets(db); err != nil {
+		return err
+	}
 	return nil
 }
 
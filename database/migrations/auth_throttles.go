@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateAuthThrottlesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("AuthThrottle tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.AuthThrottle{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("AuthThrottle tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationEventsTables(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationEvent tabloları migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationEvent{}, &models.InvitationEventResponse{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationEvent tabloları migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationGiftTables(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationGift tabloları migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationGiftAccount{}, &models.InvitationGiftNote{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationGift tabloları migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationGuestbookTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationGuestbookEntry tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationGuestbookEntry{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationGuestbookEntry tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationMediaTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationMedia tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationMedia{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationMedia tablosu migrate işlemi tamamlandı.")
+	return nil
+}
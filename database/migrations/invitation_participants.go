@@ -9,9 +9,19 @@ import (
 
 func MigrateInvitationParticipantsTable(db *gorm.DB) error {
 	logconfig.SLog.Info("InvitationParticipant tablosu migrate ediliyor...")
+	// Durum sütunundan önce katılımcılar yalnızca katılım formundan geliyordu;
+	// mevcut kayıtlar katılıyor olarak işaretlenir.
+	hadStatus := db.Migrator().HasColumn(&models.InvitationParticipant{}, "status")
 	if err := db.AutoMigrate(&models.InvitationParticipant{}); err != nil {
 		return err
 	}
+	if !hadStatus {
+		if err := db.Model(&models.InvitationParticipant{}).
+			Where("1 = 1").
+			Update("status", models.ParticipantAccepted).Error; err != nil {
+			return err
+		}
+	}
 	logconfig.SLog.Info("InvitationParticipant tablosu migrate işlemi tamamlandı.")
 	return nil
 }
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationQuestionsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationQuestion tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationQuestion{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationQuestion tablosu migrate işlemi tamamlandı.")
+	return nil
+}
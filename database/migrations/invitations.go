@@ -1,6 +1,8 @@
 package migrations
 
 import (
+	"errors"
+
 	"zatrano/configs/logconfig"
 	"zatrano/models"
 
@@ -12,6 +14,31 @@ func MigrateInvitationsTable(db *gorm.DB) error {
 	if err := db.AutoMigrate(&models.Invitation{}); err != nil {
 		return err
 	}
+
+	// Ayrı tutulan tarih ve serbest metin saat kolonları, saat dilimli event_at
+	// kolonuna taşınıyor. Saat okunamıyorsa gün başı kabul edilir.
+	migrator := db.Migrator()
+	if migrator.HasColumn(&models.Invitation{}, "date") {
+		timeExpr := "'00:00'::time"
+		if migrator.HasColumn(&models.Invitation{}, "time") {
+			timeExpr = `CASE WHEN "time" ~ '^(?:[01]?[0-9]|2[0-3]):[0-5][0-9]' THEN substring("time" from '^(?:[01]?[0-9]|2[0-3]):[0-5][0-9]')::time ELSE '00:00'::time END`
+		}
+		backfill := `UPDATE invitations SET event_at = (("date"::date + ` + timeExpr + `) AT TIME ZONE timezone) WHERE event_at IS NULL AND "date" IS NOT NULL`
+		if err := db.Exec(backfill).Error; err != nil {
+			return errors.New("event_at kolonu doldurulamadı: " + err.Error())
+		}
+		logconfig.SLog.Info("invitations.event_at kolonu eski tarih/saat kolonlarından dolduruldu.")
+
+		for _, column := range []string{"date", "time"} {
+			if migrator.HasColumn(&models.Invitation{}, column) {
+				if err := migrator.DropColumn(&models.Invitation{}, column); err != nil {
+					return errors.New(column + " kolonu silinemedi: " + err.Error())
+				}
+				logconfig.SLog.Infof("invitations.%s kolonu silindi.", column)
+			}
+		}
+	}
+
 	logconfig.SLog.Info("Invitation tablosu migrate işlemi tamamlandı.")
 	return nil
 }
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigratePasswordHistoriesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("PasswordHistory tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.PasswordHistory{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("PasswordHistory tablosu migrate işlemi tamamlandı.")
+	return nil
+}
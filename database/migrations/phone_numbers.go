@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"errors"
+
+	"zatrano/configs/logconfig"
+	"zatrano/pkg/phone"
+
+	"gorm.io/gorm"
+)
+
+// phoneColumns, E.164 biçiminde saklanan telefon kolonlarıdır.
+var phoneColumns = []struct {
+	table  string
+	column string
+}{
+	{"cards", "telephone"},
+	{"invitations", "telephone"},
+	{"invitation_participants", "phone_number"},
+}
+
+// NormalizePhoneNumbers, telefonların E.164 biçiminde saklanmasından önce
+// girilmiş kayıtları dönüştürür. Çözümlenemeyen değerlere dokunulmaz; adım
+// her çalıştırmada yalnızca henüz dönüştürülmemiş satırları işler.
+func NormalizePhoneNumbers(db *gorm.DB) error {
+	logconfig.SLog.Info("Telefon numaraları E.164 biçimine dönüştürülüyor...")
+	for _, target := range phoneColumns {
+		var rows []struct {
+			ID    uint
+			Value string
+		}
+		err := db.Table(target.table).
+			Select("id, " + target.column + " AS value").
+			Where(target.column + " <> '' AND " + target.column + " !~ '^\\+[0-9]{8,15}$'").
+			Scan(&rows).Error
+		if err != nil {
+			return errors.New(target.table + " telefonları okunamadı: " + err.Error())
+		}
+
+		updated, skipped := 0, 0
+		for _, row := range rows {
+			normalized, err := phone.Parse(row.Value)
+			if err != nil {
+				skipped++
+				continue
+			}
+			if normalized == row.Value {
+				continue
+			}
+			if err := db.Table(target.table).Where("id = ?", row.ID).Update(target.column, normalized).Error; err != nil {
+				return errors.New(target.table + " telefonu güncellenemedi: " + err.Error())
+			}
+			updated++
+		}
+		logconfig.SLog.Infof("%s.%s: %d kayıt dönüştürüldü, %d kayıt çözümlenemediği için olduğu gibi bırakıldı.", target.table, target.column, updated, skipped)
+	}
+	logconfig.SLog.Info("Telefon numaralarının dönüştürülmesi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateRememberTokensTable(db *gorm.DB) error {
+	logconfig.SLog.Info("RememberToken tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.RememberToken{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("RememberToken tablosu migrate işlemi tamamlandı.")
+	return nil
+}
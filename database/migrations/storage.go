@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"zatrano/configs/limiterconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateLimiterStorageTable(db *gorm.DB) error {
+	logconfig.SLog.Info("LimiterStorage tablosu migrate ediliyor...")
+	if err := db.Table(limiterconfig.StorageTable).AutoMigrate(&models.StorageEntry{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("LimiterStorage tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+func MigrateSessionStorageTable(db *gorm.DB) error {
+	logconfig.SLog.Info("SessionStorage tablosu migrate ediliyor...")
+	if err := db.Table(sessionconfig.StorageTable).AutoMigrate(&models.StorageEntry{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("SessionStorage tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"errors"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/secretconfig"
+	"zatrano/models"
+	"zatrano/pkg/secretbox"
+
+	"gorm.io/gorm"
+)
+
+// EncryptTwoFactorSecrets, şifrelemeden önce düz metin olarak saklanmış TOTP
+// anahtarlarını APP_SECRET ile şifreler. APP_SECRET tanımlı değilse geçici
+// anahtarla şifrelenen veriler bir sonraki açılışta çözülemeyeceğinden adım
+// atlanır.
+func EncryptTwoFactorSecrets(db *gorm.DB) error {
+	if !secretconfig.Configured() {
+		logconfig.SLog.Warn("APP_SECRET tanımlı değil; TOTP anahtarlarının şifrelenmesi atlandı.")
+		return nil
+	}
+
+	logconfig.SLog.Info("TOTP anahtarları şifreleniyor...")
+	var rows []struct {
+		ID     uint
+		Secret string
+	}
+	err := db.Table("users").
+		Select("id, two_factor_secret AS secret").
+		Where("two_factor_secret <> '' AND two_factor_secret NOT LIKE 'v1:%'").
+		Scan(&rows).Error
+	if err != nil {
+		return errors.New("TOTP anahtarları okunamadı: " + err.Error())
+	}
+
+	for _, row := range rows {
+		sealed, err := secretbox.Seal(secretconfig.Key(), models.TwoFactorSecretPurpose, row.Secret)
+		if err != nil {
+			return errors.New("TOTP anahtarı şifrelenemedi: " + err.Error())
+		}
+		// Koşul, eşzamanlı bir güncellemenin üzerine yazılmasını önler.
+		err = db.Table("users").
+			Where("id = ? AND two_factor_secret = ?", row.ID, row.Secret).
+			Update("two_factor_secret", sealed).Error
+		if err != nil {
+			return errors.New("TOTP anahtarı güncellenemedi: " + err.Error())
+		}
+	}
+	logconfig.SLog.Infof("TOTP anahtarlarının şifrelenmesi tamamlandı: %d kayıt.", len(rows))
+	return nil
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateUserRecoveryCodesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("UserRecoveryCode tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.UserRecoveryCode{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("UserRecoveryCode tablosu migrate işlemi tamamlandı.")
+	return nil
+}
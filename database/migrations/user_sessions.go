@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateUserSessionsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("UserSession tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.UserSession{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("UserSession tablosu migrate işlemi tamamlandı.")
+	return nil
+}
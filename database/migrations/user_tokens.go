@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateUserTokensTable(db *gorm.DB) error {
+	logconfig.SLog.Info("UserToken tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.UserToken{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("UserToken tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -36,6 +36,16 @@ func MigrateUsersTable(db *gorm.DB) error {
 		return errors.New("User tablosu migrate edilemedi: " + err.Error())
 	}
 
+	// Tokenlar user_tokens tablosuna taşındı; eski düz metin kolonlar kaldırılıyor.
+	for _, column := range []string{"reset_token", "verification_token"} {
+		if db.Migrator().HasColumn(&models.User{}, column) {
+			if err := db.Migrator().DropColumn(&models.User{}, column); err != nil {
+				return errors.New(column + " kolonu silinemedi: " + err.Error())
+			}
+			logconfig.SLog.Infof("users.%s kolonu silindi.", column)
+		}
+	}
+
 	logconfig.SLog.Info("User tablosu migrate işlemi tamamlandı.")
 	return nil
 }
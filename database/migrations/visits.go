@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func MigrateVisitTables(db *gorm.DB) error {
+	logconfig.SLog.Info("Ziyaret istatistiği tabloları migrate ediliyor...")
+	if err := db.AutoMigrate(&models.VisitCounter{}, &models.VisitUnique{}, &models.VisitSalt{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("Ziyaret istatistiği tabloları migrate işlemi tamamlandı.")
+	return nil
+}
@@ -8,51 +8,52 @@ import (
 )
 
 func SeedBanks(db *gorm.DB) error {
-	// Banka listesi
+	// Banka listesi. Code, IBAN'dan banka tespiti için EFT banka kodudur; kodu
+	// bilinmeyen kuruluşlar yönetim panelinden tamamlanabilir.
 	banks := []models.Bank{
-		{Name: "AKBANK T.A.Ş.", IsActive: true},
-		{Name: "AKTİF YATIRIM BANKASI A.Ş.", IsActive: true},
+		{Name: "AKBANK T.A.Ş.", Code: "00046", IsActive: true},
+		{Name: "AKTİF YATIRIM BANKASI A.Ş.", Code: "00143", IsActive: true},
 		{Name: "AHLATCI ÖDEME VE ELEKTRONİK PARA HİZMETLERİ A.Ş.", IsActive: true},
-		{Name: "ALBARAKA TÜRK KATILIM BANKASI A.Ş.", IsActive: true},
-		{Name: "ALTERNATİFBANK A.Ş.", IsActive: true},
-		{Name: "ANADOLUBANK A.Ş.", IsActive: true},
+		{Name: "ALBARAKA TÜRK KATILIM BANKASI A.Ş.", Code: "00203", IsActive: true},
+		{Name: "ALTERNATİFBANK A.Ş.", Code: "00124", IsActive: true},
+		{Name: "ANADOLUBANK A.Ş.", Code: "00135", IsActive: true},
 		{Name: "BELBİM ELEKTRONİK PARA VE ÖDEME HİZMETLERİ A.Ş.", IsActive: true},
-		{Name: "BURGAN BANK A.Ş.", IsActive: true},
-		{Name: "DENİZBANK A.Ş.", IsActive: true},
-		{Name: "DÜNYA KATILIM BANKASI A.Ş.", IsActive: true},
+		{Name: "BURGAN BANK A.Ş.", Code: "00125", IsActive: true},
+		{Name: "DENİZBANK A.Ş.", Code: "00134", IsActive: true},
+		{Name: "DÜNYA KATILIM BANKASI A.Ş.", Code: "00100", IsActive: true},
 		{Name: "ENPARA BANK A.Ş.", IsActive: true},
-		{Name: "FİBABANKA A.Ş.", IsActive: true},
+		{Name: "FİBABANKA A.Ş.", Code: "00103", IsActive: true},
 		{Name: "GOLDEN GLOBAL YATIRIM BANKASI A.Ş.", IsActive: true},
 		{Name: "HAYAT FİNANS KATILIM BANKASI A.Ş.", IsActive: true},
-		{Name: "ING BANK A.Ş.", IsActive: true},
+		{Name: "ING BANK A.Ş.", Code: "00099", IsActive: true},
 		{Name: "İNİNAL ÖDEME VE ELEKTRONİK PARA HİZMETLERİ A.Ş.", IsActive: true},
 		{Name: "İYZİ ÖDEME VE ELEKTRONİK PARA HİZMETLERİ A.Ş.", IsActive: true},
-		{Name: "KUVEYT TÜRK KATILIM BANKASI A.Ş.", IsActive: true},
+		{Name: "KUVEYT TÜRK KATILIM BANKASI A.Ş.", Code: "00205", IsActive: true},
 		{Name: "LYDIANS ELEKTRONİK PARA VE ÖDEME HİZMETLERİ A.Ş.", IsActive: true},
 		{Name: "MİSYON YATIRIM BANKASI A.Ş.", IsActive: true},
 		{Name: "MOKA UNİTED ÖDEME HİZMETLERİ VE ELEKTRONİK PARA KURULUŞU A.Ş.", IsActive: true},
-		{Name: "ODEA BANK A.Ş.", IsActive: true},
+		{Name: "ODEA BANK A.Ş.", Code: "00146", IsActive: true},
 		{Name: "PAPARA ELEKTRONİK PARA A.Ş.", IsActive: true},
 		{Name: "PAROLAPARA ELEKTRONİK PARA VE ÖDEME HİZMETLERİ A.Ş.", IsActive: true},
 		{Name: "PAY FİX ELEKTRONİK PARA VE ÖDEME HİZMETLERİ A.Ş.", IsActive: true},
 		{Name: "POSTA VE TELGRAF TEŞKİLATI A.Ş.", IsActive: true},
-		{Name: "QNB BANK A.Ş.", IsActive: true},
+		{Name: "QNB BANK A.Ş.", Code: "00111", IsActive: true},
 		{Name: "SİPAY ELEKTRONİK PARA VE ÖDEME HİZMETLERİ A.Ş.", IsActive: true},
-		{Name: "ŞEKERBANK T.A.Ş.", IsActive: true},
-		{Name: "T.C. ZİRAAT BANKASI A.Ş.", IsActive: true},
-		{Name: "T. EKONOMİ BANKASI A.Ş.", IsActive: true},
-		{Name: "T. GARANTİ BANKASI A.Ş.", IsActive: true},
-		{Name: "T. HALK BANKASI A.Ş.", IsActive: true},
-		{Name: "T. İŞ BANKASI A.Ş.", IsActive: true},
+		{Name: "ŞEKERBANK T.A.Ş.", Code: "00059", IsActive: true},
+		{Name: "T.C. ZİRAAT BANKASI A.Ş.", Code: "00010", IsActive: true},
+		{Name: "T. EKONOMİ BANKASI A.Ş.", Code: "00032", IsActive: true},
+		{Name: "T. GARANTİ BANKASI A.Ş.", Code: "00062", IsActive: true},
+		{Name: "T. HALK BANKASI A.Ş.", Code: "00012", IsActive: true},
+		{Name: "T. İŞ BANKASI A.Ş.", Code: "00064", IsActive: true},
 		{Name: "T.O.M. KATILIM BANKASI A.Ş.", IsActive: true},
-		{Name: "T. VAKIFLAR BANKASI T.A.O.", IsActive: true},
+		{Name: "T. VAKIFLAR BANKASI T.A.O.", Code: "00015", IsActive: true},
 		{Name: "TURK ELEKTRONİK PARA A.Ş.", IsActive: true},
 		{Name: "TURKCELL ÖDEME VE ELEKTRONİK PARA HİZMETLERİ A.Ş.", IsActive: true},
-		{Name: "TÜRKİYE EMLAK KATILIM BANKASI A.Ş.", IsActive: true},
-		{Name: "TÜRKİYE FİNANS KATILIM BANKASI A.Ş.", IsActive: true},
-		{Name: "VAKIF KATILIM BANKASI A.Ş.", IsActive: true},
-		{Name: "YAPI VE KREDİ BANKASI A.Ş.", IsActive: true},
-		{Name: "ZİRAAT KATILIM BANKASI A.Ş.", IsActive: true},
+		{Name: "TÜRKİYE EMLAK KATILIM BANKASI A.Ş.", Code: "00211", IsActive: true},
+		{Name: "TÜRKİYE FİNANS KATILIM BANKASI A.Ş.", Code: "00206", IsActive: true},
+		{Name: "VAKIF KATILIM BANKASI A.Ş.", Code: "00210", IsActive: true},
+		{Name: "YAPI VE KREDİ BANKASI A.Ş.", Code: "00067", IsActive: true},
+		{Name: "ZİRAAT KATILIM BANKASI A.Ş.", Code: "00209", IsActive: true},
 	}
 
 	logconfig.SLog.Info("Banka verileri yükleniyor...")
@@ -61,13 +62,20 @@ func SeedBanks(db *gorm.DB) error {
 	for _, bank := range banks {
 		// Banka zaten var mı kontrol et
 		var existingBank models.Bank
-		if err := db.Where("name = ?", bank.Name).First(&existingBank).Error; err == gorm.ErrRecordNotFound {
+		err := db.Where("name = ?", bank.Name).First(&existingBank).Error
+		if err == gorm.ErrRecordNotFound {
 			// Banka yoksa ekle
 			if err := db.Create(&bank).Error; err != nil {
 				logconfig.SLog.Error("Banka eklenirken hata: " + bank.Name)
 				return err
 			}
 			logconfig.SLog.Info("Banka eklendi: " + bank.Name)
+		} else if err == nil && existingBank.Code == "" && bank.Code != "" {
+			// Banka kodu sütunu sonradan eklendiği için mevcut kayıtlar tamamlanır
+			if err := db.Model(&existingBank).Update("code", bank.Code).Error; err != nil {
+				logconfig.SLog.Error("Banka kodu güncellenirken hata: " + bank.Name)
+				return err
+			}
 		}
 	}
 
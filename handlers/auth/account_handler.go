@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/pkg/flashmessages"
+	"zatrano/requests"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *AuthHandler) ExportData(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	var buf bytes.Buffer
+	if err := h.accountService.ExportData(c.UserContext(), userID, &buf); err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return h.handleError(c, err, userID, "", "Veri Dışa Aktarımı")
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Verileriniz hazırlanamadı. Lütfen daha sonra tekrar deneyin.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	fileName := fmt.Sprintf("zatrano-verilerim-%s.zip", time.Now().Format("20060102"))
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+fileName+`"`)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Send(buf.Bytes())
+}
+
+func (h *AuthHandler) RequestAccountDeletion(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	req, ok := c.Locals("deleteAccountRequest").(requests.DeleteAccountRequest)
+	if !ok {
+		logconfig.SLog.Warn("Hesap silme: Geçersiz istek formatı")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	scheduledAt, err := h.accountService.RequestDeletion(c.UserContext(), userID, req.Confirmation)
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrDeletionConfirmationInvalid):
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Onay bilgisi hatalı. Hesabınız silinmek üzere işaretlenmedi.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	case errors.Is(err, services.ErrDeletionAlreadyRequested):
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Hesabınız için zaten bir silme talebi bulunuyor.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	default:
+		return h.handleError(c, err, userID, "", "Hesap Silme Talebi")
+	}
+
+	h.destroySession(c)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, fmt.Sprintf(
+		"Hesabınız %s tarihinde kalıcı olarak silinecek. Bu tarihe kadar giriş yapıp profilinizden talebi iptal edebilirsiniz.",
+		scheduledAt.Local().Format("02.01.2006 15:04"),
+	))
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) CancelAccountDeletion(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.accountService.CancelDeletion(c.UserContext(), userID); err != nil && !errors.Is(err, services.ErrNoPendingDeletion) {
+		return h.handleError(c, err, userID, "", "Hesap Silme İptali")
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Hesap silme talebiniz iptal edildi.")
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
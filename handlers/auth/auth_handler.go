@@ -1,12 +1,16 @@
 package handlers
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"errors"
+	"fmt"
 	"net/http"
-	"os"
+	"net/url"
+	"strings"
+	"time"
 
 	"zatrano/configs/logconfig"
+	"zatrano/configs/oauthconfig"
+	"zatrano/configs/passwordconfig"
 	"zatrano/configs/sessionconfig"
 	"zatrano/models"
 	"zatrano/pkg/flashmessages"
@@ -15,18 +19,29 @@ import (
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
 	"go.uber.org/zap"
 )
 
 type AuthHandler struct {
-	service    services.IAuthService
-	mailSender services.IMailService
+	service          services.IAuthService
+	twoFactorService services.ITwoFactorService
+	sessionService   services.ISessionService
+	rememberService  services.IRememberMeService
+	accountService   services.IAccountService
 }
 
+// Giriş formundaki "beni hatırla" seçimi, iki adımlı doğrulama araya girse de
+// girişin tamamlandığı ana kadar oturumda taşınır.
+const rememberRequestedKey = "remember_me_requested"
+
 func NewAuthHandler() *AuthHandler {
 	return &AuthHandler{
-		service:    services.NewAuthService(),
-		mailSender: services.NewMailService(),
+		service:          services.NewAuthService(),
+		twoFactorService: services.NewTwoFactorService(),
+		sessionService:   services.NewSessionService(),
+		rememberService:  services.NewRememberMeService(),
+		accountService:   services.NewAccountService(),
 	}
 }
 
@@ -50,6 +65,12 @@ func (h *AuthHandler) handleError(c *fiber.Ctx, err error, userID uint, email st
 		services.ErrUserInactive: {
 			message: "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin.",
 		},
+		services.ErrAccountLocked: {
+			message: "Çok sayıda başarısız giriş denemesi nedeniyle hesabınız geçici olarak kilitlendi. Lütfen daha sonra tekrar deneyin veya şifrenizi sıfırlayın.",
+		},
+		services.ErrLoginThrottled: {
+			message: "Çok fazla başarısız giriş denemesi yapıldı. Lütfen biraz bekleyip tekrar deneyin.",
+		},
 		services.ErrUserNotFound: {
 			message:      "Kullanıcı bulunamadı, lütfen tekrar giriş yapın.",
 			shouldLogout: true,
@@ -61,17 +82,17 @@ func (h *AuthHandler) handleError(c *fiber.Ctx, err error, userID uint, email st
 			message:  "Mevcut şifreniz hatalı.",
 			redirect: "/auth/profile",
 		},
-		services.ErrPasswordTooShort: {
-			message:  "Şifre çok kısa.",
-			redirect: "/auth/profile",
-		},
-		services.ErrPasswordSameAsOld: {
-			message:  "Yeni şifre eski şifre ile aynı olamaz.",
+		services.ErrEmailInUse: {
+			message:  "Bu e-posta adresi başka bir hesap tarafından kullanılıyor.",
 			redirect: "/auth/profile",
 		},
 	}
 
-	if customErr, ok := errorMessages[err]; ok {
+	var policyErr *services.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		errMsg = policyErr.Error()
+		redirectTarget = "/auth/profile"
+	} else if customErr, ok := errorMessages[err]; ok {
 		errMsg = customErr.message
 		if customErr.redirect != "" {
 			redirectTarget = customErr.redirect
@@ -93,6 +114,10 @@ func (h *AuthHandler) handleError(c *fiber.Ctx, err error, userID uint, email st
 	return c.Redirect(redirectTarget, fiber.StatusSeeOther)
 }
 
+func passwordRequirements() string {
+	return strings.Join(passwordconfig.GetPolicy().Requirements(), ", ") + "."
+}
+
 func (h *AuthHandler) getSessionUser(c *fiber.Ctx) (uint, error) {
 	if userID, ok := c.Locals("userID").(uint); ok {
 		return userID, nil
@@ -122,6 +147,8 @@ func (h *AuthHandler) destroySession(c *fiber.Ctx) {
 		logconfig.Log.Warn("Oturum yok edilemedi (zaten yok olabilir)", zap.Error(err))
 		return
 	}
+	h.sessionService.Forget(sess.ID())
+	sessionconfig.ClearRememberCookie(c)
 	if err := sess.Destroy(); err != nil {
 		logconfig.Log.Error("Oturum yok edilemedi", zap.Error(err))
 	}
@@ -144,6 +171,7 @@ func (h *AuthHandler) ShowLogin(c *fiber.Ctx) error {
 	return renderer.Render(c, "auth/login", "layouts/auth", fiber.Map{
 		"Title":               "Giriş",
 		"PendingVerification": pendingVerification,
+		"Providers":           oauthconfig.Providers(),
 	}, http.StatusOK)
 }
 
@@ -154,7 +182,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	user, err := h.service.Authenticate(req.Email, req.Password)
+	user, err := h.service.Authenticate(req.Email, req.Password, c.IP())
 	if err != nil {
 		return h.handleError(c, err, 0, req.Email, "Login")
 	}
@@ -168,6 +196,27 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return h.handleError(c, fiber.ErrInternalServerError, user.ID, user.Email, "Login")
 	}
 
+	sess.Set(rememberRequestedKey, req.RememberMe)
+
+	if user.TwoFactorEnabled {
+		return beginTwoFactorChallenge(c, sess, user)
+	}
+
+	return h.completeLogin(c, sess, user)
+}
+
+// completeLogin, kimliği doğrulanmış kullanıcı için oturumu açar ve kullanıcı
+// tipine göre yönlendirir.
+func (h *AuthHandler) completeLogin(c *fiber.Ctx, sess *session.Session, user *models.User) error {
+	remember, _ := sess.Get(rememberRequestedKey).(bool)
+	sess.Delete(rememberRequestedKey)
+
+	// Giriş öncesi oturum kimliği giriş sonrasında kullanılmamalıdır
+	// (session fixation).
+	if err := sess.Regenerate(); err != nil {
+		logconfig.Log.Error("Oturum kimliği yenilenemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return h.handleError(c, fiber.ErrInternalServerError, user.ID, user.Email, "Login")
+	}
 	sess.Set("user_id", user.ID)
 	sess.Set("user_type", string(user.Type))
 	if err := sess.Save(); err != nil {
@@ -178,6 +227,19 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return h.handleError(c, fiber.ErrInternalServerError, user.ID, user.Email, "Login")
 	}
 
+	if err := h.sessionService.Register(c.UserContext(), user.ID, sess.ID(), c.IP(), c.Get(fiber.HeaderUserAgent)); err != nil {
+		logconfig.Log.Warn("Oturum kaydı oluşturulamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
+	if remember {
+		token, expiresAt, err := h.rememberService.Issue(c.UserContext(), user.ID, c.IP(), c.Get(fiber.HeaderUserAgent))
+		if err != nil {
+			logconfig.Log.Warn("Hatırlama tokenı oluşturulamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		} else {
+			sessionconfig.SetRememberCookie(c, token, expiresAt)
+		}
+	}
+
 	redirectPaths := map[models.UserType]string{
 		models.Panel:     "/panel/home",
 		models.Dashboard: "/dashboard/home",
@@ -207,13 +269,71 @@ func (h *AuthHandler) Profile(c *fiber.Ctx) error {
 		return h.handleError(c, err, userID, "", "Profil")
 	}
 
+	var recoveryCodes int64
+	if user.TwoFactorEnabled {
+		recoveryCodes, _ = h.twoFactorService.RemainingRecoveryCodes(userID)
+	}
+
+	var currentSessionID string
+	if sess, err := sessionconfig.SessionStart(c); err == nil {
+		currentSessionID = sess.ID()
+	}
+	activeSessions, _ := h.sessionService.ListActive(userID, currentSessionID)
+
+	linkedProvider := user.Provider
+	if provider, ok := oauthconfig.Get(user.Provider); ok {
+		linkedProvider = provider.DisplayName()
+	}
+
+	var deletionScheduledAt time.Time
+	if user.DeletionScheduledAt != nil {
+		deletionScheduledAt = *user.DeletionScheduledAt
+	}
+
 	return renderer.Render(c, "auth/profile", "layouts/auth", fiber.Map{
-		"Title": "Profilim",
-		"User":  user,
+		"Title":                "Profilim",
+		"User":                 user,
+		"RecoveryCodes":        recoveryCodes,
+		"TwoFactorRequired":    h.twoFactorService.IsRequiredFor(user),
+		"LinkedProvider":       linkedProvider,
+		"Providers":            oauthconfig.Providers(),
+		"Sessions":             activeSessions,
+		"PasswordRequirements": passwordRequirements(),
+		"DeletionScheduledAt":  deletionScheduledAt,
 	}, http.StatusOK)
 }
 
+func (h *AuthHandler) RevokeOtherSessions(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return h.handleError(c, err, userID, "", "Oturumları Sonlandırma")
+	}
+
+	count, err := h.sessionService.RevokeOthers(c.UserContext(), userID, sess.ID())
+	if err == nil {
+		// Diğer cihazların hatırlama çerezleri de oturumu yeniden açamamalı.
+		err = h.rememberService.RevokeAll(c.UserContext(), userID, c.Cookies(sessionconfig.RememberCookieName))
+	}
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Oturumlar sonlandırılamadı. Lütfen tekrar deneyin.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, fmt.Sprintf("Diğer %d oturum sonlandırıldı.", count))
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
+
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	if rawToken := c.Cookies(sessionconfig.RememberCookieName); rawToken != "" {
+		h.rememberService.Revoke(c.UserContext(), rawToken)
+	}
 	h.destroySession(c)
 	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Başarıyla çıkış yapıldı.")
 	return c.Redirect("/auth/login", fiber.StatusFound)
@@ -245,18 +365,12 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 
 func (h *AuthHandler) ShowRegister(c *fiber.Ctx) error {
 	return renderer.Render(c, "auth/register", "layouts/auth", fiber.Map{
-		"Title": "Kayıt Ol",
+		"Title":                "Kayıt Ol",
+		"Providers":            oauthconfig.Providers(),
+		"PasswordRequirements": passwordRequirements(),
 	}, http.StatusOK)
 }
 
-func generateToken() (string, error) {
-	tokenBytes := make([]byte, 16)
-	if _, err := rand.Read(tokenBytes); err != nil {
-		return "", err
-	}
-	return hex.EncodeToString(tokenBytes), nil
-}
-
 func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	req, ok := c.Locals("registerRequest").(requests.RegisterRequest)
 	if !ok {
@@ -272,32 +386,22 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		Type:     models.Panel,
 	}
 
-	resetToken, err := generateToken()
-	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Reset token oluşturulamadı")
-		return c.Redirect("/auth/register", fiber.StatusSeeOther)
-	}
-	user.ResetToken = resetToken
-
-	verificationToken, err := generateToken()
-	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Verification token oluşturulamadı")
-		return c.Redirect("/auth/register", fiber.StatusSeeOther)
-	}
-	user.VerificationToken = verificationToken
-
 	ctx := c.UserContext()
 	if err := h.service.CreateUser(ctx, user); err != nil {
+		var policyErr *services.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, policyErr.Error())
+			return c.Redirect("/auth/register", fiber.StatusSeeOther)
+		}
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kullanıcı oluşturulamadı. Lütfen tekrar deneyin.")
 		return c.Redirect("/auth/register", fiber.StatusSeeOther)
 	}
 
 	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kayıt işlemi başarıyla tamamlandı. Lütfen email adresinizi doğrulayın.")
 
-	baseURL := os.Getenv("APP_BASE_URL")
-	verificationLink := baseURL + "/auth/verify-email?token=" + verificationToken
-	emailBody := "Lütfen email adresinizi doğrulamak için aşağıdaki bağlantıya tıklayın: " + verificationLink
-	_ = h.mailSender.SendMail(user.Email, "Email Doğrulama", emailBody)
+	if err := h.service.SendVerificationLink(user); err != nil {
+		logconfig.Log.Warn("Kayıt sonrası doğrulama e-postası gönderilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
 
 	return renderer.Render(c, "auth/verify_email_notice", "layouts/auth", nil, http.StatusOK)
 }
@@ -316,6 +420,10 @@ func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.SendPasswordResetLink(req.Email); err != nil {
+		if errors.Is(err, services.ErrTooManyRequests) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Çok fazla şifre sıfırlama isteği gönderildi. Lütfen daha sonra tekrar deneyin.")
+			return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
+		}
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Şifre sıfırlama bağlantısı gönderilemedi. Lütfen tekrar deneyin.")
 		return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
 	}
@@ -332,8 +440,9 @@ func (h *AuthHandler) ShowResetPassword(c *fiber.Ctx) error {
 	}
 
 	return renderer.Render(c, "auth/reset_password", "layouts/auth", fiber.Map{
-		"Title": "Şifre Sıfırla",
-		"Token": token,
+		"Title":                "Şifre Sıfırla",
+		"Token":                token,
+		"PasswordRequirements": passwordRequirements(),
 	}, http.StatusOK)
 }
 
@@ -345,8 +454,17 @@ func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.ResetPassword(req.Token, req.NewPassword); err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Şifre sıfırlama işlemi başarısız oldu.")
-		return c.Redirect("/auth/reset-password", fiber.StatusSeeOther)
+		if errors.Is(err, services.ErrTokenInvalid) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Şifre sıfırlama bağlantısı geçersiz veya süresi dolmuş. Lütfen yeni bir bağlantı isteyin.")
+			return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
+		}
+		var policyErr *services.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, policyErr.Error())
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Şifre sıfırlama işlemi başarısız oldu.")
+		}
+		return c.Redirect("/auth/reset-password?token="+url.QueryEscape(req.Token), fiber.StatusSeeOther)
 	}
 
 	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Şifreniz başarıyla sıfırlandı. Lütfen giriş yapın.")
@@ -361,6 +479,10 @@ func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.VerifyEmail(token); err != nil {
+		if errors.Is(err, services.ErrTokenInvalid) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Doğrulama bağlantısı geçersiz veya süresi dolmuş. Lütfen yeni bir bağlantı isteyin.")
+			return c.Redirect("/auth/resend-verification", fiber.StatusSeeOther)
+		}
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Email doğrulama başarısız.")
 		return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
 	}
@@ -383,6 +505,10 @@ func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.ResendVerificationLink(req.Email); err != nil {
+		if errors.Is(err, services.ErrTooManyRequests) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Çok fazla doğrulama isteği gönderildi. Lütfen daha sonra tekrar deneyin.")
+			return c.Redirect("/auth/resend-verification", fiber.StatusSeeOther)
+		}
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Doğrulama linki gönderilemedi.")
 		return c.Redirect("/auth/resend-verification", fiber.StatusSeeOther)
 	}
@@ -406,10 +532,59 @@ func (h *AuthHandler) UpdateInfo(c *fiber.Ctx) error {
 		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 	}
 
-	if err := h.service.UpdateUserInfo(c.UserContext(), userID, req.Name, req.Email); err != nil {
+	if err := h.service.UpdateUserInfo(c.UserContext(), userID, req.Name); err != nil {
 		return h.handleError(c, err, userID, req.Email, "Profil Bilgileri Güncelleme")
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Profil bilgileri başarıyla güncellendi.")
+	err = h.service.RequestEmailChange(c.UserContext(), userID, req.Email)
+	switch {
+	case err == nil:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Profil bilgileri güncellendi. E-posta adresinizin değişmesi için yeni adresinize gönderilen bağlantıyı onaylayın.")
+	case errors.Is(err, services.ErrEmailUnchanged):
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Profil bilgileri başarıyla güncellendi.")
+	case errors.Is(err, services.ErrTooManyRequests):
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Çok fazla e-posta değişikliği isteği gönderildi. Lütfen daha sonra tekrar deneyin.")
+	default:
+		return h.handleError(c, err, userID, req.Email, "E-posta Değişikliği")
+	}
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) ConfirmEmailChange(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Onay bağlantısı eksik veya geçersiz.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.ConfirmEmailChange(token); err != nil {
+		switch {
+		case errors.Is(err, services.ErrTokenInvalid), errors.Is(err, services.ErrNoPendingEmailChange):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Onay bağlantısı geçersiz veya süresi dolmuş. Lütfen profilinizden yeniden talep edin.")
+		case errors.Is(err, services.ErrEmailInUse):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Bu e-posta adresi başka bir hesap tarafından kullanılıyor.")
+		default:
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "E-posta adresi değiştirilemedi. Lütfen tekrar deneyin.")
+		}
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "E-posta adresiniz başarıyla değiştirildi.")
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) CancelEmailChange(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.CancelEmailChange(c.UserContext(), userID); err != nil && !errors.Is(err, services.ErrNoPendingEmailChange) {
+		return h.handleError(c, err, userID, "", "E-posta Değişikliği İptali")
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Bekleyen e-posta değişikliği iptal edildi.")
 	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 }
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/url"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/oauthconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/oauth"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"go.uber.org/zap"
+)
+
+const (
+	oauthStateKey      = "oauth_state"
+	oauthVerifierKey   = "oauth_verifier"
+	oauthNonceKey      = "oauth_nonce"
+	oauthProviderKey   = "oauth_provider"
+	oauthLinkUserIDKey = "oauth_link_user_id"
+)
+
+func clearOAuthFlow(sess *session.Session) {
+	sess.Delete(oauthStateKey)
+	sess.Delete(oauthVerifierKey)
+	sess.Delete(oauthNonceKey)
+	sess.Delete(oauthProviderKey)
+	sess.Delete(oauthLinkUserIDKey)
+}
+
+func (h *AuthHandler) oauthFailure(c *fiber.Ctx, redirect, message string) error {
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+	return c.Redirect(redirect, fiber.StatusSeeOther)
+}
+
+// OAuthLogin, sağlayıcıya yönlendirmeden önce state, PKCE doğrulayıcısı ve
+// nonce değerlerini oturuma yazar. ?link=1 ile çağrıldığında akış, oturumdaki
+// kullanıcının hesabına bağlama olarak sonuçlanır.
+func (h *AuthHandler) OAuthLogin(c *fiber.Ctx) error {
+	provider, ok := oauthconfig.Get(c.Params("provider"))
+	if !ok {
+		return h.oauthFailure(c, "/auth/login", "Bu giriş yöntemi kullanılamıyor.")
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return h.oauthFailure(c, "/auth/login", "Oturum başlatılamadı.")
+	}
+
+	var linkUserID uint
+	if c.Query("link") == "1" {
+		linkUserID, err = h.getSessionUser(c)
+		if err != nil {
+			return h.oauthFailure(c, "/auth/login", "Hesap bağlamak için önce giriş yapmalısınız.")
+		}
+	}
+
+	flow, err := oauth.NewFlow()
+	if err != nil {
+		logconfig.Log.Error("OAuth akışı oluşturulamadı", zap.Error(err))
+		return h.oauthFailure(c, "/auth/login", "İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin.")
+	}
+
+	authURL, err := provider.AuthCodeURL(c.UserContext(), flow)
+	if err != nil {
+		logconfig.Log.Error("OAuth yönlendirme adresi oluşturulamadı",
+			zap.String("provider", provider.Name()),
+			zap.Error(err))
+		return h.oauthFailure(c, "/auth/login", provider.DisplayName()+" şu anda kullanılamıyor.")
+	}
+
+	sess.Set(oauthStateKey, flow.State)
+	sess.Set(oauthVerifierKey, flow.Verifier)
+	sess.Set(oauthNonceKey, flow.Nonce)
+	sess.Set(oauthProviderKey, provider.Name())
+	sess.Delete(rememberRequestedKey)
+	if linkUserID != 0 {
+		sess.Set(oauthLinkUserIDKey, linkUserID)
+	} else {
+		sess.Delete(oauthLinkUserIDKey)
+	}
+	if err := sess.Save(); err != nil {
+		return h.oauthFailure(c, "/auth/login", "Oturum kaydedilemedi.")
+	}
+
+	return c.Redirect(authURL, fiber.StatusSeeOther)
+}
+
+// OAuthFormPostCallback, yanıtı form_post ile gönderen sağlayıcılar (Apple)
+// içindir. Siteler arası POST'ta Lax oturum çerezi gönderilmediğinden
+// parametreler GET geri dönüş adresine taşınır.
+func (h *AuthHandler) OAuthFormPostCallback(c *fiber.Ctx) error {
+	query := url.Values{}
+	for _, key := range []string{"code", "state", "user", "error"} {
+		if value := c.FormValue(key); value != "" {
+			query.Set(key, value)
+		}
+	}
+	return c.Redirect("/auth/"+url.PathEscape(c.Params("provider"))+"/callback?"+query.Encode(), fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) OAuthCallback(c *fiber.Ctx) error {
+	provider, ok := oauthconfig.Get(c.Params("provider"))
+	if !ok {
+		return h.oauthFailure(c, "/auth/login", "Bu giriş yöntemi kullanılamıyor.")
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return h.oauthFailure(c, "/auth/login", "Oturum başlatılamadı.")
+	}
+
+	savedState, _ := sess.Get(oauthStateKey).(string)
+	savedProvider, _ := sess.Get(oauthProviderKey).(string)
+	flow := oauth.Flow{State: savedState}
+	flow.Verifier, _ = sess.Get(oauthVerifierKey).(string)
+	flow.Nonce, _ = sess.Get(oauthNonceKey).(string)
+	linkUserID, _ := sess.Get(oauthLinkUserIDKey).(uint)
+
+	// State tek kullanımlıktır; doğrulama sonucundan bağımsız olarak silinir.
+	clearOAuthFlow(sess)
+	_ = sess.Save()
+
+	failRedirect := "/auth/login"
+	if linkUserID != 0 {
+		failRedirect = "/auth/profile"
+	}
+
+	state := c.Query("state")
+	if state == "" || savedState == "" || savedProvider != provider.Name() ||
+		subtle.ConstantTimeCompare([]byte(state), []byte(savedState)) != 1 {
+		logconfig.Log.Warn("OAuth state doğrulaması başarısız",
+			zap.String("provider", provider.Name()),
+			zap.String("ip", c.IP()))
+		return h.oauthFailure(c, failRedirect, "Geçersiz veya süresi dolmuş istek. Lütfen tekrar deneyin.")
+	}
+
+	if c.Query("error") != "" || c.Query("code") == "" {
+		return h.oauthFailure(c, failRedirect, provider.DisplayName()+" ile işlem iptal edildi.")
+	}
+
+	identity, err := provider.Exchange(c.UserContext(), oauth.Callback{
+		Code: c.Query("code"),
+		User: c.Query("user"),
+	}, flow)
+	if err != nil {
+		logconfig.Log.Error("OAuth kimlik doğrulaması başarısız",
+			zap.String("provider", provider.Name()),
+			zap.Error(err))
+		return h.oauthFailure(c, failRedirect, provider.DisplayName()+" ile kimlik doğrulanamadı.")
+	}
+
+	if linkUserID != 0 {
+		if err := h.service.LinkOAuthProvider(c.UserContext(), linkUserID, identity); err != nil {
+			return h.handleOAuthError(c, err, failRedirect, provider)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, provider.DisplayName()+" hesabınız bağlandı.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	user, err := h.service.LoginWithOAuth(c.UserContext(), identity)
+	if err != nil {
+		return h.handleOAuthError(c, err, failRedirect, provider)
+	}
+
+	if user.TwoFactorEnabled {
+		return beginTwoFactorChallenge(c, sess, user)
+	}
+
+	return h.completeLogin(c, sess, user)
+}
+
+func (h *AuthHandler) UnlinkProvider(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		return h.oauthFailure(c, "/auth/login", "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+	}
+
+	if err := h.service.UnlinkOAuthProvider(c.UserContext(), userID); err != nil {
+		return h.handleOAuthError(c, err, "/auth/profile", nil)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Sağlayıcı bağlantısı kaldırıldı.")
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) handleOAuthError(c *fiber.Ctx, err error, redirect string, provider oauth.Provider) error {
+	messages := map[error]string{
+		services.ErrOAuthEmailMissing:     "Sağlayıcı e-posta adresinizi paylaşmadı. Lütfen e-posta izni vererek tekrar deneyin.",
+		services.ErrOAuthEmailNotVerified: "Sağlayıcıdaki e-posta adresiniz doğrulanmamış. Lütfen e-posta ve şifrenizle giriş yapın.",
+		services.ErrOAuthLinkedElsewhere:  "Bu e-posta adresi başka bir giriş yöntemine bağlı. Lütfen o yöntemle giriş yapın.",
+		services.ErrOAuthIdentityInUse:    "Bu sağlayıcı hesabı başka bir kullanıcıya bağlı.",
+		services.ErrOAuthAlreadyLinked:    "Hesabınız zaten bir sağlayıcıya bağlı. Önce mevcut bağlantıyı kaldırın.",
+		services.ErrOAuthNotLinked:        "Hesabınız herhangi bir sağlayıcıya bağlı değil.",
+		services.ErrOAuthUnlinkNoPassword: "Bağlantıyı kaldırmadan önce 'Şifremi Unuttum' adımıyla bir şifre belirlemelisiniz.",
+		services.ErrUserInactive:          "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin.",
+	}
+
+	var svcErr services.ServiceError
+	if errors.As(err, &svcErr) {
+		if message, ok := messages[svcErr]; ok {
+			return h.oauthFailure(c, redirect, message)
+		}
+	}
+
+	fields := []zap.Field{zap.Error(err)}
+	if provider != nil {
+		fields = append(fields, zap.String("provider", provider.Name()))
+	}
+	logconfig.Log.Error("OAuth: Beklenmeyen hata", fields...)
+	return h.oauthFailure(c, redirect, "İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin.")
+}
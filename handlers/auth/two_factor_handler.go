@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/models"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/qrcode"
+	"zatrano/pkg/renderer"
+	"zatrano/requests"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"go.uber.org/zap"
+)
+
+const (
+	twoFactorPendingUserKey = "two_factor_user_id"
+	twoFactorPendingAtKey   = "two_factor_started_at"
+	twoFactorAttemptsKey    = "two_factor_attempts"
+	twoFactorSetupSecretKey = "two_factor_setup_secret"
+
+	twoFactorChallengeTTL = 5 * time.Minute
+	twoFactorMaxAttempts  = 5
+)
+
+// beginTwoFactorChallenge, parolası doğrulanan kullanıcıyı oturuma user_id
+// yazmadan ikinci adıma yönlendirir.
+func beginTwoFactorChallenge(c *fiber.Ctx, sess *session.Session, user *models.User) error {
+	sess.Set(twoFactorPendingUserKey, user.ID)
+	sess.Set(twoFactorPendingAtKey, time.Now().Unix())
+	sess.Set(twoFactorAttemptsKey, 0)
+	if err := sess.Save(); err != nil {
+		logconfig.Log.Error("İki adımlı doğrulama oturumu kaydedilemedi",
+			zap.Uint("user_id", user.ID),
+			zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	return c.Redirect("/auth/two-factor", fiber.StatusSeeOther)
+}
+
+func clearTwoFactorChallenge(sess *session.Session) {
+	sess.Delete(twoFactorPendingUserKey)
+	sess.Delete(twoFactorPendingAtKey)
+	sess.Delete(twoFactorAttemptsKey)
+}
+
+func pendingTwoFactorUser(sess *session.Session) (uint, bool) {
+	userID, ok := sess.Get(twoFactorPendingUserKey).(uint)
+	if !ok || userID == 0 {
+		return 0, false
+	}
+	startedAt, ok := sess.Get(twoFactorPendingAtKey).(int64)
+	if !ok || time.Since(time.Unix(startedAt, 0)) > twoFactorChallengeTTL {
+		return 0, false
+	}
+	return userID, true
+}
+
+func (h *AuthHandler) ShowTwoFactorChallenge(c *fiber.Ctx) error {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+	if _, ok := pendingTwoFactorUser(sess); !ok {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Doğrulama süresi doldu, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "auth/two_factor", "layouts/auth", fiber.Map{
+		"Title": "İki Adımlı Doğrulama",
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) VerifyTwoFactorChallenge(c *fiber.Ctx) error {
+	req, ok := c.Locals("twoFactorCodeRequest").(requests.TwoFactorCodeRequest)
+	if !ok {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek")
+		return c.Redirect("/auth/two-factor", fiber.StatusSeeOther)
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	userID, ok := pendingTwoFactorUser(sess)
+	if !ok {
+		clearTwoFactorChallenge(sess)
+		_ = sess.Save()
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Doğrulama süresi doldu, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.twoFactorService.Verify(c.UserContext(), userID, req.Code); err != nil {
+		if errors.Is(err, services.ErrAccountLocked) {
+			logconfig.Log.Warn("İki adımlı doğrulama kilitli hesapta denendi",
+				zap.Uint("user_id", userID),
+				zap.String("ip", c.IP()))
+			clearTwoFactorChallenge(sess)
+			_ = sess.Save()
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Çok fazla hatalı doğrulama kodu girildi. Hesabınız geçici olarak kilitlendi.")
+			return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		}
+
+		attempts, _ := sess.Get(twoFactorAttemptsKey).(int)
+		attempts++
+		logconfig.Log.Warn("İki adımlı doğrulama başarısız",
+			zap.Uint("user_id", userID),
+			zap.Int("attempts", attempts),
+			zap.String("ip", c.IP()),
+			zap.Error(err))
+
+		if attempts >= twoFactorMaxAttempts {
+			clearTwoFactorChallenge(sess)
+			_ = sess.Save()
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Çok fazla hatalı deneme yapıldı, lütfen tekrar giriş yapın.")
+			return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		}
+
+		sess.Set(twoFactorAttemptsKey, attempts)
+		_ = sess.Save()
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Doğrulama kodu hatalı.")
+		return c.Redirect("/auth/two-factor", fiber.StatusSeeOther)
+	}
+
+	user, err := h.service.GetUserProfile(userID)
+	if err != nil {
+		clearTwoFactorChallenge(sess)
+		_ = sess.Save()
+		return h.handleError(c, err, userID, "", "TwoFactor")
+	}
+
+	clearTwoFactorChallenge(sess)
+	return h.completeLogin(c, sess, user)
+}
+
+func (h *AuthHandler) ShowTwoFactorSetup(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	user, err := h.service.GetUserProfile(userID)
+	if err != nil {
+		return h.handleError(c, err, userID, "", "TwoFactorSetup")
+	}
+	if user.TwoFactorEnabled {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İki adımlı doğrulama zaten etkin.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return h.handleError(c, err, userID, user.Email, "TwoFactorSetup")
+	}
+
+	// Sayfa yenilendiğinde kullanıcının uygulamaya eklediği anahtar geçersiz
+	// kalmasın diye bekleyen anahtar oturumda tutulur.
+	var enrollment *services.TwoFactorEnrollment
+	if secret, ok := sess.Get(twoFactorSetupSecretKey).(string); ok && secret != "" {
+		enrollment = h.twoFactorService.EnrollmentFor(user, secret)
+	} else {
+		enrollment, err = h.twoFactorService.NewEnrollment(user)
+		if err != nil {
+			return h.handleTwoFactorError(c, err, userID)
+		}
+		sess.Set(twoFactorSetupSecretKey, enrollment.Secret)
+		if err := sess.Save(); err != nil {
+			return h.handleError(c, err, userID, user.Email, "TwoFactorSetup")
+		}
+	}
+
+	qrImage, err := qrcode.PNGDataURI(enrollment.URI, qrcode.DefaultSize)
+	if err != nil {
+		logconfig.Log.Error("QR kod oluşturulamadı", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	return renderer.Render(c, "auth/two_factor_setup", "layouts/auth", fiber.Map{
+		"Title":    "İki Adımlı Doğrulama Kurulumu",
+		"Secret":   enrollment.Secret,
+		"URI":      enrollment.URI,
+		"QRImage":  qrImage,
+		"Required": h.twoFactorService.IsRequiredFor(user),
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) EnableTwoFactor(c *fiber.Ctx) error {
+	req, ok := c.Locals("twoFactorCodeRequest").(requests.TwoFactorCodeRequest)
+	if !ok {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek")
+		return c.Redirect("/auth/profile/two-factor/setup", fiber.StatusSeeOther)
+	}
+
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return h.handleError(c, err, userID, "", "EnableTwoFactor")
+	}
+	secret, ok := sess.Get(twoFactorSetupSecretKey).(string)
+	if !ok || secret == "" {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kurulum süresi doldu, lütfen tekrar deneyin.")
+		return c.Redirect("/auth/profile/two-factor/setup", fiber.StatusSeeOther)
+	}
+
+	codes, err := h.twoFactorService.Enable(c.UserContext(), userID, secret, req.Code)
+	if err != nil {
+		if errors.Is(err, services.ErrTwoFactorInvalidCode) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Doğrulama kodu hatalı. Uygulamadaki güncel kodu girin.")
+			return c.Redirect("/auth/profile/two-factor/setup", fiber.StatusSeeOther)
+		}
+		return h.handleTwoFactorError(c, err, userID)
+	}
+
+	sess.Delete(twoFactorSetupSecretKey)
+	_ = sess.Save()
+
+	return renderer.Render(c, "auth/two_factor_recovery_codes", "layouts/auth", fiber.Map{
+		"Title":                      "Kurtarma Kodları",
+		"Codes":                      codes,
+		renderer.FlashSuccessKeyView: "İki adımlı doğrulama etkinleştirildi.",
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *fiber.Ctx) error {
+	req, ok := c.Locals("twoFactorCodeRequest").(requests.TwoFactorCodeRequest)
+	if !ok {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.twoFactorService.Disable(c.UserContext(), userID, req.Code); err != nil {
+		return h.handleTwoFactorError(c, err, userID)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "İki adımlı doğrulama kapatıldı.")
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	req, ok := c.Locals("twoFactorCodeRequest").(requests.TwoFactorCodeRequest)
+	if !ok {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(c.UserContext(), userID, req.Code)
+	if err != nil {
+		return h.handleTwoFactorError(c, err, userID)
+	}
+
+	return renderer.Render(c, "auth/two_factor_recovery_codes", "layouts/auth", fiber.Map{
+		"Title":                      "Kurtarma Kodları",
+		"Codes":                      codes,
+		renderer.FlashSuccessKeyView: "Yeni kurtarma kodları oluşturuldu. Eski kodlar artık geçersiz.",
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) handleTwoFactorError(c *fiber.Ctx, err error, userID uint) error {
+	messages := map[error]string{
+		services.ErrTwoFactorInvalidCode:    "Doğrulama kodu hatalı.",
+		services.ErrTwoFactorAlreadyEnabled: "İki adımlı doğrulama zaten etkin.",
+		services.ErrTwoFactorNotEnabled:     "İki adımlı doğrulama etkin değil.",
+		services.ErrTwoFactorRequired:       "Hesabınız için iki adımlı doğrulama zorunludur, kapatılamaz.",
+		services.ErrAccountLocked:           "Çok fazla hatalı doğrulama kodu girildi. Lütfen daha sonra tekrar deneyin.",
+	}
+
+	msg, ok := messages[err]
+	if !ok {
+		logconfig.Log.Error("İki adımlı doğrulama: Beklenmeyen hata", zap.Uint("user_id", userID), zap.Error(err))
+		msg = "İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin."
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
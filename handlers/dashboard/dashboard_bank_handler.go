@@ -72,6 +72,7 @@ func (h *DashboardBankHandler) CreateBank(c *fiber.Ctx) error {
 
 	bank := &models.Bank{
 		Name:     req.Name,
+		Code:     req.Code,
 		IsActive: req.IsActive == "true",
 	}
 
@@ -121,6 +122,7 @@ func (h *DashboardBankHandler) UpdateBank(c *fiber.Ctx) error {
 		}
 
 		existingBank.Name = req.Name
+		existingBank.Code = req.Code
 		existingBank.IsActive = req.IsActive == "true"
 
 		return renderBankFormError(c, "dashboard/banks/update", "Banka Düzenle", req, err.Error(), existingBank)
@@ -128,6 +130,7 @@ func (h *DashboardBankHandler) UpdateBank(c *fiber.Ctx) error {
 
 	bank := &models.Bank{
 		Name:     req.Name,
+		Code:     req.Code,
 		IsActive: req.IsActive == "true",
 	}
 
@@ -178,6 +181,7 @@ func renderBankFormError(c *fiber.Ctx, template, title string, req any, message
 
 	bank := &models.Bank{
 		Name:     form.Name,
+		Code:     form.Code,
 		IsActive: form.IsActive == "true",
 	}
 
@@ -308,6 +308,14 @@ func (h *DashboardCardHandler) renderCardFormError(c *fiber.Ctx, template, title
 		IsFree:     form.IsFree == "true",
 	}
 
+	// Hatalı IBAN yüzünden reddedilen formda girilen satırlar kaybolmaz.
+	for _, cb := range form.CardBanks {
+		card.CardBanks = append(card.CardBanks, models.CardBank{BankID: cb.BankID, IBAN: cb.IBAN})
+	}
+	for _, cs := range form.CardSocialMedia {
+		card.CardSocialMedia = append(card.CardSocialMedia, models.CardSocialMedia{SocialMediaID: cs.SocialMediaID, URL: cs.URL})
+	}
+
 	if len(fallback) > 0 && fallback[0] != nil {
 		card = fallback[0]
 	}
@@ -15,6 +15,7 @@ type DashboardHomeHandler struct {
 	userService       services.IUserService
 	cardService       services.ICardService
 	invitationService services.IInvitationService
+	visitService      services.IVisitService
 }
 
 func NewDashboardHomeHandler() *DashboardHomeHandler {
@@ -25,6 +26,7 @@ func NewDashboardHomeHandler() *DashboardHomeHandler {
 		userService:       userSvc,
 		cardService:       cardSvc,
 		invitationService: invitationSvc,
+		visitService:      services.NewVisitService(),
 	}
 }
 
@@ -45,11 +47,17 @@ func (h *DashboardHomeHandler) HomePage(c *fiber.Ctx) error {
 		invitationCount = 0
 	}
 
+	visitOverview, visitErr := h.visitService.Overview(c.UserContext(), services.VisitStatsDays())
+	if visitErr != nil {
+		logconfig.Log.Error("Anasayfa: Ziyaret özeti alınamadı", zap.Error(visitErr))
+	}
+
 	mapData := fiber.Map{
 		"Title":           "Dashboard",
 		"UserCount":       userCount,
 		"CardCount":       cardCount,
 		"InvitationCount": invitationCount,
+		"VisitOverview":   visitOverview,
 	}
 	return renderer.Render(c, "dashboard/home/home", "layouts/dashboard", mapData, http.StatusOK)
 }
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"zatrano/models"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DashboardLoginLockHandler struct {
+	protectionService services.ILoginProtectionService
+}
+
+func NewDashboardLoginLockHandler() *DashboardLoginLockHandler {
+	return &DashboardLoginLockHandler{protectionService: services.NewLoginProtectionService()}
+}
+
+func (h *DashboardLoginLockHandler) ListLocks(c *fiber.Ctx) error {
+	locks, err := h.protectionService.GetLockedEntries()
+
+	renderData := fiber.Map{
+		"Title": "Kilitli Hesaplar",
+		"Locks": locks,
+	}
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "Kilitli hesaplar getirilirken bir hata oluştu."
+		renderData["Locks"] = []models.AuthThrottle{}
+	}
+	return renderer.Render(c, "dashboard/login-locks/list", "layouts/dashboard", renderData, http.StatusOK)
+}
+
+func (h *DashboardLoginLockHandler) Unlock(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID")
+		return c.Redirect("/dashboard/login-locks", fiber.StatusSeeOther)
+	}
+
+	if err := h.protectionService.Unlock(c.UserContext(), uint(id)); err != nil {
+		errMsg := "Kilit kaldırılamadı."
+		if errors.Is(err, services.ErrThrottleNotFound) {
+			errMsg = "Kilit kaydı bulunamadı veya süresi dolmuş."
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect("/dashboard/login-locks", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kilit başarıyla kaldırıldı.")
+	return c.Redirect("/dashboard/login-locks", fiber.StatusFound)
+}
@@ -68,16 +68,14 @@ func (h *DashboardUserHandler) CreateUser(c *fiber.Ctx) error {
 	}
 
 	user := &models.User{
-		Name:              req.Name,
-		Email:             req.Email,
-		Password:          req.Password,
-		Status:            req.Status == "true",
-		Type:              models.UserType(req.Type),
-		ResetToken:        req.ResetToken,
-		EmailVerified:     req.EmailVerified == "true",
-		VerificationToken: req.VerificationToken,
-		Provider:          req.Provider,
-		ProviderID:        req.ProviderID,
+		Name:          req.Name,
+		Email:         req.Email,
+		Password:      req.Password,
+		Status:        req.Status == "true",
+		Type:          models.UserType(req.Type),
+		EmailVerified: req.EmailVerified == "true",
+		Provider:      req.Provider,
+		ProviderID:    req.ProviderID,
 	}
 
 	if err := h.userService.CreateUser(c.UserContext(), user); err != nil {
@@ -126,15 +124,13 @@ func (h *DashboardUserHandler) UpdateUser(c *fiber.Ctx) error {
 	}
 
 	user := &models.User{
-		Name:              req.Name,
-		Email:             req.Email,
-		Status:            req.Status == "true",
-		Type:              models.UserType(req.Type),
-		ResetToken:        req.ResetToken,
-		EmailVerified:     req.EmailVerified == "true",
-		VerificationToken: req.VerificationToken,
-		Provider:          req.Provider,
-		ProviderID:        req.ProviderID,
+		Name:          req.Name,
+		Email:         req.Email,
+		Status:        req.Status == "true",
+		Type:          models.UserType(req.Type),
+		EmailVerified: req.EmailVerified == "true",
+		Provider:      req.Provider,
+		ProviderID:    req.ProviderID,
 	}
 
 	if req.Password != "" {
@@ -183,15 +179,13 @@ func renderUserFormError(c *fiber.Ctx, template, title string, req any, message
 	}
 
 	user := &models.User{
-		Name:              form.Name,
-		Email:             form.Email,
-		Status:            form.Status == "true",
-		Type:              models.UserType(form.Type),
-		ResetToken:        form.ResetToken,
-		EmailVerified:     form.EmailVerified == "true",
-		VerificationToken: form.VerificationToken,
-		Provider:          form.Provider,
-		ProviderID:        form.ProviderID,
+		Name:          form.Name,
+		Email:         form.Email,
+		Status:        form.Status == "true",
+		Type:          models.UserType(form.Type),
+		EmailVerified: form.EmailVerified == "true",
+		Provider:      form.Provider,
+		ProviderID:    form.ProviderID,
 	}
 
 	if form.Password != "" {
@@ -20,6 +20,7 @@ type PanelCardHandler struct {
 	cardService        services.ICardService
 	bankService        services.IBankService
 	socialMediaService services.ISocialMediaService
+	visitService       services.IVisitService
 }
 
 func NewPanelCardHandler() *PanelCardHandler {
@@ -27,6 +28,7 @@ func NewPanelCardHandler() *PanelCardHandler {
 		cardService:        services.NewCardService(),
 		bankService:        services.NewBankService(),
 		socialMediaService: services.NewSocialMediaService(),
+		visitService:       services.NewVisitService(),
 	}
 }
 
@@ -137,6 +139,40 @@ func (h *PanelCardHandler) ShowUpdateCard(c *fiber.Ctx) error {
 	})
 }
 
+func (h *PanelCardHandler) ShowCardStats(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz Kartvizit ID'si.")
+		return c.Redirect("/panel/cards", http.StatusSeeOther)
+	}
+
+	card, err := h.cardService.GetCardByID(uint(id))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kartvizit bulunamadı.")
+		return c.Redirect("/panel/cards", http.StatusSeeOther)
+	}
+	// Başka kullanıcının kartviziti, varlığı belli olmasın diye 404 ile reddedilir.
+	if userID, _ := c.Locals("userID").(uint); userID == 0 || card.UserID != userID {
+		return fiber.ErrNotFound
+	}
+
+	days := services.VisitStatsDays()
+	stats, err := h.visitService.Stats(c.UserContext(), models.VisitTargetCard, card.ID, days)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İstatistikler alınamadı.")
+		return c.Redirect("/panel/cards", http.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "panel/stats/visits", "layouts/panel", fiber.Map{
+		"Title":     "Kartvizit İstatistikleri",
+		"Subject":   card.Name,
+		"PublicURL": "/@" + card.Slug,
+		"BackURL":   "/panel/cards",
+		"Days":      days,
+		"Stats":     stats,
+	})
+}
+
 func (h *PanelCardHandler) UpdateCard(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
@@ -296,6 +332,14 @@ func (h *PanelCardHandler) renderCardFormError(c *fiber.Ctx, template, title str
 		IsFree:     form.IsFree == "true",
 	}
 
+	// Hatalı IBAN yüzünden reddedilen formda girilen satırlar kaybolmaz.
+	for _, cb := range form.CardBanks {
+		card.CardBanks = append(card.CardBanks, models.CardBank{BankID: cb.BankID, IBAN: cb.IBAN})
+	}
+	for _, cs := range form.CardSocialMedia {
+		card.CardSocialMedia = append(card.CardSocialMedia, models.CardSocialMedia{SocialMediaID: cs.SocialMediaID, URL: cs.URL})
+	}
+
 	if len(fallback) > 0 && fallback[0] != nil {
 		card = fallback[0]
 	}
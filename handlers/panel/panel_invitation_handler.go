@@ -1,31 +1,57 @@
 package handlers
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"zatrano/configs/logconfig"
 	"zatrano/models"
 	"zatrano/pkg/filemanager"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/notifier"
 	"zatrano/pkg/queryparams"
 	"zatrano/pkg/renderer"
 	"zatrano/requests"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
 type PanelInvitationHandler struct {
-	invitationService services.IInvitationService
-	categoryService   services.IInvitationCategoryService
+	invitationService  services.IInvitationService
+	categoryService    services.IInvitationCategoryService
+	reminderService    services.IReminderService
+	participantService services.IInvitationParticipantService
+	questionService    services.IInvitationQuestionService
+	eventService       services.IInvitationEventService
+	guestbookService   services.IInvitationGuestbookService
+	mediaService       services.IInvitationMediaService
+	giftService        services.IInvitationGiftService
+	accessService      services.IInvitationAccessService
+	bankService        services.IBankService
+	visitService       services.IVisitService
 }
 
 func NewPanelInvitationHandler() *PanelInvitationHandler {
 	return &PanelInvitationHandler{
-		invitationService: services.NewInvitationService(),
-		categoryService:   services.NewInvitationCategoryService(),
+		invitationService:  services.NewInvitationService(),
+		categoryService:    services.NewInvitationCategoryService(),
+		reminderService:    services.NewReminderService(),
+		participantService: services.NewInvitationParticipantService(),
+		questionService:    services.NewInvitationQuestionService(),
+		eventService:       services.NewInvitationEventService(),
+		guestbookService:   services.NewInvitationGuestbookService(),
+		mediaService:       services.NewInvitationMediaService(),
+		giftService:        services.NewInvitationGiftService(),
+		accessService:      services.NewInvitationAccessService(),
+		bankService:        services.NewBankService(),
+		visitService:       services.NewVisitService(),
 	}
 }
 
@@ -64,8 +90,10 @@ func (h *PanelInvitationHandler) ShowCreateInvitation(c *fiber.Ctx) error {
 	categories, _ := h.categoryService.GetAllCategories(queryparams.DefaultListParams())
 
 	return renderer.Render(c, "panel/invitations/create", "layouts/panel", fiber.Map{
-		"Title":      "Yeni Davetiye Ekle",
-		"Categories": categories,
+		"Title":            "Yeni Davetiye Ekle",
+		"Categories":       categories,
+		"Timezones":        requests.InvitationTimezones,
+		"ReminderChannels": services.ReminderChannels,
 	})
 }
 
@@ -80,19 +108,31 @@ func (h *PanelInvitationHandler) CreateInvitation(c *fiber.Ctx) error {
 		return h.renderInvitationFormError(c, "panel/invitations/create", "Yeni Davetiye Ekle", req, "Resim yüklenemedi: "+err.Error())
 	}
 
-	dateValue, _ := time.Parse("2006-01-02", req.Date)
+	schedule, _ := req.Schedule()
+	reminderEnabled, reminderLeadHours, reminderChannel := reminderSettings(req)
+	userID, _ := c.Locals("userID").(uint)
 	invitation := &models.Invitation{
-		CategoryID:    req.CategoryID,
-		Image:         newFileName,
-		Venue:         req.Venue,
-		Address:       req.Address,
-		Location:      req.Location,
-		Telephone:     req.Telephone,
-		Date:          dateValue,
-		Time:          req.Time,
-		IsConfirmed:   req.IsConfirmed == "true",
-		IsParticipant: req.IsParticipant == "true",
-		IsFree:        req.IsFree == "true",
+		UserID:            userID,
+		CategoryID:        req.CategoryID,
+		Image:             newFileName,
+		Venue:             req.Venue,
+		Address:           req.Address,
+		Location:          req.Location,
+		Telephone:         req.Telephone,
+		EventAt:           schedule.EventAt,
+		Timezone:          schedule.Timezone,
+		PublishAt:         schedule.PublishAt,
+		ExpireAt:          schedule.ExpireAt,
+		IsConfirmed:       req.IsConfirmed == "true",
+		IsParticipant:     req.IsParticipant == "true",
+		IsFree:            req.IsFree == "true",
+		ReminderEnabled:   reminderEnabled,
+		ReminderLeadHours: reminderLeadHours,
+		ReminderChannel:   reminderChannel,
+		MaxGuests:         req.MaxGuests,
+		MaxGuestsPerRSVP:  req.MaxGuestsPerRSVP,
+		RSVPDeadline:      schedule.RSVPDeadline,
+		WaitlistEnabled:   req.WaitlistEnabled == "true",
 		InvitationDetail: &models.InvitationDetail{
 			Title:              req.Detail.Title,
 			BrideName:          req.Detail.BrideName,
@@ -137,104 +177,1263 @@ func (h *PanelInvitationHandler) ShowUpdateInvitation(c *fiber.Ctx) error {
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
 
-	invitation, err := h.invitationService.GetInvitationByID(uint(id))
+	invitation, err := h.ownedInvitation(c, id)
 	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return err
+	}
+
+	categories, _ := h.categoryService.GetAllCategories(queryparams.DefaultListParams())
+
+	return renderer.Render(c, "panel/invitations/update", "layouts/panel", fiber.Map{
+		"Title":            "Davetiye Düzenle",
+		"Invitation":       invitation,
+		"Categories":       categories,
+		"Timezones":        requests.InvitationTimezones,
+		"ReminderChannels": services.ReminderChannels,
+		"ReminderPreview":  h.reminderService.Preview(invitation).Body,
+	})
+}
+
+func (h *PanelInvitationHandler) ShowInvitationStats(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	days := services.VisitStatsDays()
+	stats, err := h.visitService.Stats(c.UserContext(), models.VisitTargetInvitation, invitation.ID, days)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İstatistikler alınamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "panel/stats/visits", "layouts/panel", fiber.Map{
+		"Title":     "Davetiye İstatistikleri",
+		"Subject":   invitation.EventTitle(),
+		"PublicURL": "/" + invitation.InvitationKey,
+		"BackURL":   "/panel/invitations",
+		"Days":      days,
+		"Stats":     stats,
+	})
+}
+
+func (h *PanelInvitationHandler) UpdateInvitation(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	existingInvitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	req, err := requests.ParseAndValidateInvitationRequest(c)
+	if err != nil {
+		return h.renderInvitationFormError(c, "panel/invitations/update", "Davetiye Düzenle", req, err.Error(), existingInvitation)
+	}
+
+	newFileName, err := filemanager.UploadFile(c, "image", "invitations")
+	var oldPhotoToDelete string
+	if newFileName != "" {
+		oldPhotoToDelete = existingInvitation.Image
+		existingInvitation.Image = newFileName
+	}
+
+	schedule, _ := req.Schedule()
+	existingInvitation.CategoryID = req.CategoryID
+	existingInvitation.Venue = req.Venue
+	existingInvitation.Address = req.Address
+	existingInvitation.Location = req.Location
+	existingInvitation.Telephone = req.Telephone
+	existingInvitation.EventAt = schedule.EventAt
+	existingInvitation.Timezone = schedule.Timezone
+	existingInvitation.PublishAt = schedule.PublishAt
+	existingInvitation.ExpireAt = schedule.ExpireAt
+	if schedule.EventAt.After(time.Now()) {
+		// İleri bir tarihe taşınan etkinlik yeniden yayına alınır.
+		existingInvitation.ArchivedAt = nil
+	}
+	existingInvitation.IsConfirmed = req.IsConfirmed == "true"
+	existingInvitation.IsParticipant = req.IsParticipant == "true"
+	existingInvitation.IsFree = req.IsFree == "true"
+	existingInvitation.ReminderEnabled, existingInvitation.ReminderLeadHours, existingInvitation.ReminderChannel = reminderSettings(req)
+	existingInvitation.MaxGuests = req.MaxGuests
+	existingInvitation.MaxGuestsPerRSVP = req.MaxGuestsPerRSVP
+	existingInvitation.RSVPDeadline = schedule.RSVPDeadline
+	existingInvitation.WaitlistEnabled = req.WaitlistEnabled == "true"
+
+	if existingInvitation.InvitationDetail != nil {
+		existingInvitation.InvitationDetail.Title = req.Detail.Title
+		existingInvitation.InvitationDetail.BrideName = req.Detail.BrideName
+		existingInvitation.InvitationDetail.BrideSurname = req.Detail.BrideSurname
+		existingInvitation.InvitationDetail.BrideMotherName = req.Detail.BrideMotherName
+		existingInvitation.InvitationDetail.BrideMotherSurname = req.Detail.BrideMotherSurname
+		existingInvitation.InvitationDetail.BrideFatherName = req.Detail.BrideFatherName
+		existingInvitation.InvitationDetail.BrideFatherSurname = req.Detail.BrideFatherSurname
+		existingInvitation.InvitationDetail.GroomName = req.Detail.GroomName
+		existingInvitation.InvitationDetail.GroomSurname = req.Detail.GroomSurname
+		existingInvitation.InvitationDetail.GroomMotherName = req.Detail.GroomMotherName
+		existingInvitation.InvitationDetail.GroomMotherSurname = req.Detail.GroomMotherSurname
+		existingInvitation.InvitationDetail.GroomFatherName = req.Detail.GroomFatherName
+		existingInvitation.InvitationDetail.GroomFatherSurname = req.Detail.GroomFatherSurname
+		existingInvitation.InvitationDetail.Person = req.Detail.Person
+		existingInvitation.InvitationDetail.MotherName = req.Detail.MotherName
+		existingInvitation.InvitationDetail.MotherSurname = req.Detail.MotherSurname
+		existingInvitation.InvitationDetail.FatherName = req.Detail.FatherName
+		existingInvitation.InvitationDetail.FatherSurname = req.Detail.FatherSurname
+		existingInvitation.InvitationDetail.IsMotherLive = req.Detail.IsMotherLive == "true"
+		existingInvitation.InvitationDetail.IsFatherLive = req.Detail.IsFatherLive == "true"
+		existingInvitation.InvitationDetail.IsBrideMotherLive = req.Detail.IsBrideMotherLive == "true"
+		existingInvitation.InvitationDetail.IsBrideFatherLive = req.Detail.IsBrideFatherLive == "true"
+		existingInvitation.InvitationDetail.IsGroomMotherLive = req.Detail.IsGroomMotherLive == "true"
+		existingInvitation.InvitationDetail.IsGroomFatherLive = req.Detail.IsGroomFatherLive == "true"
+	}
+
+	if err := h.invitationService.UpdateInvitationWithRelations(c.UserContext(), existingInvitation); err != nil {
+		if newFileName != "" {
+			filemanager.DeleteFile("invitations", newFileName)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye güncellenemedi: "+err.Error())
+		return c.Redirect("/panel/invitations/update/"+strconv.Itoa(id), http.StatusSeeOther)
+	}
+
+	if oldPhotoToDelete != "" {
+		filemanager.DeleteFile("invitations", oldPhotoToDelete)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Davetiye başarıyla güncellendi.")
+	return c.Redirect("/panel/invitations", http.StatusFound)
+}
+
+// ListParticipants, davetiyenin misafir listesini kişiye özel bağlantılar ve
+// açılma bilgileriyle gösterir; ?durum=invited henüz açmamış olanları süzer.
+func (h *PanelInvitationHandler) ListParticipants(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	status := models.ParticipantStatus(c.Query("durum"))
+	if !status.IsValid() {
+		status = ""
+	}
+
+	participants, err := h.participantService.ListParticipants(c.UserContext(), invitation.ID, status)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	summary, err := h.participantService.Summary(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	questions, err := h.questionService.ListQuestions(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	attendance, err := h.eventService.Attendance(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	links := make(map[uint]string, len(participants))
+	for _, participant := range participants {
+		links[participant.ID] = h.participantService.GuestLink(invitation, participant.ID)
+	}
+
+	return renderer.Render(c, "panel/invitations/participants", "layouts/panel", fiber.Map{
+		"Title":        "Katılımcılar",
+		"Invitation":   invitation,
+		"Participants": participants,
+		"Links":        links,
+		"Summary":      summary,
+		"Status":       status,
+		"Questions":    questions,
+		"Attendance":   attendance,
+	})
+}
+
+// AddParticipants, tek satırlık veya toplu yapıştırılan misafir listesini ekler.
+func (h *PanelInvitationHandler) AddParticipants(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/participants/" + strconv.Itoa(id)
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	req, err := requests.ParseAndValidateGuestListRequest(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	guests, err := h.participantService.ParseGuestList(req.Guests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Misafir listesi okunamadı: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	added, err := h.participantService.AddGuests(c.UserContext(), invitation.ID, guests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Misafirler eklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, strconv.Itoa(added)+" misafir eklendi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// PromoteParticipant, yedek listedeki misafiri kapasite yetiyorsa katılımcı
+// listesine alır.
+func (h *PanelInvitationHandler) PromoteParticipant(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/participants/" + strconv.Itoa(id)
+
+	participantID, err := strconv.Atoi(c.Params("participantID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz katılımcı ID'si.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	if err := h.participantService.Promote(c.UserContext(), uint(id), uint(participantID)); err != nil {
+		message := "Katılımcı yedek listeden alınamadı: " + err.Error()
+		if errors.Is(err, services.ErrRSVPFull) {
+			message = "Kapasite yetersiz. Yedek listeden almak için önce davetiyenin kapasitesini artırın."
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Katılımcı yedek listeden alındı.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) DeleteParticipant(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	participantID, err := strconv.Atoi(c.Params("participantID"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	redirectURL := "/panel/invitations/participants/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	if err := h.participantService.DeleteParticipant(c.UserContext(), uint(id), uint(participantID)); err != nil {
+		errMsg := "Katılımcı silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Katılımcı başarıyla silindi."})
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Katılımcı başarıyla silindi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// ExportParticipants, misafir listesini soru yanıtlarıyla birlikte CSV
+// olarak indirir.
+func (h *PanelInvitationHandler) ExportParticipants(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.participantService.ExportCSV(c.UserContext(), invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Katılımcılar dışa aktarılamadı: "+err.Error())
+		return c.Redirect("/panel/invitations/participants/"+strconv.Itoa(id), http.StatusSeeOther)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="katilimcilar-`+invitation.InvitationKey+`.csv"`)
+	return c.Send(data)
+}
+
+// ListQuestions, katılım formu sorularını listeler; ?duzenle=<soru ID> ile
+// form seçilen soruyu düzenleyecek şekilde açılır.
+func (h *PanelInvitationHandler) ListQuestions(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	questions, err := h.questionService.ListQuestions(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	var editing *models.InvitationQuestion
+	if questionID, err := strconv.Atoi(c.Query("duzenle")); err == nil {
+		editing, _ = h.questionService.GetQuestion(c.UserContext(), invitation.ID, uint(questionID))
+	}
+
+	return renderer.Render(c, "panel/invitations/questions", "layouts/panel", fiber.Map{
+		"Title":         "Katılım Formu Soruları",
+		"Invitation":    invitation,
+		"Questions":     questions,
+		"Editing":       editing,
+		"QuestionTypes": models.QuestionTypes,
+	})
+}
+
+func (h *PanelInvitationHandler) CreateQuestion(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/questions/" + strconv.Itoa(id)
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	req, err := requests.ParseAndValidateInvitationQuestionRequest(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if err := h.questionService.CreateQuestion(c.UserContext(), invitation.ID, questionInput(req)); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Soru eklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Soru başarıyla eklendi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) UpdateQuestion(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/questions/" + strconv.Itoa(id)
+
+	questionID, err := strconv.Atoi(c.Params("questionID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz soru ID'si.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	req, err := requests.ParseAndValidateInvitationQuestionRequest(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL+"?duzenle="+strconv.Itoa(questionID), http.StatusSeeOther)
+	}
+
+	if err := h.questionService.UpdateQuestion(c.UserContext(), uint(id), uint(questionID), questionInput(req)); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Soru güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL+"?duzenle="+strconv.Itoa(questionID), http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Soru başarıyla güncellendi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) DeleteQuestion(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	questionID, err := strconv.Atoi(c.Params("questionID"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	redirectURL := "/panel/invitations/questions/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	if err := h.questionService.DeleteQuestion(c.UserContext(), uint(id), uint(questionID)); err != nil {
+		errMsg := "Soru silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Soru başarıyla silindi."})
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Soru başarıyla silindi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// ListEvents, davetiyenin etkinliklerini (ör. kına, nikah, düğün) listeler;
+// ?duzenle=<etkinlik ID> ile form seçilen etkinliği düzenleyecek şekilde açılır.
+func (h *PanelInvitationHandler) ListEvents(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	events, err := h.eventService.ListEvents(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	attendance, err := h.eventService.Attendance(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	var editing *models.InvitationEvent
+	if eventID, err := strconv.Atoi(c.Query("duzenle")); err == nil {
+		editing, _ = h.eventService.GetEvent(c.UserContext(), invitation.ID, uint(eventID))
+	}
+
+	return renderer.Render(c, "panel/invitations/events", "layouts/panel", fiber.Map{
+		"Title":      "Etkinlikler",
+		"Invitation": invitation,
+		"Events":     events,
+		"Attendance": attendance,
+		"Editing":    editing,
+	})
+}
+
+func (h *PanelInvitationHandler) CreateEvent(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/events/" + strconv.Itoa(id)
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	input, err := eventInput(c, invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if err := h.eventService.CreateEvent(c.UserContext(), invitation.ID, input); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Etkinlik eklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Etkinlik başarıyla eklendi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) UpdateEvent(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/events/" + strconv.Itoa(id)
+
+	eventID, err := strconv.Atoi(c.Params("eventID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz etkinlik ID'si.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	input, err := eventInput(c, invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL+"?duzenle="+strconv.Itoa(eventID), http.StatusSeeOther)
+	}
+
+	if err := h.eventService.UpdateEvent(c.UserContext(), invitation.ID, uint(eventID), input); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Etkinlik güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL+"?duzenle="+strconv.Itoa(eventID), http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Etkinlik başarıyla güncellendi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) DeleteEvent(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	eventID, err := strconv.Atoi(c.Params("eventID"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	redirectURL := "/panel/invitations/events/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	if err := h.eventService.DeleteEvent(c.UserContext(), uint(id), uint(eventID)); err != nil {
+		errMsg := "Etkinlik silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Etkinlik başarıyla silindi."})
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Etkinlik başarıyla silindi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// ListGuestbook, anı defteri mesajlarını durum filtresiyle listeler.
+func (h *PanelInvitationHandler) ListGuestbook(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	status := models.GuestbookStatus(c.Query("durum"))
+	if !status.IsValid() {
+		status = ""
+	}
+
+	entries, err := h.guestbookService.ListEntries(c.UserContext(), invitation.ID, status)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	summary, err := h.guestbookService.Summary(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "panel/invitations/guestbook", "layouts/panel", fiber.Map{
+		"Title":      "Anı Defteri",
+		"Invitation": invitation,
+		"Entries":    entries,
+		"Summary":    summary,
+		"Status":     status,
+	})
+}
+
+// UpdateGuestbookSettings, davetiyede anı defterini açar veya kapatır.
+// Kapatıldığında mesajlar silinmez, yalnızca sayfada gösterilmez.
+func (h *PanelInvitationHandler) UpdateGuestbookSettings(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/guestbook/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	enabled := c.FormValue("guestbook_enabled") == "true"
+	if err := h.guestbookService.SetEnabled(c.UserContext(), uint(id), enabled); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Anı defteri ayarı kaydedilemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	message := "Anı defteri kapatıldı."
+	if enabled {
+		message = "Anı defteri açıldı."
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, message)
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// SetGuestbookEntryStatus, mesajı onaylar (yayınlar) veya gizler.
+func (h *PanelInvitationHandler) SetGuestbookEntryStatus(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/guestbook/" + strconv.Itoa(id)
+	if status := c.FormValue("durum"); models.GuestbookStatus(status).IsValid() {
+		redirectURL += "?durum=" + status
+	}
+
+	entryID, err := strconv.Atoi(c.Params("entryID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz mesaj ID'si.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	status := models.GuestbookStatus(c.FormValue("status"))
+	if err := h.guestbookService.SetStatus(c.UserContext(), uint(id), uint(entryID), status); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Mesaj güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	message := "Mesaj güncellendi."
+	switch status {
+	case models.GuestbookApproved:
+		message = "Mesaj onaylandı ve yayınlandı."
+	case models.GuestbookHidden:
+		message = "Mesaj gizlendi."
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, message)
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) DeleteGuestbookEntry(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	entryID, err := strconv.Atoi(c.Params("entryID"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	redirectURL := "/panel/invitations/guestbook/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	if err := h.guestbookService.DeleteEntry(c.UserContext(), uint(id), uint(entryID)); err != nil {
+		errMsg := "Mesaj silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Mesaj başarıyla silindi."})
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Mesaj başarıyla silindi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) ExportGuestbook(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.guestbookService.ExportCSV(c.UserContext(), invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Mesajlar dışa aktarılamadı: "+err.Error())
+		return c.Redirect("/panel/invitations/guestbook/"+strconv.Itoa(id), http.StatusSeeOther)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="ani-defteri-`+invitation.InvitationKey+`.csv"`)
+	return c.Send(data)
+}
+
+// ListGallery, galeri fotoğraflarını durum filtresiyle listeler.
+func (h *PanelInvitationHandler) ListGallery(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	status := models.MediaStatus(c.Query("durum"))
+	if !status.IsValid() {
+		status = ""
+	}
+
+	media, err := h.mediaService.ListMedia(c.UserContext(), invitation.ID, status)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	summary, err := h.mediaService.Summary(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "panel/invitations/gallery", "layouts/panel", fiber.Map{
+		"Title":      "Fotoğraf Galerisi",
+		"Invitation": invitation,
+		"Media":      media,
+		"Summary":    summary,
+		"Status":     status,
+	})
+}
+
+// UploadGalleryMedia, davetiye sahibinin seçtiği fotoğrafları galerinin
+// sonuna ekler; sahibin yüklediği fotoğraflar doğrudan yayınlanır.
+func (h *PanelInvitationHandler) UploadGalleryMedia(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/gallery/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	summary, err := h.mediaService.Summary(c.UserContext(), uint(id))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraflar yüklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if summary.Remaining == 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Galeri kotası doldu. Yeni fotoğraf eklemek için önce bazı fotoğrafları silin.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	fileNames, err := filemanager.UploadFiles(c, "photos", services.MediaContentType, summary.Remaining)
+	if err != nil {
+		message := "Fotoğraflar yüklenemedi: " + err.Error()
+		if errors.Is(err, filemanager.ErrTooManyFiles) {
+			message = fmt.Sprintf("Bu yüklemede en fazla %d fotoğraf seçebilirsiniz.", summary.Remaining)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if err := h.mediaService.AddMedia(c.UserContext(), uint(id), services.MediaUpload{
+		FileNames: fileNames,
+		Source:    models.MediaSourceOwner,
+	}); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraflar yüklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, fmt.Sprintf("%d fotoğraf galeriye eklendi.", len(fileNames)))
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// UpdateGallerySettings, misafir yüklemesini açar veya kapatır. Kod boş
+// bırakılırsa yeni bir ortak kod üretilir.
+func (h *PanelInvitationHandler) UpdateGallerySettings(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/gallery/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	enabled := c.FormValue("gallery_guest_uploads") == "true"
+	code, err := h.mediaService.UpdateSettings(c.UserContext(), uint(id), enabled, c.FormValue("gallery_upload_code"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Galeri ayarı kaydedilemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	message := "Misafir fotoğraf yüklemesi kapatıldı."
+	if enabled {
+		message = "Misafir fotoğraf yüklemesi açıldı. Yükleme kodu: " + code
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, message)
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) UpdateGalleryMedia(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := galleryRedirectURL("/panel", id, c.FormValue("durum"))
+
+	mediaID, err := strconv.Atoi(c.Params("mediaID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz fotoğraf ID'si.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	req, err := requests.ParseAndValidateInvitationMediaRequest(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if err := h.mediaService.UpdateMedia(c.UserContext(), uint(id), uint(mediaID), req.Caption, req.SortOrder); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraf güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Fotoğraf güncellendi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// SetGalleryMediaStatus, misafir fotoğrafını onaylar (yayınlar) veya gizler.
+func (h *PanelInvitationHandler) SetGalleryMediaStatus(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
+	redirectURL := galleryRedirectURL("/panel", id, c.FormValue("durum"))
 
-	categories, _ := h.categoryService.GetAllCategories(queryparams.DefaultListParams())
+	mediaID, err := strconv.Atoi(c.Params("mediaID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz fotoğraf ID'si.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
 
-	return renderer.Render(c, "panel/invitations/update", "layouts/panel", fiber.Map{
-		"Title":      "Davetiye Düzenle",
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	status := models.MediaStatus(c.FormValue("status"))
+	if err := h.mediaService.SetStatus(c.UserContext(), uint(id), uint(mediaID), status); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraf güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	message := "Fotoğraf güncellendi."
+	switch status {
+	case models.MediaApproved:
+		message = "Fotoğraf onaylandı ve yayınlandı."
+	case models.MediaHidden:
+		message = "Fotoğraf gizlendi."
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, message)
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) DeleteGalleryMedia(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	mediaID, err := strconv.Atoi(c.Params("mediaID"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	redirectURL := "/panel/invitations/gallery/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	if err := h.mediaService.DeleteMedia(c.UserContext(), uint(id), uint(mediaID)); err != nil {
+		errMsg := "Fotoğraf silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Fotoğraf başarıyla silindi."})
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Fotoğraf başarıyla silindi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// DownloadGallery, galerideki tüm fotoğrafları (onay bekleyen ve gizlenenler
+// dahil) ZIP arşivi olarak indirir. Arşiv belleğe alınmadan yanıta yazılır.
+func (h *PanelInvitationHandler) DownloadGallery(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	redirectURL := "/panel/invitations/gallery/" + strconv.Itoa(id)
+	media, err := h.mediaService.ListMedia(c.UserContext(), invitation.ID, "")
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraflar indirilemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if len(media) == 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Galeride indirilecek fotoğraf yok.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="galeri-`+invitation.InvitationKey+`.zip"`)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.mediaService.WriteArchive(w, media); err != nil {
+			logconfig.Log.Error("Galeri arşivi yazılamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		}
+	})
+	return nil
+}
+
+// ListGifts, davetiyenin hediye hesaplarını ve misafirlerin bıraktığı notları
+// listeler; ?duzenle=<hesap ID> ile form seçilen hesabı düzenleyecek şekilde açılır.
+func (h *PanelInvitationHandler) ListGifts(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := h.giftService.ListAccounts(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	notes, err := h.giftService.ListNotes(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	var editing *models.InvitationGiftAccount
+	if accountID, err := strconv.Atoi(c.Query("duzenle")); err == nil {
+		editing, _ = h.giftService.GetAccount(c.UserContext(), invitation.ID, uint(accountID))
+	}
+
+	var banks interface{}
+	if banksResult, err := h.bankService.GetAllBanks(queryparams.ListParams{PerPage: 1000}); err == nil {
+		banks = banksResult.Data
+	}
+
+	return renderer.Render(c, "panel/invitations/gifts", "layouts/panel", fiber.Map{
+		"Title":      "Hediye Hesapları",
 		"Invitation": invitation,
-		"Categories": categories,
+		"Accounts":   accounts,
+		"Notes":      notes,
+		"Banks":      banks,
+		"Editing":    editing,
 	})
 }
 
-func (h *PanelInvitationHandler) UpdateInvitation(c *fiber.Ctx) error {
+func (h *PanelInvitationHandler) CreateGiftAccount(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
+	redirectURL := "/panel/invitations/gifts/" + strconv.Itoa(id)
 
-	req, err := requests.ParseAndValidateInvitationRequest(c)
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	req, err := requests.ParseAndValidateInvitationGiftAccountRequest(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if err := h.giftService.CreateAccount(c.UserContext(), uint(id), giftAccountInput(req)); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Hesap eklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Hesap başarıyla eklendi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) UpdateGiftAccount(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/gifts/" + strconv.Itoa(id)
+
+	accountID, err := strconv.Atoi(c.Params("accountID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz hesap ID'si.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	req, err := requests.ParseAndValidateInvitationGiftAccountRequest(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL+"?duzenle="+strconv.Itoa(accountID), http.StatusSeeOther)
+	}
+
+	if err := h.giftService.UpdateAccount(c.UserContext(), uint(id), uint(accountID), giftAccountInput(req)); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Hesap güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL+"?duzenle="+strconv.Itoa(accountID), http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Hesap başarıyla güncellendi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) DeleteGiftAccount(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	accountID, err := strconv.Atoi(c.Params("accountID"))
 	if err != nil {
-		existingInvitation, dbErr := h.invitationService.GetInvitationByID(uint(id))
-		if dbErr != nil {
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güncellenecek davetiye bulunamadı.")
-			return c.Redirect("/panel/invitations", http.StatusSeeOther)
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	redirectURL := "/panel/invitations/gifts/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	if err := h.giftService.DeleteAccount(c.UserContext(), uint(id), uint(accountID)); err != nil {
+		errMsg := "Hesap silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
 		}
-		return h.renderInvitationFormError(c, "panel/invitations/update", "Davetiye Düzenle", req, err.Error(), existingInvitation)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Hesap başarıyla silindi."})
 	}
 
-	existingInvitation, err := h.invitationService.GetInvitationByID(uint(id))
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Hesap başarıyla silindi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// UpdateGiftSettings, davetiye sayfasındaki hediye notu formunu açar veya kapatır.
+func (h *PanelInvitationHandler) UpdateGiftSettings(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güncellenecek davetiye bulunamadı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
+	redirectURL := "/panel/invitations/gifts/" + strconv.Itoa(id)
 
-	newFileName, err := filemanager.UploadFile(c, "image", "invitations")
-	var oldPhotoToDelete string
-	if newFileName != "" {
-		oldPhotoToDelete = existingInvitation.Image
-		existingInvitation.Image = newFileName
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
 	}
 
-	dateValue, _ := time.Parse("2006-01-02", req.Date)
-	existingInvitation.CategoryID = req.CategoryID
-	existingInvitation.Venue = req.Venue
-	existingInvitation.Address = req.Address
-	existingInvitation.Location = req.Location
-	existingInvitation.Telephone = req.Telephone
-	existingInvitation.Date = dateValue
-	existingInvitation.Time = req.Time
-	existingInvitation.IsConfirmed = req.IsConfirmed == "true"
-	existingInvitation.IsParticipant = req.IsParticipant == "true"
-	existingInvitation.IsFree = req.IsFree == "true"
+	enabled := c.FormValue("gift_notes_enabled") == "true"
+	if err := h.giftService.SetNotesEnabled(c.UserContext(), uint(id), enabled); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Hediye notu ayarı kaydedilemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
 
-	if existingInvitation.InvitationDetail != nil {
-		existingInvitation.InvitationDetail.Title = req.Detail.Title
-		existingInvitation.InvitationDetail.BrideName = req.Detail.BrideName
-		existingInvitation.InvitationDetail.BrideSurname = req.Detail.BrideSurname
-		existingInvitation.InvitationDetail.BrideMotherName = req.Detail.BrideMotherName
-		existingInvitation.InvitationDetail.BrideMotherSurname = req.Detail.BrideMotherSurname
-		existingInvitation.InvitationDetail.BrideFatherName = req.Detail.BrideFatherName
-		existingInvitation.InvitationDetail.BrideFatherSurname = req.Detail.BrideFatherSurname
-		existingInvitation.InvitationDetail.GroomName = req.Detail.GroomName
-		existingInvitation.InvitationDetail.GroomSurname = req.Detail.GroomSurname
-		existingInvitation.InvitationDetail.GroomMotherName = req.Detail.GroomMotherName
-		existingInvitation.InvitationDetail.GroomMotherSurname = req.Detail.GroomMotherSurname
-		existingInvitation.InvitationDetail.GroomFatherName = req.Detail.GroomFatherName
-		existingInvitation.InvitationDetail.GroomFatherSurname = req.Detail.GroomFatherSurname
-		existingInvitation.InvitationDetail.Person = req.Detail.Person
-		existingInvitation.InvitationDetail.MotherName = req.Detail.MotherName
-		existingInvitation.InvitationDetail.MotherSurname = req.Detail.MotherSurname
-		existingInvitation.InvitationDetail.FatherName = req.Detail.FatherName
-		existingInvitation.InvitationDetail.FatherSurname = req.Detail.FatherSurname
-		existingInvitation.InvitationDetail.IsMotherLive = req.Detail.IsMotherLive == "true"
-		existingInvitation.InvitationDetail.IsFatherLive = req.Detail.IsFatherLive == "true"
-		existingInvitation.InvitationDetail.IsBrideMotherLive = req.Detail.IsBrideMotherLive == "true"
-		existingInvitation.InvitationDetail.IsBrideFatherLive = req.Detail.IsBrideFatherLive == "true"
-		existingInvitation.InvitationDetail.IsGroomMotherLive = req.Detail.IsGroomMotherLive == "true"
-		existingInvitation.InvitationDetail.IsGroomFatherLive = req.Detail.IsGroomFatherLive == "true"
+	message := "Hediye notları kapatıldı."
+	if enabled {
+		message = "Hediye notları açıldı."
 	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, message)
+	return c.Redirect(redirectURL, http.StatusFound)
+}
 
-	if err := h.invitationService.UpdateInvitationWithRelations(c.UserContext(), existingInvitation); err != nil {
-		if newFileName != "" {
-			filemanager.DeleteFile("invitations", newFileName)
+func (h *PanelInvitationHandler) DeleteGiftNote(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	noteID, err := strconv.Atoi(c.Params("noteID"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
+	}
+	redirectURL := "/panel/invitations/gifts/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	if err := h.giftService.DeleteNote(c.UserContext(), uint(id), uint(noteID)); err != nil {
+		errMsg := "Not silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
 		}
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye güncellenemedi: "+err.Error())
-		return c.Redirect("/panel/invitations/update/"+strconv.Itoa(id), http.StatusSeeOther)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
 	}
 
-	if oldPhotoToDelete != "" {
-		filemanager.DeleteFile("invitations", oldPhotoToDelete)
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Not başarıyla silindi."})
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Davetiye başarıyla güncellendi.")
-	return c.Redirect("/panel/invitations", http.StatusFound)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Not başarıyla silindi.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *PanelInvitationHandler) ExportGiftNotes(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.giftService.ExportNotesCSV(c.UserContext(), invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Notlar dışa aktarılamadı: "+err.Error())
+		return c.Redirect("/panel/invitations/gifts/"+strconv.Itoa(id), http.StatusSeeOther)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="hediye-notlari-`+invitation.InvitationKey+`.csv"`)
+	return c.Send(data)
+}
+
+// ShowAccess, davetiyenin görünürlük ayarını gösterir.
+func (h *PanelInvitationHandler) ShowAccess(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+
+	return renderer.Render(c, "panel/invitations/access", "layouts/panel", fiber.Map{
+		"Title":        "Görünürlük ve Şifre",
+		"Invitation":   invitation,
+		"Visibilities": models.InvitationVisibilities,
+	})
+}
+
+// UpdateAccess, davetiyeyi herkese açık, liste dışı veya şifreli yapar.
+// Şifreli modda şifre alanı boş bırakılırsa mevcut şifre korunur.
+func (h *PanelInvitationHandler) UpdateAccess(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	redirectURL := "/panel/invitations/access/" + strconv.Itoa(id)
+
+	if _, err := h.ownedInvitation(c, id); err != nil {
+		return err
+	}
+
+	req, err := requests.ParseAndValidateInvitationVisibilityRequest(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	visibility := models.InvitationVisibility(req.Visibility)
+	if err := h.accessService.SetVisibility(c.UserContext(), uint(id), visibility, req.PIN); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Görünürlük kaydedilemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Davetiye görünürlüğü \""+visibility.Name()+"\" olarak kaydedildi.")
+	return c.Redirect(redirectURL, http.StatusFound)
 }
 
 func (h *PanelInvitationHandler) DeleteInvitation(c *fiber.Ctx) error {
@@ -243,10 +1442,14 @@ func (h *PanelInvitationHandler) DeleteInvitation(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID")
 	}
 
-	invitation, err := h.invitationService.GetInvitationByID(uint(id))
-	if err == nil && invitation.Image != "" {
+	invitation, err := h.ownedInvitation(c, id)
+	if err != nil {
+		return err
+	}
+	if invitation.Image != "" {
 		filemanager.DeleteFile("invitations", invitation.Image)
 	}
+	mediaFiles := h.mediaService.FileNames(c.UserContext(), uint(id))
 
 	if err := h.invitationService.DeleteInvitationWithRelations(c.UserContext(), uint(id)); err != nil {
 		errMsg := "Davetiye silinemedi: " + err.Error()
@@ -256,6 +1459,9 @@ func (h *PanelInvitationHandler) DeleteInvitation(c *fiber.Ctx) error {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
 		return c.Redirect("/panel/invitations", fiber.StatusSeeOther)
 	}
+	for _, fileName := range mediaFiles {
+		filemanager.DeleteFile(services.MediaContentType, fileName)
+	}
 
 	if strings.Contains(c.Get("Accept"), "application/json") {
 		return c.JSON(fiber.Map{"message": "Davetiye başarıyla silindi."})
@@ -277,6 +1483,9 @@ func (h *PanelInvitationHandler) ShowInvitation(c *fiber.Ctx) error {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
+	if userID, _ := c.Locals("userID").(uint); invitation.UserID != userID {
+		return fiber.ErrNotFound
+	}
 
 	return renderer.Render(c, "panel/invitations/show", "layouts/panel", fiber.Map{
 		"Title":      "Davetiye Detayları",
@@ -284,22 +1493,46 @@ func (h *PanelInvitationHandler) ShowInvitation(c *fiber.Ctx) error {
 	})
 }
 
+// ownedInvitation, davetiyeyi yalnızca oturumdaki kullanıcıya aitse yükler.
+// Başka kullanıcının davetiyesi, varlığı belli olmasın diye bulunamamış gibi
+// 404 ile reddedilir.
+func (h *PanelInvitationHandler) ownedInvitation(c *fiber.Ctx, id int) (*models.Invitation, error) {
+	userID, _ := c.Locals("userID").(uint)
+	invitation, err := h.invitationService.GetInvitationForUser(uint(id), userID)
+	if errors.Is(err, services.ErrInvitationNotFound) {
+		return nil, fiber.ErrNotFound
+	}
+	return invitation, err
+}
+
 func (h *PanelInvitationHandler) renderInvitationFormError(c *fiber.Ctx, template, title string, req any, message string, fallback ...*models.Invitation) error {
 	form, ok := req.(requests.InvitationRequest)
 	if !ok {
 		return c.Status(http.StatusInternalServerError).SendString("Sunucu Hatası")
 	}
 
+	schedule, _ := form.Schedule()
+	reminderEnabled, reminderLeadHours, reminderChannel := reminderSettings(form)
 	invitation := &models.Invitation{
-		CategoryID:    form.CategoryID,
-		Venue:         form.Venue,
-		Address:       form.Address,
-		Location:      form.Location,
-		Telephone:     form.Telephone,
-		Time:          form.Time,
-		IsConfirmed:   form.IsConfirmed == "true",
-		IsParticipant: form.IsParticipant == "true",
-		IsFree:        form.IsFree == "true",
+		CategoryID:        form.CategoryID,
+		Venue:             form.Venue,
+		Address:           form.Address,
+		Location:          form.Location,
+		Telephone:         form.Telephone,
+		EventAt:           schedule.EventAt,
+		Timezone:          schedule.Timezone,
+		PublishAt:         schedule.PublishAt,
+		ExpireAt:          schedule.ExpireAt,
+		IsConfirmed:       form.IsConfirmed == "true",
+		IsParticipant:     form.IsParticipant == "true",
+		IsFree:            form.IsFree == "true",
+		ReminderEnabled:   reminderEnabled,
+		ReminderLeadHours: reminderLeadHours,
+		ReminderChannel:   reminderChannel,
+		MaxGuests:         form.MaxGuests,
+		MaxGuestsPerRSVP:  form.MaxGuestsPerRSVP,
+		RSVPDeadline:      schedule.RSVPDeadline,
+		WaitlistEnabled:   form.WaitlistEnabled == "true",
 		InvitationDetail: &models.InvitationDetail{
 			Title:              form.Detail.Title,
 			BrideName:          form.Detail.BrideName,
@@ -339,5 +1572,73 @@ func (h *PanelInvitationHandler) renderInvitationFormError(c *fiber.Ctx, templat
 		renderer.FlashErrorKeyView: message,
 		"Invitation":               invitation,
 		"Categories":               categories,
+		"Timezones":                requests.InvitationTimezones,
+		"ReminderChannels":         services.ReminderChannels,
+		"ReminderPreview":          h.reminderService.Preview(invitation).Body,
 	}, http.StatusBadRequest)
 }
+
+func reminderSettings(req requests.InvitationRequest) (bool, int, string) {
+	leadHours := req.ReminderLeadHours
+	if leadHours == 0 {
+		leadHours = services.DefaultReminderLeadHours()
+	}
+	channel := req.ReminderChannel
+	if channel == "" {
+		channel = string(notifier.ChannelSMS)
+	}
+	return req.ReminderEnabled == "true", services.ClampReminderLeadHours(leadHours), channel
+}
+
+func questionInput(req requests.InvitationQuestionRequest) services.QuestionInput {
+	return services.QuestionInput{
+		Label:      req.Label,
+		Type:       models.QuestionType(req.Type),
+		Options:    req.Options,
+		IsRequired: req.IsRequired == "true",
+		SortOrder:  req.SortOrder,
+	}
+}
+
+// eventInput, etkinlik formunu doğrular ve zamanları davetiyenin saat
+// diliminde çözer.
+func eventInput(c *fiber.Ctx, invitation *models.Invitation) (services.EventInput, error) {
+	req, err := requests.ParseAndValidateInvitationEventRequest(c)
+	if err != nil {
+		return services.EventInput{}, err
+	}
+	schedule, err := req.Schedule(invitation.TimeLocation())
+	if err != nil {
+		return services.EventInput{}, err
+	}
+	return services.EventInput{
+		Name:        req.Name,
+		Venue:       req.Venue,
+		Address:     req.Address,
+		Latitude:    schedule.Latitude,
+		Longitude:   schedule.Longitude,
+		StartAt:     schedule.StartAt,
+		EndAt:       schedule.EndAt,
+		RSVPEnabled: req.RSVPEnabled == "true",
+		SortOrder:   req.SortOrder,
+	}, nil
+}
+
+// galleryRedirectURL, galeri sayfasına seçili durum filtresini koruyarak döner.
+func galleryRedirectURL(prefix string, invitationID int, status string) string {
+	redirectURL := prefix + "/invitations/gallery/" + strconv.Itoa(invitationID)
+	if models.MediaStatus(status).IsValid() {
+		redirectURL += "?durum=" + status
+	}
+	return redirectURL
+}
+
+func giftAccountInput(req requests.InvitationGiftAccountRequest) services.GiftAccountInput {
+	return services.GiftAccountInput{
+		BankID:        req.BankID,
+		IBAN:          req.IBAN,
+		AccountHolder: req.AccountHolder,
+		Description:   req.Description,
+		SortOrder:     req.SortOrder,
+	}
+}
@@ -1,18 +1,71 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"zatrano/configs/sessionconfig"
+	"zatrano/models"
+	"zatrano/pkg/calendar"
+	"zatrano/pkg/filemanager"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/qrcode"
 	"zatrano/pkg/renderer"
+	"zatrano/pkg/vcard"
+	"zatrano/requests"
+	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// Statik sayfa adları views/website altındaki dosya adlarıyla eşleşir; alt
+// klasörlerdeki şablonlar ve aşağıdaki sayfalar doğrudan açılamaz.
+var staticPageNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+const (
+	qrMinSize = 128
+	qrMaxSize = 1024
+)
+
+var reservedStaticPages = map[string]bool{
+	"home": true,
+	"card": true,
+}
+
 type WebsiteHandler struct {
+	invitationService  services.IInvitationService
+	cardService        services.ICardService
+	visitService       services.IVisitService
+	participantService services.IInvitationParticipantService
+	questionService    services.IInvitationQuestionService
+	eventService       services.IInvitationEventService
+	guestbookService   services.IInvitationGuestbookService
+	mediaService       services.IInvitationMediaService
+	giftService        services.IInvitationGiftService
+	accessService      services.IInvitationAccessService
 }
 
 func NewWebsiteHandler() *WebsiteHandler {
-	return &WebsiteHandler{}
+	return &WebsiteHandler{
+		invitationService:  services.NewInvitationService(),
+		cardService:        services.NewCardService(),
+		visitService:       services.NewVisitService(),
+		participantService: services.NewInvitationParticipantService(),
+		questionService:    services.NewInvitationQuestionService(),
+		eventService:       services.NewInvitationEventService(),
+		guestbookService:   services.NewInvitationGuestbookService(),
+		mediaService:       services.NewInvitationMediaService(),
+		giftService:        services.NewInvitationGiftService(),
+		accessService:      services.NewInvitationAccessService(),
+	}
 }
 
 func (h *WebsiteHandler) ShowHomePage(c *fiber.Ctx) error {
@@ -24,20 +77,570 @@ func (h *WebsiteHandler) ShowTermsOfUse(c *fiber.Ctx) error {
 	return renderer.Render(c, "website/terms_of_use", "layouts/website", fiber.Map{}, http.StatusOK)
 }
 
+// ShowStaticPage, adı bir statik sayfa şablonuna karşılık gelmeyen istekleri
+// davetiye rotasına devreder.
 func (h *WebsiteHandler) ShowStaticPage(c *fiber.Ctx) error {
 	page := c.Params("staticPageName")
+	if !staticPageNamePattern.MatchString(page) || reservedStaticPages[page] {
+		return c.Next()
+	}
+	if _, err := os.Stat(filepath.Join("views", "website", page+".html")); err != nil {
+		return c.Next()
+	}
 	template := "website/" + page
 	return renderer.Render(c, template, "layouts/website", fiber.Map{}, http.StatusOK)
 }
 
 func (h *WebsiteHandler) ShowInvitation(c *fiber.Ctx) error {
 	invitationKey := c.Params("invitationKey")
-	// TODO: Davetiye verisini çek ve render et
-	return renderer.Render(c, "website/invitation", "layouts/website", fiber.Map{"InvitationKey": invitationKey}, http.StatusOK)
+	invitation, state, err := h.invitationService.GetPublicInvitation(c.UserContext(), invitationKey)
+	if err != nil {
+		// Sonraki rotalar (ör. /panel, /dashboard) eşleşmezse Fiber 404 döner.
+		return c.Next()
+	}
+	if invitation.NoIndex() {
+		c.Set("X-Robots-Tag", "noindex, nofollow")
+	}
+
+	// Kişiye özel bağlantı (?g=) geçersizse sayfa genel davetiye olarak gösterilir.
+	var guest *models.InvitationParticipant
+	guestToken := c.Query("g")
+	if guestToken != "" {
+		if guest, err = h.participantService.ResolveGuest(c.UserContext(), invitation, guestToken); err != nil {
+			guest = nil
+			guestToken = ""
+		}
+	}
+
+	// Şifreli davetiyede geçerli bir kişiye özel bağlantı şifrenin yerine geçer.
+	if !h.hasInvitationAccess(c, invitation) {
+		if guest == nil {
+			return renderer.Render(c, "website/invitation/access", "layouts/website", fiber.Map{
+				"Invitation": invitation,
+				"NoIndex":    true,
+			}, http.StatusForbidden)
+		}
+		h.grantInvitationAccess(c, invitation)
+	}
+
+	if state == models.InvitationArchived {
+		return renderer.Render(c, "website/invitation/ended", "layouts/website", fiber.Map{
+			"Invitation": invitation,
+			"NoIndex":    invitation.NoIndex(),
+		}, http.StatusGone)
+	}
+	if guest != nil {
+		h.participantService.RecordOpen(c.UserContext(), guest)
+	}
+
+	var answers models.ParticipantAnswers
+	if guest != nil {
+		answers = guest.Answers
+	}
+	now := time.Now()
+	seatsLeft, limited := 0, false
+	if invitation.RSVPOpenAt(now) {
+		seatsLeft, limited = h.participantService.SeatsLeft(c.UserContext(), invitation)
+	}
+
+	var guestbook []models.InvitationGuestbookEntry
+	if invitation.GuestbookEnabled {
+		guestbook = h.guestbookService.ApprovedEntries(c.UserContext(), invitation.ID)
+	}
+
+	gallery := h.mediaService.ApprovedMedia(c.UserContext(), invitation.ID)
+
+	h.visitService.TrackView(c.UserContext(), models.VisitTargetInvitation, invitation.ID, visitRequest(c))
+	return renderer.Render(c, "website/invitation/show", "layouts/website", fiber.Map{
+		"Questions":   h.questionService.FormFields(invitation.Questions, answers),
+		"EventFields": h.eventService.FormFields(invitation.Events, guest),
+		"Invitation":  invitation,
+		"Ended":       state == models.InvitationEnded,
+		"RSVPOpen":    invitation.RSVPOpenAt(now),
+		"RSVPClosed":  invitation.IsParticipant && state == models.InvitationActive && invitation.RSVPDeadlinePassed(now),
+		"SeatsLeft":   seatsLeft,
+		"Limited":     limited,
+		"Guest":       guest,
+		"GuestToken":  guestToken,
+		"Guestbook":   guestbook,
+		"Gallery":     gallery,
+		"NoIndex":     invitation.NoIndex(),
+		// Flash mesajı anı defteri, galeri veya hediye formundan geldiyse o bölümde gösterilir.
+		"GuestbookFeedback": c.Query("defter") == "1",
+		"GalleryFeedback":   c.Query("galeri") == "1",
+		"GiftFeedback":      c.Query("hediye") == "1",
+	}, http.StatusOK)
+}
+
+// UnlockInvitation, şifreli davetiyenin giriş formunu doğrular. Doğru şifrede
+// erişim imzalı bir çerezle hatırlanır ve misafir davetiyeye yönlendirilir.
+func (h *WebsiteHandler) UnlockInvitation(c *fiber.Ctx) error {
+	invitationKey := c.Params("invitationKey")
+	invitation, _, err := h.invitationService.GetPublicInvitation(c.UserContext(), invitationKey)
+	if err != nil {
+		return c.Next()
+	}
+	redirectURL := "/" + invitation.InvitationKey
+	if !invitation.IsProtected() {
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	req, err := requests.ParseAndValidateInvitationPINRequest(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if err := h.accessService.VerifyPIN(c.UserContext(), invitation, req.PIN, c.IP()); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye açılamadı: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	h.grantInvitationAccess(c, invitation)
+	return c.Redirect(redirectURL, http.StatusSeeOther)
+}
+
+// SubmitRSVP, davetiye sayfasındaki katılım formunu kaydeder ve misafiri
+// (varsa kişiye özel bağlantısıyla) davetiyeye geri yönlendirir.
+func (h *WebsiteHandler) SubmitRSVP(c *fiber.Ctx) error {
+	invitationKey := c.Params("invitationKey")
+	invitation, _, err := h.invitationService.GetPublicInvitation(c.UserContext(), invitationKey)
+	if err != nil {
+		return c.Next()
+	}
+	if !h.hasInvitationAccess(c, invitation) {
+		return c.Redirect("/"+invitation.InvitationKey, http.StatusSeeOther)
+	}
+
+	req, err := requests.ParseAndValidateRSVPRequest(c)
+	redirectURL := "/" + invitation.InvitationKey
+	if req.GuestToken != "" {
+		redirectURL += "?g=" + url.QueryEscape(req.GuestToken)
+	}
+	redirectURL += "#katilim"
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	var guest *models.InvitationParticipant
+	if req.GuestToken != "" {
+		if guest, err = h.participantService.ResolveGuest(c.UserContext(), invitation, req.GuestToken); err != nil {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kişiye özel bağlantınız geçersiz.")
+			return c.Redirect("/"+invitation.InvitationKey+"#katilim", http.StatusSeeOther)
+		}
+	}
+
+	// Katılmayacağını bildiren misafirden zorunlu soruları yanıtlaması beklenmez.
+	answers, err := h.questionService.ValidateAnswers(invitation.Questions, prefixedFormValues(c, "q_"), req.Attending == "yes")
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	eventResponses, err := h.eventService.BuildResponses(invitation.Events, prefixedFormValues(c, "event_"), req.Attending == "yes")
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	participant, err := h.participantService.Respond(c.UserContext(), invitation, guest, services.RSVPInput{
+		Title:          strings.TrimSpace(req.Title),
+		PhoneNumber:    strings.TrimSpace(req.PhoneNumber),
+		GuestCount:     req.GuestCount,
+		Attending:      req.Attending == "yes",
+		Answers:        answers,
+		EventResponses: eventResponses,
+	})
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Yanıtınız kaydedilemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	var message string
+	switch participant.Status {
+	case models.ParticipantWaitlisted:
+		message = "Kontenjan dolu olduğu için yedek listeye alındınız. Yer açılırsa davet sahibi sizinle iletişime geçecektir."
+	case models.ParticipantDeclined:
+		message = "Yanıtınız için teşekkürler, katılamayacağınızı not ettik."
+	default:
+		message = "Yanıtınız için teşekkürler, katılımınız kaydedildi."
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, message)
+	return c.Redirect(redirectURL, http.StatusSeeOther)
+}
+
+// SubmitGuestbook, anı defterine bırakılan mesajı onay bekleyen olarak
+// kaydeder. Bal küpü alanı dolu gelen istekler kaydedilmeden başarılı gibi
+// yanıtlanır.
+func (h *WebsiteHandler) SubmitGuestbook(c *fiber.Ctx) error {
+	invitationKey := c.Params("invitationKey")
+	invitation, state, err := h.invitationService.GetPublicInvitation(c.UserContext(), invitationKey)
+	if err != nil {
+		return c.Next()
+	}
+	if !h.hasInvitationAccess(c, invitation) {
+		return c.Redirect("/"+invitation.InvitationKey, http.StatusSeeOther)
+	}
+
+	req, err := requests.ParseAndValidateGuestbookRequest(c)
+	redirectURL := "/" + invitation.InvitationKey + "?defter=1"
+	if req.GuestToken != "" {
+		redirectURL += "&g=" + url.QueryEscape(req.GuestToken)
+	}
+	redirectURL += "#ani-defteri"
+
+	const successMessage = "Mesajınız için teşekkürler! Davet sahibi onayladıktan sonra yayınlanacak."
+	if strings.TrimSpace(req.Website) != "" {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, successMessage)
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if state == models.InvitationArchived {
+		return c.SendStatus(http.StatusGone)
+	}
+
+	if err := h.guestbookService.Submit(c.UserContext(), invitation, services.GuestbookInput{
+		Name:    req.Name,
+		Message: req.Message,
+	}); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Mesajınız kaydedilemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, successMessage)
+	return c.Redirect(redirectURL, http.StatusSeeOther)
+}
+
+// UploadGalleryMedia, misafirin ortak yükleme koduyla gönderdiği fotoğrafları
+// onay bekleyen olarak galeriye ekler.
+func (h *WebsiteHandler) UploadGalleryMedia(c *fiber.Ctx) error {
+	invitationKey := c.Params("invitationKey")
+	invitation, state, err := h.invitationService.GetPublicInvitation(c.UserContext(), invitationKey)
+	if err != nil {
+		return c.Next()
+	}
+	if !h.hasInvitationAccess(c, invitation) {
+		return c.Redirect("/"+invitation.InvitationKey, http.StatusSeeOther)
+	}
+	if state == models.InvitationArchived {
+		return c.SendStatus(http.StatusGone)
+	}
+
+	req, err := requests.ParseAndValidateGalleryUploadRequest(c)
+	redirectURL := "/" + invitation.InvitationKey + "?galeri=1"
+	if req.GuestToken != "" {
+		redirectURL += "&g=" + url.QueryEscape(req.GuestToken)
+	}
+	redirectURL += "#galeri"
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if err := h.mediaService.CheckUploadCode(invitation, req.Code); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraflar yüklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	summary, err := h.mediaService.Summary(c.UserContext(), invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraflar yüklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if summary.Remaining == 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Galeri doldu, yeni fotoğraf kabul edilmiyor.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	fileNames, err := filemanager.UploadFiles(c, "photos", services.MediaContentType, summary.Remaining)
+	if err != nil {
+		message := "Fotoğraflar yüklenemedi: " + err.Error()
+		if errors.Is(err, filemanager.ErrTooManyFiles) {
+			message = fmt.Sprintf("Tek seferde en fazla %d fotoğraf yükleyebilirsiniz.", summary.Remaining)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	if err := h.mediaService.AddMedia(c.UserContext(), invitation.ID, services.MediaUpload{
+		FileNames:    fileNames,
+		Source:       models.MediaSourceGuest,
+		UploaderName: req.Name,
+	}); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraflar yüklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Fotoğraflarınız için teşekkürler! Davet sahibi onayladıktan sonra galeride yayınlanacak.")
+	return c.Redirect(redirectURL, http.StatusSeeOther)
+}
+
+// SubmitGiftNote, misafirin hediyesiyle birlikte bıraktığı notu kaydeder.
+// Kişiye özel bağlantıyla gelen misafirin notu katılımcı kaydına bağlanır.
+func (h *WebsiteHandler) SubmitGiftNote(c *fiber.Ctx) error {
+	invitationKey := c.Params("invitationKey")
+	invitation, state, err := h.invitationService.GetPublicInvitation(c.UserContext(), invitationKey)
+	if err != nil {
+		return c.Next()
+	}
+	if !h.hasInvitationAccess(c, invitation) {
+		return c.Redirect("/"+invitation.InvitationKey, http.StatusSeeOther)
+	}
+
+	req, err := requests.ParseAndValidateGiftNoteRequest(c)
+	redirectURL := "/" + invitation.InvitationKey + "?hediye=1"
+	if req.GuestToken != "" {
+		redirectURL += "&g=" + url.QueryEscape(req.GuestToken)
+	}
+	redirectURL += "#hediye"
+
+	const successMessage = "Notunuz için teşekkürler, davet sahibine iletildi."
+	if strings.TrimSpace(req.Website) != "" {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, successMessage)
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if state == models.InvitationArchived {
+		return c.SendStatus(http.StatusGone)
+	}
+
+	// Geçersiz kişiye özel bağlantıyla gelen not, misafire bağlanmadan kaydedilir.
+	var guest *models.InvitationParticipant
+	if req.GuestToken != "" {
+		if guest, err = h.participantService.ResolveGuest(c.UserContext(), invitation, req.GuestToken); err != nil {
+			guest = nil
+		}
+	}
+
+	if err := h.giftService.SubmitNote(c.UserContext(), invitation, guest, services.GiftNoteInput{
+		Name:          req.Name,
+		Note:          req.Note,
+		GiftAccountID: req.GiftAccountID,
+	}); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Notunuz kaydedilemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, successMessage)
+	return c.Redirect(redirectURL, http.StatusSeeOther)
+}
+
+// DownloadInvitationCalendar, davetiyeyi takvim uygulamalarına aktarılabilecek
+// bir .ics dosyası olarak indirir.
+func (h *WebsiteHandler) DownloadInvitationCalendar(c *fiber.Ctx) error {
+	invitationKey := c.Params("invitationKey")
+	invitation, state, err := h.invitationService.GetPublicInvitation(c.UserContext(), invitationKey)
+	if err != nil || invitation.EventAt.IsZero() {
+		return c.Next()
+	}
+	if !h.hasInvitationAccess(c, invitation) {
+		return c.Redirect("/"+invitation.InvitationKey, http.StatusSeeOther)
+	}
+	if state == models.InvitationArchived {
+		return c.SendStatus(http.StatusGone)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="davetiye-`+invitation.InvitationKey+`.ics"`)
+	return c.Send(calendar.FromInvitation(invitation).ICS(time.Now()))
+}
+
+// DownloadEventCalendar, çok etkinlikli davetiyelerde tek bir etkinliği .ics
+// dosyası olarak indirir.
+func (h *WebsiteHandler) DownloadEventCalendar(c *fiber.Ctx) error {
+	invitationKey := c.Params("invitationKey")
+	invitation, state, err := h.invitationService.GetPublicInvitation(c.UserContext(), invitationKey)
+	if err != nil {
+		return c.Next()
+	}
+	if !h.hasInvitationAccess(c, invitation) {
+		return c.Redirect("/"+invitation.InvitationKey, http.StatusSeeOther)
+	}
+	if state == models.InvitationArchived {
+		return c.SendStatus(http.StatusGone)
+	}
+
+	eventID, err := c.ParamsInt("eventID")
+	if err != nil || eventID <= 0 {
+		return c.Next()
+	}
+	for i := range invitation.Events {
+		event := &invitation.Events[i]
+		if event.ID != uint(eventID) {
+			continue
+		}
+		c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="davetiye-`+invitation.InvitationKey+`-`+strconv.Itoa(eventID)+`.ics"`)
+		return c.Send(calendar.FromInvitationEvent(invitation, event).ICS(time.Now()))
+	}
+	return c.Next()
+}
+
+// InvitationQRCode, davetiye sahiplerinin yayından önce de baskı
+// alabilmesi için yayın durumuna bakmadan QR kodu üretir.
+func (h *WebsiteHandler) InvitationQRCode(c *fiber.Ctx) error {
+	invitationKey := c.Params("invitationKey")
+	if _, err := h.invitationService.GetInvitationByKey(c.UserContext(), invitationKey); err != nil {
+		return c.Next()
+	}
+	return sendQRCode(c, publicURL(c, "/"+invitationKey))
 }
 
 func (h *WebsiteHandler) ShowCard(c *fiber.Ctx) error {
-	cardSlug := c.Params("cardSlug")
-	// TODO: Kartvizit verisini çek ve render et
-	return renderer.Render(c, "website/card", "layouts/website", fiber.Map{"CardSlug": cardSlug}, http.StatusOK)
+	card, err := h.cardService.GetPublicCard(c.UserContext(), c.Params("cardSlug"))
+	if err != nil {
+		return c.Next()
+	}
+	h.visitService.TrackView(c.UserContext(), models.VisitTargetCard, card.ID, visitRequest(c))
+	return renderer.Render(c, "website/card", "layouts/website", fiber.Map{
+		"Card": card,
+	}, http.StatusOK)
+}
+
+// DownloadCardVCard, kartviziti telefon rehberine eklenebilecek vCard 4.0
+// dosyası olarak indirir.
+func (h *WebsiteHandler) DownloadCardVCard(c *fiber.Ctx) error {
+	card, err := h.cardService.GetPublicCard(c.UserContext(), c.Params("cardSlug"))
+	if err != nil {
+		return c.Next()
+	}
+
+	c.Set(fiber.HeaderContentType, "text/vcard; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+card.Slug+`.vcf"`)
+	return c.Send(vcard.FromCard(card).Bytes(card.UpdatedAt))
+}
+
+func (h *WebsiteHandler) CardQRCode(c *fiber.Ctx) error {
+	card, err := h.cardService.GetPublicCard(c.UserContext(), c.Params("cardSlug"))
+	if err != nil {
+		return c.Next()
+	}
+	return sendQRCode(c, publicURL(c, "/@"+card.Slug))
+}
+
+// TrackInvitationClick ve TrackCardClick, sayfadaki bağlantı ve kopyalama
+// tıklamalarını navigator.sendBeacon ile gelen form verisinden kaydeder.
+func (h *WebsiteHandler) TrackInvitationClick(c *fiber.Ctx) error {
+	invitation, _, err := h.invitationService.GetPublicInvitation(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return c.Next()
+	}
+	if !h.hasInvitationAccess(c, invitation) {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	known := services.ClickLabels{}
+	for _, account := range invitation.GiftAccounts {
+		known["iban"] = append(known["iban"], account.Bank.Name)
+	}
+	return trackClick(c, h.visitService, models.VisitTargetInvitation, invitation.ID, known)
+}
+
+func (h *WebsiteHandler) TrackCardClick(c *fiber.Ctx) error {
+	card, err := h.cardService.GetPublicCard(c.UserContext(), c.Params("cardSlug"))
+	if err != nil {
+		return c.Next()
+	}
+	known := services.ClickLabels{}
+	for _, social := range card.CardSocialMedia {
+		known["social"] = append(known["social"], social.SocialMedia.Name)
+	}
+	for _, bank := range card.CardBanks {
+		known["iban"] = append(known["iban"], bank.Bank.Name)
+	}
+	return trackClick(c, h.visitService, models.VisitTargetCard, card.ID, known)
+}
+
+func trackClick(c *fiber.Ctx, visitService services.IVisitService, targetType models.VisitTargetType, targetID uint, known services.ClickLabels) error {
+	if !visitService.TrackClick(targetType, targetID, c.FormValue("kind"), c.FormValue("label"), known) {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// hasInvitationAccess, şifreli davetiyelerde erişim çerezini doğrular; diğer
+// davetiyeler herkese açıktır.
+func (h *WebsiteHandler) hasInvitationAccess(c *fiber.Ctx, invitation *models.Invitation) bool {
+	if !invitation.IsProtected() {
+		return true
+	}
+	return h.accessService.HasAccess(invitation, c.Cookies(sessionconfig.InvitationAccessCookieName), time.Now())
+}
+
+func (h *WebsiteHandler) grantInvitationAccess(c *fiber.Ctx, invitation *models.Invitation) {
+	token, expires := h.accessService.AccessToken(invitation, time.Now())
+	sessionconfig.SetInvitationAccessCookie(c, invitation.InvitationKey, token, expires)
+}
+
+func visitRequest(c *fiber.Ctx) services.VisitRequest {
+	return services.VisitRequest{
+		IP:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		Referrer:  c.Get(fiber.HeaderReferer),
+		Host:      c.Hostname(),
+	}
+}
+
+// sendQRCode, rotadaki format parametresine göre PNG veya SVG QR kodu döner.
+// PNG boyutu ?size= ile 128-1024 piksel arasında seçilebilir.
+func sendQRCode(c *fiber.Ctx, content string) error {
+	size := c.QueryInt("size", qrcode.DefaultSize)
+	if size < qrMinSize {
+		size = qrMinSize
+	}
+	if size > qrMaxSize {
+		size = qrMaxSize
+	}
+
+	var (
+		data        []byte
+		err         error
+		contentType string
+	)
+	switch c.Params("format") {
+	case "png":
+		data, err = qrcode.PNG(content, size)
+		contentType = "image/png"
+	case "svg":
+		data, err = qrcode.SVG(content, size)
+		contentType = "image/svg+xml"
+	default:
+		return c.Next()
+	}
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "QR kod oluşturulamadı")
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	if c.Query("download") != "" {
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="qr.`+c.Params("format")+`"`)
+	}
+	return c.Send(data)
+}
+
+// prefixedFormValues, katılım formundaki "<önek><ID>" alanlarını (ör. "q_12",
+// "event_3") ID'ye göre toplar; çoklu seçimlerde aynı ad birden çok kez gelir.
+func prefixedFormValues(c *fiber.Ctx, prefix string) map[string][]string {
+	raw := make(map[string][]string)
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		if id, ok := strings.CutPrefix(string(key), prefix); ok {
+			raw[id] = append(raw[id], string(value))
+		}
+	})
+	return raw
+}
+
+// publicURL, APP_BASE_URL tanımlıysa onu, değilse isteğin adresini kullanır.
+func publicURL(c *fiber.Ctx, path string) string {
+	base := strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")
+	if base == "" {
+		base = c.BaseURL()
+	}
+	return base + path
 }
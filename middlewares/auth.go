@@ -13,6 +13,9 @@ import (
 func AuthMiddleware(c *fiber.Ctx) error {
 	userID, err := sessionconfig.GetUserIDFromSession(c)
 	if err != nil || userID == 0 {
+		userID = restoreRememberedSession(c)
+	}
+	if userID == 0 {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Oturum bilgileri geçersiz")
 		return c.Redirect("/auth/login")
 	}
@@ -25,6 +28,10 @@ func AuthMiddleware(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login")
 	}
 
+	if sess, err := sessionconfig.SessionStart(c); err == nil {
+		services.NewSessionService().Touch(sess.ID(), c.IP(), c.Get(fiber.HeaderUserAgent))
+	}
+
 	ctx := context.WithValue(c.Context(), "user_id", userID)
 	ctx = context.WithValue(ctx, "user_type", user.Type)
 	ctx = context.WithValue(ctx, "user_email", user.Email)
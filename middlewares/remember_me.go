@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// restoreRememberedSession, oturumu olmayan ancak geçerli "beni hatırla"
+// çerezi taşıyan kullanıcı için oturumu sessizce yeniden kurar ve çerezdeki
+// tokenı döndürür. Başarısız olursa 0 döner ve çerezi siler.
+func restoreRememberedSession(c *fiber.Ctx) uint {
+	rawToken := c.Cookies(sessionconfig.RememberCookieName)
+	if rawToken == "" {
+		return 0
+	}
+
+	login, err := services.NewRememberMeService().Consume(c.UserContext(), rawToken, c.IP(), c.Get(fiber.HeaderUserAgent))
+	if err != nil {
+		sessionconfig.ClearRememberCookie(c)
+		return 0
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return 0
+	}
+	if err := sess.Regenerate(); err != nil {
+		logconfig.Log.Error("Oturum kimliği yenilenemedi", zap.Uint("user_id", login.User.ID), zap.Error(err))
+		return 0
+	}
+	sess.Set("user_id", login.User.ID)
+	sess.Set("user_type", string(login.User.Type))
+	if err := sess.Save(); err != nil {
+		logconfig.Log.Error("Hatırlanan oturum kaydedilemedi", zap.Uint("user_id", login.User.ID), zap.Error(err))
+		return 0
+	}
+
+	if err := services.NewSessionService().Register(c.UserContext(), login.User.ID, sess.ID(), c.IP(), c.Get(fiber.HeaderUserAgent)); err != nil {
+		logconfig.Log.Warn("Oturum kaydı oluşturulamadı", zap.Uint("user_id", login.User.ID), zap.Error(err))
+	}
+
+	if login.Token != "" {
+		sessionconfig.SetRememberCookie(c, login.Token, login.ExpiresAt)
+	}
+	return login.User.ID
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"zatrano/configs/sessionconfig"
+	"zatrano/pkg/flashmessages"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TwoFactorMiddleware(c *fiber.Ctx) error {
+	userID, err := sessionconfig.GetUserIDFromSession(c)
+	if err != nil || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	authService := services.NewAuthService()
+	user, err := authService.GetUserProfile(userID)
+	if err != nil {
+		sessionconfig.DestroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kullanıcı bulunamadı")
+		return c.Redirect("/auth/login")
+	}
+
+	twoFactorService := services.NewTwoFactorService()
+	if twoFactorService.IsRequiredFor(user) && !user.TwoFactorEnabled {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Devam etmek için iki adımlı doğrulamayı etkinleştirmeniz gerekiyor")
+		return c.Redirect("/auth/profile/two-factor/setup")
+	}
+
+	return c.Next()
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type AuthThrottleScope string
+
+const (
+	ThrottleScopeLoginEmail        AuthThrottleScope = "login_email"
+	ThrottleScopeLoginIP           AuthThrottleScope = "login_ip"
+	ThrottleScopePasswordReset     AuthThrottleScope = "password_reset"
+	ThrottleScopeVerificationEmail AuthThrottleScope = "verification_email"
+	ThrottleScopeEmailChange       AuthThrottleScope = "email_change"
+	// ThrottleScopeTwoFactor, parolası doğru girilen hesaptaki hatalı iki
+	// adımlı doğrulama kodlarıdır; tanımlayıcı normalize edilmiş e-postadır.
+	ThrottleScopeTwoFactor AuthThrottleScope = "two_factor"
+	// ThrottleScopeInvitationPIN, şifreli davetiyelerdeki hatalı şifre
+	// denemeleridir; tanımlayıcı "<davetiye ID>:<IP>" biçimindedir.
+	ThrottleScopeInvitationPIN AuthThrottleScope = "invitation_pin"
+	// ThrottleScopeInvitationPINTotal, bir davetiyeye farklı IP'lerden yapılan
+	// toplam hatalı denemelerdir; tanımlayıcı davetiye ID'sidir.
+	ThrottleScopeInvitationPINTotal AuthThrottleScope = "invitation_pin_total"
+)
+
+// AuthThrottle, bir kapsam (scope) ve tanımlayıcı (email veya IP) için
+// başarısız deneme sayacını ve varsa kilit bitiş zamanını tutar.
+type AuthThrottle struct {
+	ID            uint `gorm:"primarykey"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Scope         AuthThrottleScope `gorm:"type:varchar(50);not null;uniqueIndex:idx_auth_throttles_scope_identifier"`
+	Identifier    string            `gorm:"size:255;not null;uniqueIndex:idx_auth_throttles_scope_identifier"`
+	Attempts      int               `gorm:"not null;default:0"`
+	LastAttemptAt time.Time         `gorm:"not null"`
+	LockedUntil   *time.Time        `gorm:"index"`
+}
+
+func (AuthThrottle) TableName() string {
+	return "auth_throttles"
+}
+
+func (t *AuthThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
@@ -4,6 +4,9 @@ type Bank struct {
 	BaseModel
 	IsActive bool   `gorm:"not null;index"`
 	Name     string `gorm:"size:255;not null;index"`
+	// Code, TR IBAN'larının 5-9. karakterlerindeki EFT banka kodudur; IBAN'dan
+	// banka tespiti için kullanılır.
+	Code string `gorm:"size:5;index"`
 }
 
 func (Bank) TableName() string {
@@ -1,33 +1,241 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 type Invitation struct {
 	BaseModel
 
-	InvitationKey string    `gorm:"type:varchar(100);uniqueIndex;not null"`
-	Image         string    `gorm:"type:varchar(255);not null"`
-	UserID        uint      `gorm:"index;not null"`
-	CategoryID    uint      `gorm:"index;not null"`
-	IsConfirmed   bool      `gorm:"not null;default:false;index"`
-	IsParticipant bool      `gorm:"not null;default:false;index"`
-	IsFree        bool      `gorm:"not null;index"`
-	Description   string    `gorm:"type:text"`
-	Venue         string    `gorm:"type:varchar(255)"`
-	Address       string    `gorm:"type:varchar(255)"`
-	Location      string    `gorm:"type:varchar(255)"`
-	Link          string    `gorm:"type:varchar(255)"`
-	Telephone     string    `gorm:"type:varchar(20)"`
-	Note          string    `gorm:"type:text"`
-	Date          time.Time `gorm:"index"`
-	Time          string    `gorm:"type:varchar(10)"`
-
-	User             *User                   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Category         *InvitationCategory     `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	InvitationDetail *InvitationDetail       `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Participants     []InvitationParticipant `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	InvitationKey string `gorm:"type:varchar(100);uniqueIndex;not null"`
+	Image         string `gorm:"type:varchar(255);not null"`
+	UserID        uint   `gorm:"index;not null"`
+	CategoryID    uint   `gorm:"index;not null"`
+	IsConfirmed   bool   `gorm:"not null;default:false;index"`
+	IsParticipant bool   `gorm:"not null;default:false;index"`
+	IsFree        bool   `gorm:"not null;index"`
+	Description   string `gorm:"type:text"`
+	Venue         string `gorm:"type:varchar(255)"`
+	Address       string `gorm:"type:varchar(255)"`
+	Location      string `gorm:"type:varchar(255)"`
+	Link          string `gorm:"type:varchar(255)"`
+	Telephone     string `gorm:"type:varchar(20)"`
+	Note          string `gorm:"type:text"`
+	// EventAt UTC saklanır; Timezone davetiye sahibinin girdiği yerel saati gösterir.
+	EventAt    time.Time  `gorm:"index"`
+	Timezone   string     `gorm:"type:varchar(64);not null;default:'Europe/Istanbul'"`
+	PublishAt  *time.Time `gorm:"index"`
+	ExpireAt   *time.Time `gorm:"index"`
+	ArchivedAt *time.Time `gorm:"index"`
+	// Katılımcılara etkinlikten ReminderLeadHours saat önce hatırlatma gönderilir.
+	ReminderEnabled   bool   `gorm:"not null;default:false;index"`
+	ReminderLeadHours int    `gorm:"not null;default:24"`
+	ReminderChannel   string `gorm:"type:varchar(20);not null;default:'sms'"`
+	// Katılım sınırları; 0 sınırsız demektir. Kapasite "katılıyor" durumundaki
+	// yanıtların kişi sayılarıyla hesaplanır, dolduğunda yanıtlar yedek
+	// listeye alınır (WaitlistEnabled) veya reddedilir.
+	MaxGuests        int        `gorm:"not null;default:0"`
+	MaxGuestsPerRSVP int        `gorm:"column:max_guests_per_rsvp;not null;default:0"`
+	RSVPDeadline     *time.Time `gorm:"column:rsvp_deadline"`
+	WaitlistEnabled  bool       `gorm:"not null;default:false"`
+	// GuestbookEnabled açıksa davetiye sayfasında anı defteri gösterilir.
+	GuestbookEnabled bool `gorm:"not null;default:false"`
+	// GalleryGuestUploads açıksa misafirler GalleryUploadCode ile galeriye
+	// fotoğraf yükleyebilir.
+	GalleryGuestUploads bool   `gorm:"not null;default:false"`
+	GalleryUploadCode   string `gorm:"type:varchar(32)"`
+	// GiftNotesEnabled açıksa misafirler hediye hesaplarının altından davetiye
+	// sahibine not bırakabilir.
+	GiftNotesEnabled bool `gorm:"not null;default:false"`
+	// Visibility, davetiyenin kimlere açık olduğunu belirler. Şifreli
+	// davetiyeler AccessPINHash ile doğrulanan şifre girilmeden gösterilmez.
+	Visibility    InvitationVisibility `gorm:"type:varchar(20);not null;default:'public'"`
+	AccessPINHash string               `gorm:"type:varchar(255)" json:"-"`
+
+	User             *User                      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Category         *InvitationCategory        `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	InvitationDetail *InvitationDetail          `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Participants     []InvitationParticipant    `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Questions        []InvitationQuestion       `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Events           []InvitationEvent          `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	GuestbookEntries []InvitationGuestbookEntry `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Media            []InvitationMedia          `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	GiftAccounts     []InvitationGiftAccount    `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	GiftNotes        []InvitationGiftNote       `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
 func (Invitation) TableName() string {
 	return "invitations"
 }
+
+const DefaultInvitationTimezone = "Europe/Istanbul"
+
+type InvitationVisibility string
+
+const (
+	// VisibilityPublic davetiye herkese açıktır ve arama motorlarında listelenebilir.
+	VisibilityPublic InvitationVisibility = "public"
+	// VisibilityUnlisted davetiye bağlantıyı bilen herkese açıktır ancak arama
+	// motorlarına kapalıdır (noindex).
+	VisibilityUnlisted InvitationVisibility = "unlisted"
+	// VisibilityProtected davetiye şifre girilmeden gösterilmez.
+	VisibilityProtected InvitationVisibility = "protected"
+)
+
+// InvitationVisibilities, panelde seçeneklerin gösterilme sırasıdır.
+var InvitationVisibilities = []InvitationVisibility{VisibilityPublic, VisibilityUnlisted, VisibilityProtected}
+
+func (v InvitationVisibility) Name() string {
+	switch v {
+	case VisibilityPublic:
+		return "Herkese açık"
+	case VisibilityUnlisted:
+		return "Liste dışı"
+	case VisibilityProtected:
+		return "Şifreli"
+	default:
+		return string(v)
+	}
+}
+
+func (v InvitationVisibility) IsValid() bool {
+	for _, visibility := range InvitationVisibilities {
+		if v == visibility {
+			return true
+		}
+	}
+	return false
+}
+
+// IsProtected, davetiyenin şifreyle korunup korunmadığını döndürür.
+func (i *Invitation) IsProtected() bool {
+	return i.Visibility == VisibilityProtected && i.AccessPINHash != ""
+}
+
+// NoIndex, davetiye sayfasının arama motorlarına kapalı olup olmadığını
+// döndürür; eski kayıtlarda boş değer herkese açık sayılır.
+func (i *Invitation) NoIndex() bool {
+	return i.Visibility != "" && i.Visibility != VisibilityPublic
+}
+
+func (i *Invitation) CheckAccessPIN(pin string) error {
+	return bcrypt.CompareHashAndPassword([]byte(i.AccessPINHash), []byte(pin))
+}
+
+func (i *Invitation) SetAccessPIN(pin string) error {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	i.AccessPINHash = string(hashed)
+	return nil
+}
+
+type InvitationState string
+
+const (
+	InvitationScheduled InvitationState = "scheduled"
+	InvitationActive    InvitationState = "active"
+	InvitationEnded     InvitationState = "ended"
+	InvitationArchived  InvitationState = "archived"
+)
+
+// TimeLocation, davetiyenin saat dilimini döndürür; geçersiz değerlerde varsayılan
+// saat dilimine düşer.
+func (i *Invitation) TimeLocation() *time.Location {
+	name := i.Timezone
+	if name == "" {
+		name = DefaultInvitationTimezone
+	}
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc
+	}
+	if loc, err := time.LoadLocation(DefaultInvitationTimezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// LocalEventAt, etkinlik zamanını davetiyenin kendi saat diliminde döndürür.
+func (i *Invitation) LocalEventAt() time.Time {
+	return i.EventAt.In(i.TimeLocation())
+}
+
+// StateAt, davetiyenin verilen andaki yayın durumunu döndürür. Süresi dolan
+// davetiyeler arşivlenmiş sayılır.
+func (i *Invitation) StateAt(now time.Time) InvitationState {
+	switch {
+	case i.ArchivedAt != nil:
+		return InvitationArchived
+	case i.ExpireAt != nil && !now.Before(*i.ExpireAt):
+		return InvitationArchived
+	case i.PublishAt != nil && now.Before(*i.PublishAt):
+		return InvitationScheduled
+	case !i.EventAt.IsZero() && now.After(i.EventAt):
+		return InvitationEnded
+	default:
+		return InvitationActive
+	}
+}
+
+func (i *Invitation) State() InvitationState {
+	return i.StateAt(time.Now())
+}
+
+// ReminderDueAt, hatırlatmanın verilen anda gönderilme penceresinde olup
+// olmadığını döndürür: etkinliğe ReminderLeadHours saat veya daha az kalmış
+// ama etkinlik başlamamıştır. Arşivlenmiş ve süresi dolmuş davetiyelere
+// hatırlatma gönderilmez.
+func (i *Invitation) ReminderDueAt(now time.Time) bool {
+	if !i.ReminderEnabled || i.ArchivedAt != nil {
+		return false
+	}
+	if i.ExpireAt != nil && !now.Before(*i.ExpireAt) {
+		return false
+	}
+	windowStart := i.EventAt.Add(-time.Duration(i.ReminderLeadHours) * time.Hour)
+	return now.Before(i.EventAt) && !now.Before(windowStart)
+}
+
+// RSVPDeadlinePassed, katılım yanıtları için son tarihin geçip geçmediğini
+// döndürür.
+func (i *Invitation) RSVPDeadlinePassed(now time.Time) bool {
+	return i.RSVPDeadline != nil && !now.Before(*i.RSVPDeadline)
+}
+
+// RSVPOpenAt, verilen anda katılım yanıtı alınıp alınamayacağını döndürür.
+func (i *Invitation) RSVPOpenAt(now time.Time) bool {
+	return i.IsParticipant && i.StateAt(now) == InvitationActive && !i.RSVPDeadlinePassed(now)
+}
+
+// EventTitle, bildirimlerde ve takvim kayıtlarında kullanılacak etkinlik adını
+// döndürür; detay başlığı yoksa isimlere, sonra kategori adına düşer.
+func (i *Invitation) EventTitle() string {
+	if detail := i.InvitationDetail; detail != nil {
+		switch {
+		case detail.Title != "":
+			return detail.Title
+		case detail.BrideName != "" && detail.GroomName != "":
+			return detail.BrideName + " & " + detail.GroomName
+		case detail.Person != "":
+			return detail.Person
+		}
+	}
+	if i.Category != nil && i.Category.Name != "" {
+		return i.Category.Name
+	}
+	return "Davetiye"
+}
+
+// RSVPEvents, katılım formunda ayrıca yanıt istenen etkinlikleri döndürür.
+func (i *Invitation) RSVPEvents() []InvitationEvent {
+	var events []InvitationEvent
+	for _, event := range i.Events {
+		if event.RSVPEnabled {
+			events = append(events, event)
+		}
+	}
+	return events
+}
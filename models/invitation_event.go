@@ -0,0 +1,83 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InvitationEvent, tek davetiye altındaki etkinliklerden biridir (ör. kına,
+// nikah, düğün). Zamanlar UTC saklanır ve davetiyenin saat diliminde gösterilir.
+type InvitationEvent struct {
+	BaseModel
+
+	InvitationID uint      `gorm:"index;not null"`
+	Name         string    `gorm:"type:varchar(100);not null"`
+	Venue        string    `gorm:"type:varchar(255)"`
+	Address      string    `gorm:"type:varchar(255)"`
+	Latitude     *float64  `gorm:"type:numeric(9,6)"`
+	Longitude    *float64  `gorm:"type:numeric(9,6)"`
+	StartAt      time.Time `gorm:"not null;index"`
+	EndAt        *time.Time
+	// RSVPEnabled açıksa katılım formunda bu etkinlik için ayrıca yanıt istenir.
+	RSVPEnabled bool `gorm:"column:rsvp_enabled;not null;default:false"`
+	SortOrder   int  `gorm:"not null;default:0"`
+
+	Invitation Invitation `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (InvitationEvent) TableName() string {
+	return "invitation_events"
+}
+
+// TimeRange, etkinlik zamanını verilen saat diliminde gösterir; bitiş aynı
+// gündeyse yalnızca saati yazılır.
+func (e *InvitationEvent) TimeRange(loc *time.Location) string {
+	start := e.StartAt.In(loc)
+	text := start.Format("02.01.2006 15:04")
+	if e.EndAt == nil {
+		return text
+	}
+	end := e.EndAt.In(loc)
+	if end.Year() == start.Year() && end.YearDay() == start.YearDay() {
+		return text + " - " + end.Format("15:04")
+	}
+	return text + " - " + end.Format("02.01.2006 15:04")
+}
+
+// HasCoordinates, etkinliğin harita konumu girilip girilmediğini döndürür.
+func (e *InvitationEvent) HasCoordinates() bool {
+	return e.Latitude != nil && e.Longitude != nil
+}
+
+// MapQuery, harita bağlantılarında kullanılacak sorgudur; koordinat yoksa
+// mekan adı ve adres kullanılır.
+func (e *InvitationEvent) MapQuery() string {
+	if e.HasCoordinates() {
+		return strconv.FormatFloat(*e.Latitude, 'f', -1, 64) + "," + strconv.FormatFloat(*e.Longitude, 'f', -1, 64)
+	}
+	var parts []string
+	for _, part := range []string{e.Venue, e.Address} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// InvitationEventResponse, katılımcının tek bir etkinlik için verdiği
+// yanıttır. Katılımcı veya etkinlik silinince yanıt da silinir.
+type InvitationEventResponse struct {
+	ParticipantID uint `gorm:"primaryKey"`
+	EventID       uint `gorm:"primaryKey;index"`
+	Attending     bool `gorm:"not null"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	Participant InvitationParticipant `gorm:"foreignKey:ParticipantID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Event       InvitationEvent       `gorm:"foreignKey:EventID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (InvitationEventResponse) TableName() string {
+	return "invitation_event_responses"
+}
@@ -0,0 +1,42 @@
+package models
+
+// InvitationGiftAccount, davetiye sayfasında hediye/takı gönderimi için
+// gösterilen banka hesabıdır. IBAN boşluksuz ve büyük harfle saklanır.
+type InvitationGiftAccount struct {
+	BaseModel
+
+	InvitationID  uint   `gorm:"index;not null"`
+	BankID        uint   `gorm:"index;not null"`
+	IBAN          string `gorm:"size:34;not null"`
+	AccountHolder string `gorm:"type:varchar(100);not null"`
+	Description   string `gorm:"type:varchar(255)"`
+	SortOrder     int    `gorm:"not null;default:0"`
+
+	Invitation Invitation `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Bank       Bank       `gorm:"foreignKey:BankID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (InvitationGiftAccount) TableName() string {
+	return "invitation_gift_accounts"
+}
+
+// InvitationGiftNote, misafirin gönderdiği hediye için bıraktığı nottur.
+// Notlar yalnızca davetiye sahibine gösterilir; kişiye özel bağlantıyla
+// gelen misafirlerin notları katılımcı kaydına bağlanır.
+type InvitationGiftNote struct {
+	BaseModel
+
+	InvitationID  uint   `gorm:"index;not null"`
+	ParticipantID *uint  `gorm:"index"`
+	GiftAccountID *uint  `gorm:"index"`
+	Name          string `gorm:"type:varchar(100);not null"`
+	Note          string `gorm:"type:text;not null"`
+
+	Invitation  Invitation             `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Participant *InvitationParticipant `gorm:"foreignKey:ParticipantID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	GiftAccount *InvitationGiftAccount `gorm:"foreignKey:GiftAccountID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
+
+func (InvitationGiftNote) TableName() string {
+	return "invitation_gift_notes"
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+type GuestbookStatus string
+
+const (
+	GuestbookPending  GuestbookStatus = "pending"
+	GuestbookApproved GuestbookStatus = "approved"
+	GuestbookHidden   GuestbookStatus = "hidden"
+)
+
+// GuestbookStatuses, panelde filtrelerin gösterilme sırasıdır.
+var GuestbookStatuses = []GuestbookStatus{GuestbookPending, GuestbookApproved, GuestbookHidden}
+
+func (s GuestbookStatus) Name() string {
+	switch s {
+	case GuestbookPending:
+		return "Onay bekliyor"
+	case GuestbookApproved:
+		return "Yayında"
+	case GuestbookHidden:
+		return "Gizlendi"
+	default:
+		return string(s)
+	}
+}
+
+func (s GuestbookStatus) IsValid() bool {
+	for _, status := range GuestbookStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// InvitationGuestbookEntry, davetiye sayfasındaki anı defterine bırakılan
+// mesajdır. Mesajlar davetiye sahibi onaylayana kadar yayınlanmaz.
+type InvitationGuestbookEntry struct {
+	BaseModel
+
+	InvitationID uint            `gorm:"index;not null"`
+	Name         string          `gorm:"type:varchar(100);not null"`
+	Message      string          `gorm:"type:text;not null"`
+	Status       GuestbookStatus `gorm:"type:varchar(20);not null;default:'pending';index"`
+	ApprovedAt   *time.Time
+
+	Invitation Invitation `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (InvitationGuestbookEntry) TableName() string {
+	return "invitation_guestbook_entries"
+}
+
+func (e *InvitationGuestbookEntry) StatusName() string {
+	return e.Status.Name()
+}
@@ -0,0 +1,69 @@
+package models
+
+type MediaSource string
+
+const (
+	MediaSourceOwner MediaSource = "owner"
+	MediaSourceGuest MediaSource = "guest"
+)
+
+type MediaStatus string
+
+const (
+	MediaPending  MediaStatus = "pending"
+	MediaApproved MediaStatus = "approved"
+	MediaHidden   MediaStatus = "hidden"
+)
+
+// MediaStatuses, panelde filtrelerin gösterilme sırasıdır.
+var MediaStatuses = []MediaStatus{MediaPending, MediaApproved, MediaHidden}
+
+func (s MediaStatus) Name() string {
+	switch s {
+	case MediaPending:
+		return "Onay bekliyor"
+	case MediaApproved:
+		return "Yayında"
+	case MediaHidden:
+		return "Gizlendi"
+	default:
+		return string(s)
+	}
+}
+
+func (s MediaStatus) IsValid() bool {
+	for _, status := range MediaStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// InvitationMedia, davetiye galerisindeki bir fotoğraftır. Davetiye sahibinin
+// yüklediği fotoğraflar doğrudan yayınlanır; misafir yüklemeleri onay bekler.
+type InvitationMedia struct {
+	BaseModel
+
+	InvitationID uint        `gorm:"index;not null"`
+	FileName     string      `gorm:"type:varchar(255);not null"`
+	Caption      string      `gorm:"type:varchar(255)"`
+	SortOrder    int         `gorm:"not null;default:0"`
+	Source       MediaSource `gorm:"type:varchar(20);not null;default:'owner'"`
+	UploaderName string      `gorm:"type:varchar(100)"`
+	Status       MediaStatus `gorm:"type:varchar(20);not null;default:'approved';index"`
+
+	Invitation Invitation `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (InvitationMedia) TableName() string {
+	return "invitation_media"
+}
+
+func (m *InvitationMedia) StatusName() string {
+	return m.Status.Name()
+}
+
+func (m *InvitationMedia) IsGuestUpload() bool {
+	return m.Source == MediaSourceGuest
+}
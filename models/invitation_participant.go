@@ -1,5 +1,19 @@
 package models
 
+import "time"
+
+type ParticipantStatus string
+
+const (
+	ParticipantInvited  ParticipantStatus = "invited"
+	ParticipantOpened   ParticipantStatus = "opened"
+	ParticipantAccepted ParticipantStatus = "accepted"
+	ParticipantDeclined ParticipantStatus = "declined"
+	// Kontenjan dolduğunda katılmak isteyenler yedek listeye alınır; davetiye
+	// sahibi yer açıldığında "accepted" durumuna yükseltebilir.
+	ParticipantWaitlisted ParticipantStatus = "waitlisted"
+)
+
 type InvitationParticipant struct {
 	BaseModel
 
@@ -9,10 +23,101 @@ type InvitationParticipant struct {
 	GuestCount   int    `gorm:"not null;default:1"`
 	InvitationID uint   `gorm:"index;not null"`
 
+	// Misafir listesinden eklenenler "invited" ile başlar; kişiye özel bağlantı
+	// açılınca "opened", yanıt verilince "accepted", "waitlisted" veya
+	// "declined" olur.
+	Status        ParticipantStatus `gorm:"type:varchar(20);not null;default:'invited';index"`
+	FirstOpenedAt *time.Time
+	LastOpenedAt  *time.Time
+	OpenCount     int `gorm:"not null;default:0"`
+	RespondedAt   *time.Time
+	// Davetiyeye eklenen soruların yanıtları (soru ID'si -> yanıt).
+	Answers ParticipantAnswers `gorm:"type:jsonb;not null;default:'{}'"`
+
+	// Hatırlatma gönderildiyse zamanı; aynı katılımcıya tekrar gönderilmez.
+	ReminderSentAt *time.Time
+	// Başarısız gönderim sayısı ve bir sonraki denemenin en erken zamanı.
+	ReminderAttempts int `gorm:"not null;default:0"`
+	ReminderRetryAt  *time.Time
+
 	// İlişki Tanımı
-	Invitation Invitation `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Invitation     Invitation                `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	EventResponses []InvitationEventResponse `gorm:"foreignKey:ParticipantID"`
 }
 
 func (InvitationParticipant) TableName() string {
 	return "invitation_participants"
 }
+
+// ParticipantStatuses, durumların panelde gösterilme sırasıdır.
+var ParticipantStatuses = []ParticipantStatus{
+	ParticipantInvited,
+	ParticipantOpened,
+	ParticipantAccepted,
+	ParticipantWaitlisted,
+	ParticipantDeclined,
+}
+
+func (s ParticipantStatus) Name() string {
+	switch s {
+	case ParticipantInvited:
+		return "Açmadı"
+	case ParticipantOpened:
+		return "Açtı, yanıtlamadı"
+	case ParticipantAccepted:
+		return "Katılıyor"
+	case ParticipantDeclined:
+		return "Katılmıyor"
+	case ParticipantWaitlisted:
+		return "Yedek listede"
+	default:
+		return string(s)
+	}
+}
+
+func (s ParticipantStatus) IsValid() bool {
+	for _, status := range ParticipantStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *InvitationParticipant) StatusName() string {
+	return p.Status.Name()
+}
+
+// HasOpened, misafirin kişiye özel bağlantıyı en az bir kez açıp açmadığını
+// döndürür.
+func (p *InvitationParticipant) HasOpened() bool {
+	return p.FirstOpenedAt != nil
+}
+
+// AnswerFor, verilen sorunun yanıtını gösterilecek metin olarak döndürür.
+func (p *InvitationParticipant) AnswerFor(question InvitationQuestion) string {
+	return question.FormatAnswer(p.Answers[question.AnswerKey()])
+}
+
+// EventResponse, verilen etkinlik için yanıtı döndürür; yanıt yoksa nil.
+func (p *InvitationParticipant) EventResponse(eventID uint) *InvitationEventResponse {
+	for i := range p.EventResponses {
+		if p.EventResponses[i].EventID == eventID {
+			return &p.EventResponses[i]
+		}
+	}
+	return nil
+}
+
+// EventAnswer, etkinlik yanıtını listelerde gösterilecek metne çevirir.
+func (p *InvitationParticipant) EventAnswer(eventID uint) string {
+	response := p.EventResponse(eventID)
+	switch {
+	case response == nil:
+		return ""
+	case response.Attending:
+		return "Katılıyor"
+	default:
+		return "Katılmıyor"
+	}
+}
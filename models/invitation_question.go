@@ -0,0 +1,99 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+type QuestionType string
+
+const (
+	QuestionText         QuestionType = "text"
+	QuestionSingleChoice QuestionType = "single_choice"
+	QuestionMultiChoice  QuestionType = "multi_choice"
+	QuestionNumber       QuestionType = "number"
+	QuestionBoolean      QuestionType = "boolean"
+)
+
+// QuestionTypes, formlarda gösterilen soru tipleridir.
+var QuestionTypes = []QuestionType{
+	QuestionText,
+	QuestionSingleChoice,
+	QuestionMultiChoice,
+	QuestionNumber,
+	QuestionBoolean,
+}
+
+func (t QuestionType) Name() string {
+	switch t {
+	case QuestionText:
+		return "Metin"
+	case QuestionSingleChoice:
+		return "Tek seçim"
+	case QuestionMultiChoice:
+		return "Çoklu seçim"
+	case QuestionNumber:
+		return "Sayı"
+	case QuestionBoolean:
+		return "Evet / Hayır"
+	default:
+		return string(t)
+	}
+}
+
+// HasOptions, tipin seçenek listesi gerektirip gerektirmediğini döndürür.
+func (t QuestionType) HasOptions() bool {
+	return t == QuestionSingleChoice || t == QuestionMultiChoice
+}
+
+// InvitationQuestion, davetiye sahibinin katılım formuna eklediği sorudur.
+// Yanıtlar katılımcının Answers alanında soru ID'siyle saklanır.
+type InvitationQuestion struct {
+	BaseModel
+
+	InvitationID uint         `gorm:"index;not null"`
+	Label        string       `gorm:"type:varchar(255);not null"`
+	Type         QuestionType `gorm:"type:varchar(20);not null"`
+	Options      StringList   `gorm:"type:jsonb;not null;default:'[]'"`
+	IsRequired   bool         `gorm:"not null;default:false"`
+	SortOrder    int          `gorm:"not null;default:0"`
+
+	Invitation Invitation `gorm:"foreignKey:InvitationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (InvitationQuestion) TableName() string {
+	return "invitation_questions"
+}
+
+// AnswerKey, yanıtların Answers içinde ve formda kullanılan anahtarıdır.
+func (q *InvitationQuestion) AnswerKey() string {
+	return strconv.FormatUint(uint64(q.ID), 10)
+}
+
+// FormatAnswer, saklanan yanıtı listelerde ve dışa aktarımda gösterilecek
+// metne çevirir.
+func (q *InvitationQuestion) FormatAnswer(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		if v {
+			return "Evet"
+		}
+		return "Hayır"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, q.FormatAnswer(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// StringList, jsonb sütununda dizi olarak saklanan metin listesidir.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(l))
+	return string(data), err
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
+
+// Lines, listeyi formlardaki çok satırlı alanlar için satır satır birleştirir.
+func (l StringList) Lines() string {
+	return strings.Join(l, "\n")
+}
+
+// ParticipantAnswers, katılımcının soru yanıtlarıdır; anahtar soru ID'sidir.
+// Değerler soru tipine göre metin, sayı, bool veya metin dizisidir.
+type ParticipantAnswers map[string]interface{}
+
+func (a ParticipantAnswers) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(map[string]interface{}(a))
+	return string(data), err
+}
+
+func (a *ParticipantAnswers) Scan(value interface{}) error {
+	return scanJSON(value, a)
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("json sütunu okunamadı: beklenmeyen tip %T", value)
+	}
+}
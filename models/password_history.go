@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PasswordHistory, şifre tekrarını engellemek için kullanıcının önceki şifre
+// özetlerini tutar.
+type PasswordHistory struct {
+	ID           uint `gorm:"primarykey"`
+	CreatedAt    time.Time
+	UserID       uint   `gorm:"not null;index"`
+	PasswordHash string `gorm:"size:255;not null" json:"-"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (PasswordHistory) TableName() string {
+	return "password_histories"
+}
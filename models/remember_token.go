@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// RememberToken, "beni hatırla" çerezinin sunucu tarafındaki karşılığıdır. Her
+// kullanımda token döndürülür (rotate); aynı girişten türeyen tokenlar aynı
+// FamilyID'yi paylaşır. Döndürülmüş bir tokenın tekrar kullanılması çalınma
+// belirtisi sayılır ve tüm aile iptal edilir.
+type RememberToken struct {
+	ID           uint `gorm:"primarykey"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	UserID       uint       `gorm:"not null;index"`
+	FamilyID     string     `gorm:"size:32;not null;index"`
+	Selector     string     `gorm:"size:32;not null;uniqueIndex"`
+	VerifierHash string     `gorm:"size:64;not null"`
+	ExpiresAt    time.Time  `gorm:"not null;index"`
+	RotatedAt    *time.Time `gorm:"index"`
+	RevokedAt    *time.Time `gorm:"index"`
+	IPAddress    string     `gorm:"size:45"`
+	UserAgent    string     `gorm:"size:512"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (RememberToken) TableName() string {
+	return "remember_tokens"
+}
@@ -0,0 +1,9 @@
+package models
+
+// StorageEntry, fiber.Storage uyumlu anahtar-değer tablolarının ortak şemasıdır.
+// Tablo adı kullanım yerine göre verilir (ör. limiter_storage).
+type StorageEntry struct {
+	Key       string `gorm:"primaryKey;size:255"`
+	Value     []byte `gorm:"type:bytea;not null"`
+	ExpiresAt int64  `gorm:"not null;default:0;index"`
+}
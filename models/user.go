@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -23,18 +25,28 @@ func (UserType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 	return "varchar(10)"
 }
 
+// TwoFactorSecretPurpose, TwoFactorSecret APP_SECRET ile şifrelenirken
+// kullanılan anahtar türetme amacıdır.
+const TwoFactorSecretPurpose = "two_factor_secret"
+
 type User struct {
 	BaseModel
-	Name              string   `gorm:"size:100;not null;index"`
-	Email             string   `gorm:"size:100;unique;not null"`
-	Password          string   `gorm:"size:255;not null"`
-	Status            bool     `gorm:"index"`
-	Type              UserType `gorm:"type:user_type;not null;default:'panel';index"`
-	ResetToken        string   `gorm:"size:255;index"`
-	EmailVerified     bool     `gorm:"default:false;index"`
-	VerificationToken string   `gorm:"size:255;index"`
-	Provider          string   `gorm:"size:50;index"`
-	ProviderID        string   `gorm:"size:100;index"`
+	Name             string   `gorm:"size:100;not null;index"`
+	Email            string   `gorm:"size:100;unique;not null"`
+	PendingEmail     string   `gorm:"size:100"`
+	Password         string   `gorm:"size:255;not null"`
+	Status           bool     `gorm:"index"`
+	Type             UserType `gorm:"type:user_type;not null;default:'panel';index"`
+	EmailVerified    bool     `gorm:"default:false;index"`
+	Provider         string   `gorm:"size:50;index"`
+	ProviderID       string   `gorm:"size:100;index"`
+	TwoFactorEnabled bool     `gorm:"not null;default:false"`
+	// TwoFactorSecret, pkg/secretbox ile şifrelenmiş TOTP anahtarıdır.
+	TwoFactorSecret   string `gorm:"size:255" json:"-"`
+	TwoFactorLastStep int64  `gorm:"not null;default:0" json:"-"`
+	// Hesap silme talebinde bekleme süresi sonunda verilerin silineceği an.
+	DeletionRequestedAt *time.Time
+	DeletionScheduledAt *time.Time `gorm:"index"`
 }
 
 func (u *User) CheckPassword(password string) error {
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// UserRecoveryCode, iki adımlı doğrulama için tek kullanımlık kurtarma
+// kodlarını tutar. Kodların yalnızca SHA-256 özeti saklanır.
+type UserRecoveryCode struct {
+	BaseModel
+	UserID   uint       `gorm:"index;not null"`
+	CodeHash string     `gorm:"size:64;not null;index"`
+	UsedAt   *time.Time `gorm:"index"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (UserRecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// UserSession, oturum deposundaki bir girişli oturumun görünür bilgilerini
+// tutar. SessionID, oturum deposundaki anahtardır ve çıkış yaptırmak için
+// kullanılır; kullanıcıya gösterilmez.
+type UserSession struct {
+	ID         uint `gorm:"primarykey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	SessionID  string    `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	UserID     uint      `gorm:"not null;index"`
+	IPAddress  string    `gorm:"size:45"`
+	UserAgent  string    `gorm:"size:512"`
+	LastSeenAt time.Time `gorm:"not null;index"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
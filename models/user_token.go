@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type UserTokenPurpose string
+
+const (
+	TokenPurposePasswordReset     UserTokenPurpose = "password_reset"
+	TokenPurposeEmailVerification UserTokenPurpose = "email_verification"
+	TokenPurposeEmailChange       UserTokenPurpose = "email_change"
+)
+
+// UserToken, tek kullanımlık ve süreli tokenları tutar. Ham token hiçbir zaman
+// saklanmaz; Selector ile kayıt bulunur, VerifierHash sabit zamanlı karşılaştırılır.
+type UserToken struct {
+	BaseModel
+	UserID       uint             `gorm:"index;not null"`
+	Purpose      UserTokenPurpose `gorm:"type:varchar(50);not null;index"`
+	Selector     string           `gorm:"size:32;not null;uniqueIndex"`
+	VerifierHash string           `gorm:"size:64;not null"`
+	ExpiresAt    time.Time        `gorm:"not null;index"`
+	UsedAt       *time.Time       `gorm:"index"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+func (t *UserToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
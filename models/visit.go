@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+type VisitTargetType string
+
+const (
+	VisitTargetCard       VisitTargetType = "card"
+	VisitTargetInvitation VisitTargetType = "invitation"
+)
+
+type VisitMetric string
+
+const (
+	VisitMetricView     VisitMetric = "view"
+	VisitMetricUnique   VisitMetric = "unique"
+	VisitMetricReferrer VisitMetric = "referrer"
+	VisitMetricDevice   VisitMetric = "device"
+	VisitMetricClick    VisitMetric = "click"
+)
+
+// VisitCounter, herkese açık kartvizit ve davetiye sayfaları için günlük
+// toplamları tutar. Dimension; yönlendiren site, cihaz sınıfı veya tıklama
+// türü gibi metriğe bağlı kırılımdır, görüntüleme sayılarında boş kalır.
+type VisitCounter struct {
+	ID         uint            `gorm:"primarykey"`
+	TargetType VisitTargetType `gorm:"type:varchar(20);not null;uniqueIndex:idx_visit_counters_key"`
+	TargetID   uint            `gorm:"not null;uniqueIndex:idx_visit_counters_key"`
+	Day        time.Time       `gorm:"type:date;not null;uniqueIndex:idx_visit_counters_key;index"`
+	Metric     VisitMetric     `gorm:"type:varchar(20);not null;uniqueIndex:idx_visit_counters_key"`
+	Dimension  string          `gorm:"size:255;not null;default:'';uniqueIndex:idx_visit_counters_key"`
+	Count      int64           `gorm:"not null;default:0"`
+	UpdatedAt  time.Time
+}
+
+func (VisitCounter) TableName() string {
+	return "visit_counters"
+}
+
+// VisitUnique, aynı gün içindeki tekil ziyaretçileri ayırt etmek için IP ve
+// User-Agent'ın günlük tuzla alınmış özetini tutar. Tuz ertesi gün silindiği
+// için özetler günler arasında ilişkilendirilemez.
+type VisitUnique struct {
+	TargetType  VisitTargetType `gorm:"type:varchar(20);primaryKey"`
+	TargetID    uint            `gorm:"primaryKey"`
+	Day         time.Time       `gorm:"type:date;primaryKey;index"`
+	VisitorHash string          `gorm:"type:char(64);primaryKey"`
+}
+
+func (VisitUnique) TableName() string {
+	return "visit_uniques"
+}
+
+// VisitSalt, birden fazla sunucunun aynı gün aynı tuzu kullanması için
+// veritabanında tutulur.
+type VisitSalt struct {
+	Day       time.Time `gorm:"type:date;primaryKey"`
+	Salt      string    `gorm:"type:varchar(64);not null"`
+	CreatedAt time.Time
+}
+
+func (VisitSalt) TableName() string {
+	return "visit_salts"
+}
@@ -0,0 +1,227 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	productID      = "-//zatrano//Davetiye//TR"
+	icsTimeLayout  = "20060102T150405"
+	maxLineOctets  = 75
+	googleCalendar = "https://calendar.google.com/calendar/render"
+	outlookCompose = "https://outlook.live.com/calendar/0/deeplink/compose"
+)
+
+// Event, takvime eklenecek tek bir etkinliktir. Start ve End herhangi bir
+// saat diliminde olabilir; çıktılar Location'a göre üretilir.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	TimeZone    *time.Location
+}
+
+func (e Event) zone() *time.Location {
+	if e.TimeZone == nil {
+		return time.UTC
+	}
+	return e.TimeZone
+}
+
+// ICS, etkinliği RFC 5545 uyumlu bir VCALENDAR belgesi olarak döndürür.
+// UTC dışındaki saat dilimleri için etkinlik yılını kapsayan bir VTIMEZONE
+// bileşeni eklenir ve zamanlar TZID ile yazılır.
+func (e Event) ICS(now time.Time) []byte {
+	var b bytes.Buffer
+	loc := e.zone()
+	useTZID := loc != time.UTC && loc.String() != "UTC"
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+productID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if useTZID {
+		writeTimezone(&b, loc, e.Start)
+	}
+
+	writeLine(&b, "BEGIN:VEVENT")
+	writeLine(&b, "UID:"+escapeText(e.UID))
+	writeLine(&b, "DTSTAMP:"+now.UTC().Format(icsTimeLayout)+"Z")
+	if useTZID {
+		writeLine(&b, "DTSTART;TZID="+loc.String()+":"+e.Start.In(loc).Format(icsTimeLayout))
+		writeLine(&b, "DTEND;TZID="+loc.String()+":"+e.End.In(loc).Format(icsTimeLayout))
+	} else {
+		writeLine(&b, "DTSTART:"+e.Start.UTC().Format(icsTimeLayout)+"Z")
+		writeLine(&b, "DTEND:"+e.End.UTC().Format(icsTimeLayout)+"Z")
+	}
+	writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+	if e.Description != "" {
+		writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+	}
+	if e.Location != "" {
+		writeLine(&b, "LOCATION:"+escapeText(e.Location))
+	}
+	if e.URL != "" {
+		writeLine(&b, "URL:"+e.URL)
+	}
+	writeLine(&b, "END:VEVENT")
+	writeLine(&b, "END:VCALENDAR")
+	return b.Bytes()
+}
+
+// GoogleURL, Google Takvim'de etkinlik oluşturma sayfasına giden bağlantıdır.
+func (e Event) GoogleURL() string {
+	loc := e.zone()
+	q := url.Values{}
+	q.Set("action", "TEMPLATE")
+	q.Set("text", e.Summary)
+	q.Set("dates", e.Start.In(loc).Format(icsTimeLayout)+"/"+e.End.In(loc).Format(icsTimeLayout))
+	q.Set("ctz", loc.String())
+	if details := e.details(); details != "" {
+		q.Set("details", details)
+	}
+	if e.Location != "" {
+		q.Set("location", e.Location)
+	}
+	return googleCalendar + "?" + q.Encode()
+}
+
+// OutlookURL, Outlook.com takviminde etkinlik oluşturma sayfasına giden
+// bağlantıdır. Outlook saat dilimi parametresi almadığından zamanlar UTC yazılır.
+func (e Event) OutlookURL() string {
+	q := url.Values{}
+	q.Set("path", "/calendar/action/compose")
+	q.Set("rru", "addevent")
+	q.Set("subject", e.Summary)
+	q.Set("startdt", e.Start.UTC().Format("2006-01-02T15:04:05Z"))
+	q.Set("enddt", e.End.UTC().Format("2006-01-02T15:04:05Z"))
+	if details := e.details(); details != "" {
+		q.Set("body", details)
+	}
+	if e.Location != "" {
+		q.Set("location", e.Location)
+	}
+	return outlookCompose + "?" + q.Encode()
+}
+
+func (e Event) details() string {
+	switch {
+	case e.Description != "" && e.URL != "":
+		return e.Description + "\n\n" + e.URL
+	case e.URL != "":
+		return e.URL
+	default:
+		return e.Description
+	}
+}
+
+type observance struct {
+	start    time.Time
+	from, to int
+	name     string
+	dst      bool
+}
+
+// writeTimezone, etkinlik yılındaki ofset geçişlerini Go saat dilimi
+// veritabanından çıkararak VTIMEZONE bileşenini yazar.
+func writeTimezone(b *bytes.Buffer, loc *time.Location, around time.Time) {
+	yearStart := time.Date(around.In(loc).Year(), 1, 1, 0, 0, 0, 0, loc)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+
+	name, offset := yearStart.Zone()
+	observances := []observance{{start: yearStart, from: offset, to: offset, name: name, dst: yearStart.IsDST()}}
+
+	for day := yearStart; day.Before(yearEnd); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		_, fromOffset := day.Zone()
+		if _, nextOffset := next.Zone(); nextOffset == fromOffset {
+			continue
+		}
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, midOffset := mid.Zone(); midOffset == fromOffset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		transition := hi.In(loc)
+		toName, toOffset := transition.Zone()
+		observances = append(observances, observance{
+			// Gözlem başlangıcı, geçişten önceki ofsete göre yerel saattir.
+			start: transition.In(time.FixedZone("", fromOffset)),
+			from:  fromOffset,
+			to:    toOffset,
+			name:  toName,
+			dst:   transition.IsDST(),
+		})
+	}
+
+	writeLine(b, "BEGIN:VTIMEZONE")
+	writeLine(b, "TZID:"+loc.String())
+	for _, o := range observances {
+		kind := "STANDARD"
+		if o.dst {
+			kind = "DAYLIGHT"
+		}
+		writeLine(b, "BEGIN:"+kind)
+		writeLine(b, "DTSTART:"+o.start.Format(icsTimeLayout))
+		writeLine(b, "TZOFFSETFROM:"+formatOffset(o.from))
+		writeLine(b, "TZOFFSETTO:"+formatOffset(o.to))
+		if o.name != "" {
+			writeLine(b, "TZNAME:"+escapeText(o.name))
+		}
+		writeLine(b, "END:"+kind)
+	}
+	writeLine(b, "END:VTIMEZONE")
+}
+
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeLine, satırı 75 oktetten uzunsa UTF-8 karakterlerini bölmeden katlar
+// ve CRLF ile sonlandırır.
+func writeLine(b *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Devam satırlarındaki baştaki boşluk da sınıra dahildir.
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,80 @@
+package calendar
+
+import (
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/models"
+)
+
+// DefaultEventDuration, davetiyelerde bitiş saati tutulmadığından takvim
+// kaydına eklenen varsayılan süredir.
+const DefaultEventDuration = 2 * time.Hour
+
+// FromInvitation, davetiyeden takvim etkinliği üretir. Zaman davetiyenin
+// kendi saat diliminde yazılır.
+func FromInvitation(invitation *models.Invitation) Event {
+	start := invitation.LocalEventAt()
+	pageURL, host := invitationPage(invitation)
+
+	return Event{
+		UID:         "invitation-" + invitation.InvitationKey + "@" + host,
+		Summary:     invitation.EventTitle(),
+		Description: strings.TrimSpace(invitation.Description),
+		Location:    joinLocation(invitation.Venue, invitation.Address),
+		URL:         pageURL,
+		Start:       start,
+		End:         start.Add(DefaultEventDuration),
+		TimeZone:    invitation.TimeLocation(),
+	}
+}
+
+// FromInvitationEvent, çok etkinlikli davetiyelerde tek bir etkinlik için
+// takvim kaydı üretir. Bitiş zamanı girilmemişse varsayılan süre eklenir.
+func FromInvitationEvent(invitation *models.Invitation, event *models.InvitationEvent) Event {
+	loc := invitation.TimeLocation()
+	start := event.StartAt.In(loc)
+	end := start.Add(DefaultEventDuration)
+	if event.EndAt != nil {
+		end = event.EndAt.In(loc)
+	}
+	pageURL, host := invitationPage(invitation)
+
+	return Event{
+		UID:         "invitation-" + invitation.InvitationKey + "-event-" + strconv.FormatUint(uint64(event.ID), 10) + "@" + host,
+		Summary:     event.Name + " - " + invitation.EventTitle(),
+		Description: strings.TrimSpace(invitation.Description),
+		Location:    joinLocation(event.Venue, event.Address),
+		URL:         pageURL,
+		Start:       start,
+		End:         end,
+		TimeZone:    loc,
+	}
+}
+
+// invitationPage, davetiye sayfasının adresini ve UID'lerde kullanılacak
+// alan adını döndürür.
+func invitationPage(invitation *models.Invitation) (pageURL, host string) {
+	host = "zatrano"
+	baseURL := strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")
+	if baseURL != "" {
+		pageURL = baseURL + "/" + invitation.InvitationKey
+		if u, err := url.Parse(baseURL); err == nil && u.Hostname() != "" {
+			host = u.Hostname()
+		}
+	}
+	return pageURL, host
+}
+
+func joinLocation(parts ...string) string {
+	var location []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			location = append(location, part)
+		}
+	}
+	return strings.Join(location, ", ")
+}
@@ -0,0 +1,119 @@
+package dbstorage
+
+import (
+	"errors"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const DefaultGCInterval = 10 * time.Minute
+
+// Storage, fiber.Storage arayüzünü veritabanındaki bir tablo üzerinden gerçekler.
+// Böylece limiter ve benzeri middleware'ler birden fazla instance arasında
+// aynı durumu paylaşır.
+type Storage struct {
+	db    *gorm.DB
+	table string
+	done  chan struct{}
+}
+
+func New(db *gorm.DB, table string, gcInterval time.Duration) *Storage {
+	s := &Storage{
+		db:    db,
+		table: table,
+		done:  make(chan struct{}),
+	}
+	if gcInterval > 0 {
+		go s.gc(gcInterval)
+	}
+	return s
+}
+
+func (s *Storage) query() *gorm.DB {
+	return s.db.Table(s.table)
+}
+
+func (s *Storage) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	var entry models.StorageEntry
+	err := s.query().
+		Where("key = ? AND (expires_at = 0 OR expires_at > ?)", key, time.Now().Unix()).
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entry.Value, nil
+}
+
+func (s *Storage) Set(key string, val []byte, exp time.Duration) error {
+	if key == "" || len(val) == 0 {
+		return nil
+	}
+
+	var expiresAt int64
+	if exp > 0 {
+		expiresAt = time.Now().Add(exp).Unix()
+	}
+
+	return s.query().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "expires_at"}),
+	}).Create(&models.StorageEntry{Key: key, Value: val, ExpiresAt: expiresAt}).Error
+}
+
+func (s *Storage) Delete(key string) error {
+	if key == "" {
+		return nil
+	}
+	return s.query().Where("key = ?", key).Delete(&models.StorageEntry{}).Error
+}
+
+func (s *Storage) Reset() error {
+	return s.query().Where("1 = 1").Delete(&models.StorageEntry{}).Error
+}
+
+func (s *Storage) Close() error {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+	return nil
+}
+
+func (s *Storage) gc(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			err := s.query().
+				Where("expires_at <> 0 AND expires_at <= ?", time.Now().Unix()).
+				Delete(&models.StorageEntry{}).Error
+			if err != nil {
+				logconfig.Log.Warn("Süresi dolmuş storage kayıtları temizlenemedi",
+					zap.String("table", s.table),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+var _ fiber.Storage = (*Storage)(nil)
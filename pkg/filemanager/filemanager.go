@@ -20,6 +20,7 @@ var (
 	ErrFileNotProvided = errors.New("dosya sağlanmadı")
 	ErrInvalidFileType = errors.New("geçersiz dosya türü veya uzantısı")
 	ErrFileTooLarge    = errors.New("dosya boyutu çok büyük")
+	ErrTooManyFiles    = errors.New("çok fazla dosya seçildi")
 )
 
 const (
@@ -72,6 +73,44 @@ func UploadFile(c *fiber.Ctx, formFieldName, contentType string) (string, error)
 	return newFileName, nil
 }
 
+// UploadFiles, çoklu dosya alanındaki dosyaları kaydeder. Dosyalardan biri
+// geçersizse hiçbiri kaydedilmez; kayıt sırasında hata olursa o ana kadar
+// yazılan dosyalar silinir. maxFiles 0 ise dosya sayısı sınırlanmaz.
+func UploadFiles(c *fiber.Ctx, formFieldName, contentType string, maxFiles int) ([]string, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, ErrFileNotProvided
+	}
+	files := form.File[formFieldName]
+	if len(files) == 0 {
+		return nil, ErrFileNotProvided
+	}
+	if maxFiles > 0 && len(files) > maxFiles {
+		return nil, ErrTooManyFiles
+	}
+	for _, file := range files {
+		if err := validateFile(file, contentType); err != nil {
+			return nil, err
+		}
+	}
+
+	fileNames := make([]string, 0, len(files))
+	for _, file := range files {
+		newFileName, err := generateUniqueFileName(file.Filename)
+		if err == nil {
+			err = c.SaveFile(file, filepath.Join(fileconfig.Config.GetPath(contentType), newFileName))
+		}
+		if err != nil {
+			for _, fileName := range fileNames {
+				DeleteFile(contentType, fileName)
+			}
+			return nil, err
+		}
+		fileNames = append(fileNames, newFileName)
+	}
+	return fileNames, nil
+}
+
 func validateFile(file *multipart.FileHeader, contentType string) error {
 	if file.Size > DefaultMaxFileSize {
 		return ErrFileTooLarge
@@ -0,0 +1,129 @@
+// Package iban, ISO 13616 uluslararası banka hesap numaralarını doğrular ve
+// biçimlendirir.
+package iban
+
+import (
+	"errors"
+	"strings"
+)
+
+// Hata mesajları "IBAN geçersiz: ..." biçiminde gösterilmek üzere yazılmıştır.
+var (
+	ErrInvalidCharacters = errors.New("yalnızca harf ve rakam içerebilir")
+	ErrUnknownCountry    = errors.New("ülke kodu tanınmıyor")
+	ErrInvalidLength     = errors.New("karakter sayısı ülkeye göre hatalı")
+	ErrInvalidChecksum   = errors.New("kontrol basamakları hatalı, numarada yazım hatası olabilir")
+)
+
+// countryLengths, IBAN kullanan ülkelerin ISO 13616 kaydındaki toplam
+// karakter sayılarıdır.
+var countryLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BR": 29, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26,
+	"IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21,
+	"LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22, "MK": 19,
+	"MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24, "PL": 28,
+	"PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24, "SC": 31,
+	"SE": 24, "SI": 19, "SK": 24, "SM": 27, "TL": 23, "TN": 24, "TR": 26,
+	"UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+// Normalize, IBAN'ı elektronik biçime getirir: boşluk ve tireler atılır,
+// harfler büyütülür. Sonuç ayrıca Validate ile doğrulanmalıdır.
+func Normalize(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	return strings.NewReplacer(" ", "", "-", "", "\u00a0", "").Replace(value)
+}
+
+// Validate, normalize edilmiş IBAN'ın ülke uzunluğunu ve mod-97 kontrol
+// basamaklarını doğrular.
+func Validate(value string) error {
+	if len(value) < 4 {
+		return ErrInvalidLength
+	}
+	for i := 0; i < len(value); i++ {
+		if !isAlphanumeric(value[i]) {
+			return ErrInvalidCharacters
+		}
+	}
+	length, ok := countryLengths[value[:2]]
+	if !ok {
+		return ErrUnknownCountry
+	}
+	if len(value) != length {
+		return ErrInvalidLength
+	}
+	if !isDigit(value[2]) || !isDigit(value[3]) || mod97(value) != 1 {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+// Parse, girilen değeri normalize edip doğrular.
+func Parse(value string) (string, error) {
+	value = Normalize(value)
+	if err := Validate(value); err != nil {
+		return value, err
+	}
+	return value, nil
+}
+
+// Format, IBAN'ı okunması kolay olsun diye dörderli gruplar halinde yazar
+// (ör. TR33 0006 1005 1978 6457 8413 26).
+func Format(value string) string {
+	value = Normalize(value)
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// Country, IBAN'ın ülke kodunu döndürür.
+func Country(value string) string {
+	value = Normalize(value)
+	if len(value) < 2 {
+		return ""
+	}
+	return value[:2]
+}
+
+// TRBankCode, Türkiye IBAN'ındaki beş haneli banka kodunu (5-9. karakterler)
+// döndürür. IBAN Türkiye'ye ait değilse veya geçersizse boş döner.
+func TRBankCode(value string) string {
+	value = Normalize(value)
+	if Country(value) != "TR" || Validate(value) != nil {
+		return ""
+	}
+	return value[4:9]
+}
+
+// mod97, ilk dört karakter sona taşındıktan ve harfler 10-35 arası sayılara
+// çevrildikten sonra oluşan sayının 97'ye bölümünden kalanı hesaplar.
+func mod97(value string) int {
+	rearranged := value[4:] + value[:4]
+	remainder := 0
+	for i := 0; i < len(rearranged); i++ {
+		c := rearranged[i]
+		if isDigit(c) {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		} else {
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		}
+	}
+	return remainder
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlphanumeric(c byte) bool {
+	return isDigit(c) || (c >= 'A' && c <= 'Z')
+}
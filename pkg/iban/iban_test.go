@@ -0,0 +1,151 @@
+package iban
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"TR", "TR33 0006 1005 1978 6457 8413 26", "TR330006100519786457841326", nil},
+		{"küçük harf ve tire", "tr33-0006-1005-1978-6457-8413-26", "TR330006100519786457841326", nil},
+		{"bölünemez boşluk", "TR33\u00a00006\u00a01005\u00a01978\u00a06457\u00a08413\u00a026", "TR330006100519786457841326", nil},
+		{"DE", "DE89 3704 0044 0532 0130 00", "DE89370400440532013000", nil},
+		{"GB harfli BBAN", "GB82 WEST 1234 5698 7654 32", "GB82WEST12345698765432", nil},
+		{"NL", "NL91 ABNA 0417 1643 00", "NL91ABNA0417164300", nil},
+		{"FR BBAN içinde harf", "FR14 2004 1010 0505 0001 3M02 606", "FR1420041010050500013M02606", nil},
+		{"BE", "BE68 5390 0754 7034", "BE68539007547034", nil},
+		{"CH", "CH93 0076 2011 6238 5295 7", "CH9300762011623852957", nil},
+		{"NO en kısa", "NO93 8601 1117 947", "NO9386011117947", nil},
+		{"kontrol basamağı hatalı", "TR34 0006 1005 1978 6457 8413 26", "TR340006100519786457841326", ErrInvalidChecksum},
+		{"hane yer değiştirmiş", "TR33 0006 1005 1978 6457 8413 62", "TR330006100519786457841362", ErrInvalidChecksum},
+		{"DE kontrol basamağı hatalı", "DE88 3704 0044 0532 0130 00", "DE88370400440532013000", ErrInvalidChecksum},
+		{"kontrol basamağı harf", "TRAB 0006 1005 1978 6457 8413 26", "TRAB0006100519786457841326", ErrInvalidChecksum},
+		{"kısa", "TR33 0006 1005 1978 6457 8413 2", "TR33000610051978645784132", ErrInvalidLength},
+		{"uzun", "TR33 0006 1005 1978 6457 8413 261", "TR3300061005197864578413261", ErrInvalidLength},
+		{"çok kısa", "TR3", "TR3", ErrInvalidLength},
+		{"boş", "", "", ErrInvalidLength},
+		{"bilinmeyen ülke", "ZZ33 0006 1005 1978 6457 8413 26", "ZZ330006100519786457841326", ErrUnknownCountry},
+		{"geçersiz karakter", "TR33 0006 1005 1978 6457 8413 2*", "TR33000610051978645784132*", ErrInvalidCharacters},
+		{"Türkçe harf", "TR33 0006 1005 1978 6457 8413 2İ", "TR33000610051978645784132İ", ErrInvalidCharacters},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) hata = %v, beklenen %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %q, beklenen %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCountryLengths, her ülke için kayıttaki uzunlukta üretilen geçerli bir
+// IBAN'ın kabul edildiğini, bir karakter kısa veya uzun olanların
+// reddedildiğini doğrular.
+func TestCountryLengths(t *testing.T) {
+	for country, length := range countryLengths {
+		t.Run(country, func(t *testing.T) {
+			if len(country) != 2 || strings.ToUpper(country) != country {
+				t.Fatalf("ülke kodu %q iki büyük harf olmalıdır", country)
+			}
+			if length < 15 || length > 34 {
+				t.Fatalf("%s uzunluğu %d, ISO 13616 aralığı 15-34", country, length)
+			}
+
+			valid := withCheckDigits(country, strings.Repeat("1", length-4))
+			if err := Validate(valid); err != nil {
+				t.Errorf("Validate(%q) = %v, beklenen nil", valid, err)
+			}
+
+			wrongCheck := valid[:2] + flipDigit(valid[2]) + valid[3:]
+			if err := Validate(wrongCheck); !errors.Is(err, ErrInvalidChecksum) {
+				t.Errorf("Validate(%q) = %v, beklenen %v", wrongCheck, err, ErrInvalidChecksum)
+			}
+
+			short := withCheckDigits(country, strings.Repeat("1", length-5))
+			if err := Validate(short); !errors.Is(err, ErrInvalidLength) {
+				t.Errorf("Validate(%q) = %v, beklenen %v", short, err, ErrInvalidLength)
+			}
+			long := withCheckDigits(country, strings.Repeat("1", length-3))
+			if err := Validate(long); !errors.Is(err, ErrInvalidLength) {
+				t.Errorf("Validate(%q) = %v, beklenen %v", long, err, ErrInvalidLength)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"TR330006100519786457841326", "TR33 0006 1005 1978 6457 8413 26"},
+		{" tr33 0006-1005 1978 6457 8413 26 ", "TR33 0006 1005 1978 6457 8413 26"},
+		{"DE89370400440532013000", "DE89 3704 0044 0532 0130 00"},
+		{"NO9386011117947", "NO93 8601 1117 947"},
+		{"TR33", "TR33"},
+		{"TR3", "TR3"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.input); got != tt.want {
+			t.Errorf("Format(%q) = %q, beklenen %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTRBankCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Ziraat", "TR33 0006 1005 1978 6457 8413 26", "00061"},
+		{"küçük harf", "tr330006100519786457841326", "00061"},
+		{"üretilmiş", withCheckDigits("TR", "00010"+"0"+strings.Repeat("7", 16)), "00010"},
+		{"yurt dışı", "DE89 3704 0044 0532 0130 00", ""},
+		{"kontrol basamağı hatalı", "TR34 0006 1005 1978 6457 8413 26", ""},
+		{"eksik", "TR33 0006 1005", ""},
+		{"boş", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TRBankCode(tt.input); got != tt.want {
+				t.Errorf("TRBankCode(%q) = %q, beklenen %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// withCheckDigits, ülke kodu ve BBAN için kontrol basamaklarını mod97'den
+// bağımsız olarak big.Int ile hesaplayıp tam IBAN'ı döndürür.
+func withCheckDigits(country, bban string) string {
+	var digits strings.Builder
+	for _, r := range bban + country + "00" {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(big.NewInt(int64(r-'A') + 10).String())
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	check := 98 - new(big.Int).Mod(n, big.NewInt(97)).Int64()
+	return country + string(rune('0'+check/10)) + string(rune('0'+check%10)) + bban
+}
+
+func flipDigit(c byte) string {
+	if c == '9' {
+		return "0"
+	}
+	return string(c + 1)
+}
@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+)
+
+// Fake, mesajları göndermek yerine bellekte biriktirir; testlerde ve yerel
+// geliştirmede kullanılır. Err ayarlanırsa her gönderim bu hatayla döner.
+type Fake struct {
+	mu   sync.Mutex
+	sent []Message
+	Err  error
+}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) Send(_ context.Context, msg Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+// Sent, o ana kadar biriken mesajların kopyasını döndürür.
+func (f *Fake) Sent() []Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Message(nil), f.sent...)
+}
+
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = nil
+}
@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+)
+
+type Channel string
+
+const (
+	ChannelSMS      Channel = "sms"
+	ChannelWhatsApp Channel = "whatsapp"
+	ChannelEmail    Channel = "email"
+)
+
+var ErrUnsupportedChannel = errors.New("bildirim kanalı desteklenmiyor")
+
+func (c Channel) DisplayName() string {
+	switch c {
+	case ChannelSMS:
+		return "SMS"
+	case ChannelWhatsApp:
+		return "WhatsApp"
+	case ChannelEmail:
+		return "E-posta"
+	default:
+		return string(c)
+	}
+}
+
+// Message, tek alıcıya gönderilecek bildirimdir. To, kanala göre telefon
+// numarası veya e-posta adresidir; Subject yalnızca e-postada kullanılır.
+type Message struct {
+	Channel Channel `json:"channel"`
+	To      string  `json:"to"`
+	Subject string  `json:"subject,omitempty"`
+	Body    string  `json:"body"`
+}
+
+// Notifier, SMS, WhatsApp veya e-posta sağlayıcıları için ortak arayüzdür.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Func, sıradan bir fonksiyonu Notifier olarak kullanmayı sağlar.
+type Func func(ctx context.Context, msg Message) error
+
+func (f Func) Send(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// Router, mesajı kanalına göre ilgili sağlayıcıya yönlendirir.
+type Router map[Channel]Notifier
+
+func (r Router) Send(ctx context.Context, msg Message) error {
+	n, ok := r[msg.Channel]
+	if !ok || n == nil {
+		return ErrUnsupportedChannel
+	}
+	return n.Send(ctx, msg)
+}
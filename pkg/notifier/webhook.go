@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Webhook, mesajı JSON olarak bir HTTP uç noktasına iletir. SMS ve WhatsApp
+// sağlayıcılarına bağlanan ara servisler için kullanılır.
+type Webhook struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+func NewWebhook(url, token string) *Webhook {
+	return &Webhook{
+		URL:    url,
+		Token:  token,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *Webhook) Send(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+w.Token)
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("bildirim servisi %d durum kodu döndürdü", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const appleIssuer = "https://appleid.apple.com"
+
+var ErrApplePrivateKey = errors.New("apple özel anahtarı okunamadı")
+
+type AppleConfig struct {
+	ClientID    string
+	TeamID      string
+	KeyID       string
+	PrivateKey  string
+	RedirectURL string
+}
+
+// AppleProvider, Sign in with Apple akışını uygular. Apple istemci sırrı
+// olarak ES256 ile imzalanmış kısa ömürlü bir JWT bekler ve ad/e-posta
+// kapsamı istendiğinde geri dönüşü form_post ile yapar.
+type AppleProvider struct {
+	cfg AppleConfig
+	key *ecdsa.PrivateKey
+}
+
+func NewAppleProvider(cfg AppleConfig) (*AppleProvider, error) {
+	block, _ := pem.Decode([]byte(strings.ReplaceAll(cfg.PrivateKey, `\n`, "\n")))
+	if block == nil {
+		return nil, ErrApplePrivateKey
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, ErrApplePrivateKey
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrApplePrivateKey
+	}
+	return &AppleProvider{cfg: cfg, key: key}, nil
+}
+
+func (p *AppleProvider) Name() string        { return "apple" }
+func (p *AppleProvider) DisplayName() string { return "Apple" }
+
+func (p *AppleProvider) oauthConfig(clientSecret string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       []string{"name", "email"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   appleIssuer + "/auth/authorize",
+			TokenURL:  appleIssuer + "/auth/token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+func (p *AppleProvider) clientSecret() (string, error) {
+	header, _ := json.Marshal(map[string]string{"alg": "ES256", "kid": p.cfg.KeyID})
+	now := time.Now()
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iss": p.cfg.TeamID,
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+		"aud": appleIssuer,
+		"sub": p.cfg.ClientID,
+	})
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, p.key, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (p *AppleProvider) AuthCodeURL(ctx context.Context, flow Flow) (string, error) {
+	return p.oauthConfig("").AuthCodeURL(flow.State,
+		oauth2.SetAuthURLParam("response_mode", "form_post"),
+		oauth2.SetAuthURLParam("nonce", flow.Nonce),
+	), nil
+}
+
+func (p *AppleProvider) Exchange(ctx context.Context, callback Callback, flow Flow) (*Identity, error) {
+	secret, err := p.clientSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	token, err := p.oauthConfig(secret).Exchange(ctx, callback.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+	claims, err := parseIDToken(rawIDToken, appleIssuer, p.cfg.ClientID, flow.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Provider:      p.Name(),
+		ProviderID:    claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+	}
+
+	if callback.User != "" {
+		var user struct {
+			Name struct {
+				FirstName string `json:"firstName"`
+				LastName  string `json:"lastName"`
+			} `json:"name"`
+		}
+		if err := json.Unmarshal([]byte(callback.User), &user); err == nil {
+			identity.Name = strings.TrimSpace(user.Name.FirstName + " " + user.Name.LastName)
+		}
+	}
+
+	return identity, nil
+}
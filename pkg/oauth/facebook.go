@@ -0,0 +1,81 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+type FacebookConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	GraphVersion string
+}
+
+// FacebookProvider, OIDC yerine Graph API üzerinden kullanıcı bilgisi alır.
+// Graph API e-postanın doğrulanıp doğrulanmadığını bildirmediği için adres
+// doğrulanmamış kabul edilir; aynı e-postalı mevcut hesaplara otomatik
+// bağlanmaz ve yeni hesaplar e-posta doğrulamasından geçer.
+type FacebookProvider struct {
+	oauth        *oauth2.Config
+	graphVersion string
+}
+
+func NewFacebookProvider(cfg FacebookConfig) *FacebookProvider {
+	if cfg.GraphVersion == "" {
+		cfg.GraphVersion = "v19.0"
+	}
+	return &FacebookProvider{
+		graphVersion: cfg.GraphVersion,
+		oauth: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       []string{"email", "public_profile"},
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  "https://www.facebook.com/" + cfg.GraphVersion + "/dialog/oauth",
+				TokenURL: "https://graph.facebook.com/" + cfg.GraphVersion + "/oauth/access_token",
+			},
+		},
+	}
+}
+
+func (p *FacebookProvider) Name() string        { return "facebook" }
+func (p *FacebookProvider) DisplayName() string { return "Facebook" }
+
+func (p *FacebookProvider) AuthCodeURL(ctx context.Context, flow Flow) (string, error) {
+	return p.oauth.AuthCodeURL(flow.State, oauth2.S256ChallengeOption(flow.Verifier)), nil
+}
+
+func (p *FacebookProvider) Exchange(ctx context.Context, callback Callback, flow Flow) (*Identity, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	token, err := p.oauth.Exchange(ctx, callback.Code, oauth2.VerifierOption(flow.Verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	var info struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	params := url.Values{"fields": {"id,name,email"}}
+	endpoint := "https://graph.facebook.com/" + p.graphVersion + "/me?" + params.Encode()
+	if err := getJSON(ctx, p.oauth.Client(ctx, token), endpoint, &info); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUserInfo, err)
+	}
+	if info.ID == "" {
+		return nil, ErrUserInfo
+	}
+
+	return &Identity{
+		Provider:      p.Name(),
+		ProviderID:    info.ID,
+		Email:         info.Email,
+		EmailVerified: false,
+		Name:          info.Name,
+	}, nil
+}
@@ -0,0 +1,150 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+type OIDCConfig struct {
+	Name         string
+	DisplayName  string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type discoveryDocument struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint"`
+	CodeChallengeMethods  []string `json:"code_challenge_methods_supported"`
+}
+
+// OIDCProvider, issuer adresindeki discovery belgesinden uç noktaları bir kez
+// okuyup önbelleğe alan genel OpenID Connect sağlayıcısıdır. Google da bu
+// sağlayıcı üzerinden çalışır.
+type OIDCProvider struct {
+	cfg OIDCConfig
+
+	mu      sync.Mutex
+	oauth   *oauth2.Config
+	doc     *discoveryDocument
+	usePKCE bool
+}
+
+func NewOIDCProvider(cfg OIDCConfig) *OIDCProvider {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &OIDCProvider{cfg: cfg}
+}
+
+func (p *OIDCProvider) Name() string        { return p.cfg.Name }
+func (p *OIDCProvider) DisplayName() string { return p.cfg.DisplayName }
+
+func (p *OIDCProvider) config(ctx context.Context) (*oauth2.Config, *discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth != nil {
+		return p.oauth, p.doc, nil
+	}
+
+	var doc discoveryDocument
+	wellKnown := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, httpClient, wellKnown, &doc); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(p.cfg.Issuer, "/") {
+		return nil, nil, fmt.Errorf("%w: issuer uyuşmuyor", ErrDiscovery)
+	}
+
+	p.doc = &doc
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       p.cfg.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  doc.AuthorizationEndpoint,
+			TokenURL: doc.TokenEndpoint,
+		},
+	}
+	for _, method := range doc.CodeChallengeMethods {
+		if method == "S256" {
+			p.usePKCE = true
+		}
+	}
+	return p.oauth, p.doc, nil
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, flow Flow) (string, error) {
+	conf, _, err := p.config(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	opts := []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("nonce", flow.Nonce)}
+	if p.usePKCE {
+		opts = append(opts, oauth2.S256ChallengeOption(flow.Verifier))
+	}
+	return conf.AuthCodeURL(flow.State, opts...), nil
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, callback Callback, flow Flow) (*Identity, error) {
+	conf, doc, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []oauth2.AuthCodeOption
+	if p.usePKCE {
+		opts = append(opts, oauth2.VerifierOption(flow.Verifier))
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	token, err := conf.Exchange(ctx, callback.Code, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+	claims, err := parseIDToken(rawIDToken, doc.Issuer, p.cfg.ClientID, flow.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Provider:      p.cfg.Name,
+		ProviderID:    claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}
+
+	if identity.Email == "" && doc.UserinfoEndpoint != "" {
+		var info idTokenClaims
+		if err := getJSON(ctx, conf.Client(ctx, token), doc.UserinfoEndpoint, &info); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUserInfo, err)
+		}
+		if info.Subject == claims.Subject {
+			identity.Email = info.Email
+			identity.EmailVerified = bool(info.EmailVerified)
+			if identity.Name == "" {
+				identity.Name = info.Name
+			}
+		}
+	}
+
+	return identity, nil
+}
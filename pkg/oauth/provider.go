@@ -0,0 +1,170 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidIDToken = errors.New("geçersiz kimlik tokenı")
+	ErrMissingIDToken = errors.New("kimlik tokenı alınamadı")
+	ErrDiscovery      = errors.New("sağlayıcı yapılandırması alınamadı")
+	ErrUserInfo       = errors.New("kullanıcı bilgileri alınamadı")
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Identity, sağlayıcıdan dönen ve models.User.Provider / ProviderID
+// alanlarıyla eşleştirilen kullanıcı kimliğidir.
+type Identity struct {
+	Provider      string
+	ProviderID    string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Flow, yetkilendirme isteği ile geri dönüş arasında oturumda saklanan
+// tek kullanımlık değerlerdir.
+type Flow struct {
+	State    string
+	Verifier string
+	Nonce    string
+}
+
+// Callback, sağlayıcının geri dönüşte gönderdiği değerlerdir. Apple, kullanıcının
+// adını yalnızca ilk girişte "user" alanında JSON olarak gönderir.
+type Callback struct {
+	Code string
+	User string
+}
+
+type Provider interface {
+	Name() string
+	DisplayName() string
+	AuthCodeURL(ctx context.Context, flow Flow) (string, error)
+	Exchange(ctx context.Context, callback Callback, flow Flow) (*Identity, error)
+}
+
+func randomString(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func NewFlow() (Flow, error) {
+	state, err := randomString(32)
+	if err != nil {
+		return Flow{}, err
+	}
+	nonce, err := randomString(32)
+	if err != nil {
+		return Flow{}, err
+	}
+	verifier, err := randomString(48)
+	if err != nil {
+		return Flow{}, err
+	}
+	return Flow{State: state, Verifier: verifier, Nonce: nonce}, nil
+}
+
+// flexBool, "true" gibi metin olarak gönderilen boolean claim'leri de kabul eder.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	*b = flexBool(s == "true")
+	return nil
+}
+
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, v := range a {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type idTokenClaims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Audience      audience `json:"aud"`
+	Expiry        int64    `json:"exp"`
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified flexBool `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// parseIDToken, token uç noktasından TLS üzerinden doğrudan alınan kimlik
+// tokenının claim'lerini doğrular. OIDC Core 3.1.3.7 gereği bu durumda imza
+// doğrulaması yerine TLS sunucu doğrulaması yeterlidir; iss, aud, exp ve nonce
+// yine de kontrol edilir.
+func parseIDToken(raw, issuer, clientID, nonce string) (*idTokenClaims, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidIDToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	var claims idTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	if strings.TrimSuffix(claims.Issuer, "/") != strings.TrimSuffix(issuer, "/") ||
+		!claims.Audience.contains(clientID) ||
+		time.Now().Unix() >= claims.Expiry ||
+		claims.Subject == "" {
+		return nil, ErrInvalidIDToken
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, ErrInvalidIDToken
+	}
+	return &claims, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
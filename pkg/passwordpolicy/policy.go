@@ -0,0 +1,209 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var commonPasswords = loadCommonPasswords(commonPasswordsFile)
+
+type Violation string
+
+const (
+	ViolationTooShort      Violation = "too_short"
+	ViolationTooLong       Violation = "too_long"
+	ViolationNoUpper       Violation = "no_upper"
+	ViolationNoLower       Violation = "no_lower"
+	ViolationNoDigit       Violation = "no_digit"
+	ViolationNoSymbol      Violation = "no_symbol"
+	ViolationPersonalInfo  Violation = "personal_info"
+	ViolationCommon        Violation = "common"
+	ViolationRecentlyUsed  Violation = "recently_used"
+	ViolationSameAsCurrent Violation = "same_as_current"
+)
+
+// bcrypt yalnızca ilk 72 baytı dikkate alır.
+const MaxBytes = 72
+
+const personalFragmentMinRunes = 3
+
+type Policy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// Parolanın e-posta veya ad parçalarını içermesini engeller.
+	ForbidPersonalInfo bool
+	// Parolayı depo ile gelen yaygın/sızdırılmış parola listesine karşı denetler.
+	CheckCommon bool
+	// Son kaç parolanın tekrar kullanılamayacağı; 0 kapalıdır.
+	HistorySize int
+}
+
+// Check, parolayı politikaya göre denetler ve ihlalleri sırayla döner.
+// personal, kullanıcının e-posta ve adı gibi parolada geçmemesi gereken
+// değerlerdir.
+func (p Policy) Check(password string, personal ...string) []Violation {
+	var violations []Violation
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, ViolationTooShort)
+	}
+	if len(password) > MaxBytes {
+		violations = append(violations, ViolationTooLong)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, ViolationNoUpper)
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, ViolationNoLower)
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, ViolationNoDigit)
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, ViolationNoSymbol)
+	}
+
+	if p.ForbidPersonalInfo && containsPersonalInfo(password, personal) {
+		violations = append(violations, ViolationPersonalInfo)
+	}
+	if p.CheckCommon && IsCommon(password) {
+		violations = append(violations, ViolationCommon)
+	}
+
+	return violations
+}
+
+// Message, ihlal için kullanıcıya gösterilecek Türkçe açıklamayı döner.
+func (p Policy) Message(v Violation) string {
+	switch v {
+	case ViolationTooShort:
+		return fmt.Sprintf("Şifre en az %d karakter olmalıdır.", p.MinLength)
+	case ViolationTooLong:
+		return fmt.Sprintf("Şifre en fazla %d bayt olabilir.", MaxBytes)
+	case ViolationNoUpper:
+		return "Şifre en az bir büyük harf içermelidir."
+	case ViolationNoLower:
+		return "Şifre en az bir küçük harf içermelidir."
+	case ViolationNoDigit:
+		return "Şifre en az bir rakam içermelidir."
+	case ViolationNoSymbol:
+		return "Şifre en az bir özel karakter içermelidir."
+	case ViolationPersonalInfo:
+		return "Şifre adınızı veya e-posta adresinizi içeremez."
+	case ViolationCommon:
+		return "Bu şifre çok yaygın veya sızdırılmış şifre listelerinde yer alıyor; lütfen başka bir şifre seçin."
+	case ViolationRecentlyUsed:
+		return fmt.Sprintf("Son %d şifrenizden birini tekrar kullanamazsınız.", p.HistorySize)
+	case ViolationSameAsCurrent:
+		return "Yeni şifre mevcut şifre ile aynı olamaz."
+	default:
+		return "Şifre güvenlik kurallarını karşılamıyor."
+	}
+}
+
+// Requirements, formlarda gösterilmek üzere politikanın kısa özetidir.
+func (p Policy) Requirements() []string {
+	requirements := []string{fmt.Sprintf("En az %d karakter", p.MinLength)}
+	if p.RequireUpper {
+		requirements = append(requirements, "en az bir büyük harf")
+	}
+	if p.RequireLower {
+		requirements = append(requirements, "en az bir küçük harf")
+	}
+	if p.RequireDigit {
+		requirements = append(requirements, "en az bir rakam")
+	}
+	if p.RequireSymbol {
+		requirements = append(requirements, "en az bir özel karakter")
+	}
+	return requirements
+}
+
+func lower(s string) string {
+	return strings.ToLowerSpecial(unicode.TurkishCase, s)
+}
+
+// IsCommon, parolanın kendisi veya sonundaki rakam/özel karakterler atılmış
+// hali ("Galatasaray1905!" -> "galatasaray") listede ise true döner.
+func IsCommon(password string) bool {
+	candidate := lower(strings.TrimSpace(password))
+	if _, ok := commonPasswords[candidate]; ok {
+		return true
+	}
+
+	base := strings.TrimRightFunc(candidate, func(r rune) bool {
+		return unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	if utf8.RuneCountInString(base) < 4 || base == candidate {
+		return false
+	}
+	_, ok := commonPasswords[base]
+	return ok
+}
+
+func containsPersonalInfo(password string, personal []string) bool {
+	normalized := lower(password)
+	for _, value := range personal {
+		for _, fragment := range personalFragments(lower(value)) {
+			if strings.Contains(normalized, fragment) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// personalFragments, e-postanın yerel kısmını ve ad soyadın her kelimesini
+// ayrı ayrı döner; çok kısa parçalar yanlış pozitif üretmemesi için atlanır.
+func personalFragments(value string) []string {
+	if at := strings.LastIndex(value, "@"); at > 0 {
+		value = value[:at]
+	}
+
+	var fragments []string
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if utf8.RuneCountInString(part) >= personalFragmentMinRunes {
+			fragments = append(fragments, part)
+		}
+	}
+	return fragments
+}
+
+func loadCommonPasswords(content string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[lower(line)] = struct{}{}
+	}
+	return passwords
+}
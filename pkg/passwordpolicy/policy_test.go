@@ -0,0 +1,152 @@
+package passwordpolicy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var strictPolicy = Policy{
+	MinLength:          10,
+	RequireUpper:       true,
+	RequireLower:       true,
+	RequireDigit:       true,
+	RequireSymbol:      true,
+	ForbidPersonalInfo: true,
+	CheckCommon:        true,
+	HistorySize:        5,
+}
+
+func TestCheckLengthAndClasses(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   Policy
+		password string
+		want     []Violation
+	}{
+		{"geçerli", strictPolicy, "Zq9!rT4#mW2x", nil},
+		{"kısa", strictPolicy, "Zq9!rT4#", []Violation{ViolationTooShort}},
+		{"tam sınırda", strictPolicy, "Zq9!rT4#mW", nil},
+		{"karakter sayısı bayt değil", Policy{MinLength: 8}, "şğüçöıŞĞ", nil},
+		{"uzun", strictPolicy, strings.Repeat("Zq9!", 19), []Violation{ViolationTooLong}},
+		{"72 bayt", strictPolicy, strings.Repeat("Zq9!", 18), nil},
+		{"büyük harf yok", strictPolicy, "zq9!rt4#mw2x", []Violation{ViolationNoUpper}},
+		{"küçük harf yok", strictPolicy, "ZQ9!RT4#MW2X", []Violation{ViolationNoLower}},
+		{"rakam yok", strictPolicy, "Zqx!rTy#mWkx", []Violation{ViolationNoDigit}},
+		{"özel karakter yok", strictPolicy, "Zq9xrT4kmW2x", []Violation{ViolationNoSymbol}},
+		{"boşluk özel karakter sayılır", strictPolicy, "Zq9 rT4 mW2x", nil},
+		{"Türkçe büyük harf", strictPolicy, "İq9!rt4#mw2x", nil},
+		{"boş", strictPolicy, "", []Violation{ViolationTooShort, ViolationNoUpper, ViolationNoLower, ViolationNoDigit, ViolationNoSymbol}},
+		{"kurallar kapalı", Policy{MinLength: 4}, "aaaa", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Check(tt.password); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check(%q) = %v, beklenen %v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPersonalInfo(t *testing.T) {
+	personal := []string{"ayse.yilmaz@example.com", "Ayşe Yılmaz"}
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{"e-posta parçası", "Yilmaz2024!x", true},
+		{"ad, Türkçe büyük harf", "AYŞE-Guclu-77", true},
+		{"soyad, Türkçe karakter", "xYılmaz#2024", true},
+		{"e-posta alan adı", "Example#2024x", false},
+		{"ilgisiz", "Zq9!rT4#mW2x", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := containsViolation(strictPolicy.Check(tt.password, personal...), ViolationPersonalInfo)
+			if got != tt.want {
+				t.Errorf("Check(%q) kişisel bilgi ihlali = %v, beklenen %v", tt.password, got, tt.want)
+			}
+		})
+	}
+
+	// Üç karakterden kısa ad parçaları yanlış pozitif üretmemelidir.
+	if v := strictPolicy.Check("AlOk#2024xyz", "Al Ok", "ok@example.com"); containsViolation(v, ViolationPersonalInfo) {
+		t.Errorf("kısa ad parçaları ihlal sayıldı: %v", v)
+	}
+	// Kural kapalıyken kişisel bilgi denetlenmez.
+	relaxed := strictPolicy
+	relaxed.ForbidPersonalInfo = false
+	if v := relaxed.Check("Yilmaz2024!x", personal...); containsViolation(v, ViolationPersonalInfo) {
+		t.Errorf("kural kapalıyken ihlal döndü: %v", v)
+	}
+}
+
+func TestIsCommon(t *testing.T) {
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{"123456", true},
+		{"QWERTY", true},
+		{" qwerty123 ", true},
+		{"Galatasaray1905!", true},
+		{"galatasaray", true},
+		{"abc1!", false},
+		{"Zq9!rT4#mW2x", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsCommon(tt.password); got != tt.want {
+			t.Errorf("IsCommon(%q) = %v, beklenen %v", tt.password, got, tt.want)
+		}
+	}
+
+	if v := strictPolicy.Check("Galatasaray1905!"); !reflect.DeepEqual(v, []Violation{ViolationCommon}) {
+		t.Errorf("Check(Galatasaray1905!) = %v, beklenen [%s]", v, ViolationCommon)
+	}
+	relaxed := strictPolicy
+	relaxed.CheckCommon = false
+	if v := relaxed.Check("Galatasaray1905!"); v != nil {
+		t.Errorf("liste kapalıyken ihlal döndü: %v", v)
+	}
+}
+
+func TestCommonPasswordsList(t *testing.T) {
+	if len(commonPasswords) < 100 {
+		t.Fatalf("yaygın parola listesinde %d kayıt var, beklenen en az 100", len(commonPasswords))
+	}
+	for password := range commonPasswords {
+		if password != lower(password) || strings.TrimSpace(password) != password || strings.HasPrefix(password, "#") {
+			t.Errorf("listedeki %q normalize edilmemiş", password)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	violations := []Violation{
+		ViolationTooShort, ViolationTooLong, ViolationNoUpper, ViolationNoLower, ViolationNoDigit,
+		ViolationNoSymbol, ViolationPersonalInfo, ViolationCommon, ViolationRecentlyUsed, ViolationSameAsCurrent,
+	}
+	fallback := strictPolicy.Message("bilinmeyen")
+	for _, v := range violations {
+		if msg := strictPolicy.Message(v); msg == "" || msg == fallback {
+			t.Errorf("Message(%s) = %q, ihlale özel mesaj bekleniyordu", v, msg)
+		}
+	}
+	if msg := strictPolicy.Message(ViolationTooShort); !strings.Contains(msg, "10") {
+		t.Errorf("Message(too_short) = %q, en az uzunluğu içermeli", msg)
+	}
+	if msg := strictPolicy.Message(ViolationRecentlyUsed); !strings.Contains(msg, "5") {
+		t.Errorf("Message(recently_used) = %q, geçmiş sayısını içermeli", msg)
+	}
+}
+
+func containsViolation(violations []Violation, want Violation) bool {
+	for _, v := range violations {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+// Package phone, Türkiye ve yurt dışı telefon numaralarını E.164 biçimine
+// (ör. +905321234567) çevirir ve gösterim için biçimlendirir.
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+// Hata mesajları "Telefon numarası geçersiz: ..." biçiminde gösterilmek üzere
+// yazılmıştır.
+var (
+	ErrEmpty             = errors.New("numara boş")
+	ErrInvalidCharacters = errors.New("yalnızca rakam, boşluk, parantez, tire ve başta + içerebilir")
+	ErrInvalidLength     = errors.New("hane sayısı hatalı")
+	ErrInvalidTRNumber   = errors.New("Türkiye numarası 0 ile başlayan 11 hane olmalıdır (ör. 0532 123 45 67)")
+)
+
+const trCountryCode = "90"
+
+// Parse, girilen numarayı E.164 biçimine çevirir. Ülke kodu olmadan yazılan
+// numaralar Türkiye numarası kabul edilir; 0532..., 532..., 90532... ve
+// +90532... aynı sonucu verir. Yurt dışı numaralar + veya 00 ile başlamalıdır.
+// 444 ile başlayan yedi haneli çağrı merkezi numaraları da kabul edilir.
+func Parse(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", ErrEmpty
+	}
+
+	international := false
+	var digits strings.Builder
+	for i, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			international = true
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.' || r == '/' || r == '\u00a0':
+		default:
+			return "", ErrInvalidCharacters
+		}
+	}
+
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		international = true
+		number = number[2:]
+	}
+
+	if international {
+		if strings.HasPrefix(number, trCountryCode) {
+			return parseTR(number[len(trCountryCode):])
+		}
+		// E.164 en fazla 15 hanedir; ülke kodu 0 ile başlamaz.
+		if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+			return "", ErrInvalidLength
+		}
+		return "+" + number, nil
+	}
+
+	switch {
+	case len(number) == 11 && number[0] == '0':
+		return parseTR(number[1:])
+	case len(number) == 12 && strings.HasPrefix(number, trCountryCode):
+		return parseTR(number[2:])
+	default:
+		return parseTR(number)
+	}
+}
+
+// parseTR, ülke kodu atılmış ulusal numarayı doğrular. "+90 0532..." gibi
+// ülke kodundan sonra yazılan alan kodu sıfırı da kabul edilir.
+func parseTR(national string) (string, error) {
+	if len(national) == 11 && national[0] == '0' {
+		national = national[1:]
+	}
+	if isSpecialTR(national) {
+		return "+" + trCountryCode + national, nil
+	}
+	if len(national) != 10 {
+		return "", ErrInvalidTRNumber
+	}
+	switch national[0] {
+	case '2', '3', '4', '5', '8':
+		return "+" + trCountryCode + national, nil
+	}
+	return "", ErrInvalidTRNumber
+}
+
+// isSpecialTR, alan kodu olmadan aranan 444 XXXX numaralarını tanır.
+func isSpecialTR(national string) bool {
+	return len(national) == 7 && strings.HasPrefix(national, "444")
+}
+
+// Normalize, numarayı E.164 biçimine çevirir; çevrilemezse değeri boşlukları
+// kırpılmış olarak döndürür. Eski kayıtların gösteriminde kullanılır.
+func Normalize(value string) string {
+	if normalized, err := Parse(value); err == nil {
+		return normalized
+	}
+	return strings.TrimSpace(value)
+}
+
+// IsMobile, numaranın bir Türkiye cep telefonu olup olmadığını döndürür.
+func IsMobile(value string) bool {
+	normalized, err := Parse(value)
+	if err != nil {
+		return false
+	}
+	national, ok := trNational(normalized)
+	return ok && len(national) == 10 && national[0] == '5'
+}
+
+// Format, numarayı okunması kolay biçimde yazar. Türkiye numaraları ulusal
+// biçimde (0532 123 45 67, 444 4 444), yurt dışı numaralar E.164 olarak
+// gösterilir; çözümlenemeyen değerler boşlukları kırpılmış olarak döner.
+func Format(value string) string {
+	normalized, err := Parse(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	national, ok := trNational(normalized)
+	if !ok {
+		return normalized
+	}
+	if isSpecialTR(national) {
+		return national[:3] + " " + national[3:4] + " " + national[4:]
+	}
+	return "0" + national[:3] + " " + national[3:6] + " " + national[6:8] + " " + national[8:]
+}
+
+// TelURI, numarayı tel: bağlantısına çevirir. Numara tanınmazsa boş döner.
+func TelURI(value string) string {
+	normalized, err := Parse(value)
+	if err != nil {
+		return ""
+	}
+	return "tel:" + normalized
+}
+
+// WhatsAppURL, numara için wa.me bağlantısı üretir. Türkiye'de yalnızca cep
+// telefonları WhatsApp kullanabildiğinden sabit hatlar ve 444 numaraları için
+// boş döner.
+func WhatsAppURL(value string) string {
+	normalized, err := Parse(value)
+	if err != nil {
+		return ""
+	}
+	if _, ok := trNational(normalized); ok && !IsMobile(normalized) {
+		return ""
+	}
+	return "https://wa.me/" + strings.TrimPrefix(normalized, "+")
+}
+
+func trNational(normalized string) (string, bool) {
+	if !strings.HasPrefix(normalized, "+"+trCountryCode) {
+		return "", false
+	}
+	return normalized[len(trCountryCode)+1:], true
+}
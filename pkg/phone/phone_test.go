@@ -0,0 +1,112 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"ulusal cep", "0532 123 45 67", "+905321234567", nil},
+		{"sıfırsız cep", "5321234567", "+905321234567", nil},
+		{"parantez ve tire", "+90 (532) 123-45-67", "+905321234567", nil},
+		{"artısız ülke kodu", "905321234567", "+905321234567", nil},
+		{"00 ön eki", "0090 532 1234567", "+905321234567", nil},
+		{"ülke kodundan sonra sıfır", "+90 0532 123 45 67", "+905321234567", nil},
+		{"sabit hat", "0216 000 00 00", "+902160000000", nil},
+		{"0850", "0850 123 45 67", "+908501234567", nil},
+		{"444 numarası", "444 4 444", "+904444444", nil},
+		{"bölünemez boşluk", "0532\u00a0123\u00a04567", "+905321234567", nil},
+		{"yurt dışı", "+44 20 7946 0958", "+442079460958", nil},
+		{"boş", "   ", "", ErrEmpty},
+		{"harf", "0532abc", "", ErrInvalidCharacters},
+		{"ortada artı", "0532+1234567", "", ErrInvalidCharacters},
+		{"kısa", "12345", "", ErrInvalidTRNumber},
+		{"geçersiz alan kodu", "0632 123 45 67", "", ErrInvalidTRNumber},
+		{"eksik TR", "+90 532", "", ErrInvalidTRNumber},
+		{"ülke kodu sıfır", "+0123456789", "", ErrInvalidLength},
+		{"yurt dışı kısa", "+4412", "", ErrInvalidLength},
+		{"yurt dışı uzun", "+4412345678901234", "", ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) hata = %v, beklenen %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %q, beklenen %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"0532 123 45 67", "+905321234567"},
+		{"+905321234567", "+905321234567"},
+		{"  dahili 12  ", "dahili 12"},
+		{"+90 532", "+90 532"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.input); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, beklenen %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"+905321234567", "0532 123 45 67"},
+		{"05321234567", "0532 123 45 67"},
+		{"+90 0532 123 45 67", "0532 123 45 67"},
+		{"+904444444", "444 4 444"},
+		{"+442079460958", "+442079460958"},
+		// Eski kayıtlardaki çözümlenemeyen değerler panik yaratmadan olduğu gibi döner.
+		{"+90 532", "+90 532"},
+		{"+9012", "+9012"},
+		{"+90", "+90"},
+		{" 0532 ", "0532"},
+		{"dahili 12", "dahili 12"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.input); got != tt.want {
+			t.Errorf("Format(%q) = %q, beklenen %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestLinks(t *testing.T) {
+	tests := []struct {
+		input    string
+		tel      string
+		whatsApp string
+	}{
+		{"0532 123 45 67", "tel:+905321234567", "https://wa.me/905321234567"},
+		{"0216 000 00 00", "tel:+902160000000", ""},
+		{"444 4 444", "tel:+904444444", ""},
+		{"+44 20 7946 0958", "tel:+442079460958", "https://wa.me/442079460958"},
+		{"+90 532", "", ""},
+	}
+	for _, tt := range tests {
+		if got := TelURI(tt.input); got != tt.tel {
+			t.Errorf("TelURI(%q) = %q, beklenen %q", tt.input, got, tt.tel)
+		}
+		if got := WhatsAppURL(tt.input); got != tt.whatsApp {
+			t.Errorf("WhatsAppURL(%q) = %q, beklenen %q", tt.input, got, tt.whatsApp)
+		}
+	}
+}
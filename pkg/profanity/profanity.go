@@ -0,0 +1,94 @@
+package profanity
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"unicode"
+)
+
+//go:embed words.txt
+var wordsFile string
+
+var exactWords, prefixWords = loadWords(wordsFile)
+
+// Karakter benzerliğiyle yazılmış ifadeleri (ör. "s1kt1r", "@mk") yakalamak
+// için eşlenen karakterler.
+var replacements = map[rune]rune{
+	'ç': 'c', 'ğ': 'g', 'ö': 'o', 'ş': 's', 'ü': 'u',
+	'â': 'a', 'î': 'i', 'û': 'u',
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't',
+	'@': 'a', '$': 's', '!': 'i',
+}
+
+// Contains, metinde listedeki ifadelerden biri geçiyorsa true döner.
+func Contains(text string) bool {
+	for _, token := range tokens(text) {
+		if _, ok := exactWords[token]; ok {
+			return true
+		}
+		for _, prefix := range prefixWords {
+			if strings.HasPrefix(token, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tokens, metni sadeleştirip kelimelere ayırır. Harf ve eşlenen karakterler
+// dışındaki her şey ayraç sayılır; tekrar eden harfler teke indirilir.
+func tokens(text string) []string {
+	var (
+		result  []string
+		builder strings.Builder
+		last    rune
+	)
+	flush := func() {
+		if builder.Len() > 0 {
+			result = append(result, builder.String())
+			builder.Reset()
+		}
+		last = 0
+	}
+	for _, r := range text {
+		r = unicode.TurkishCase.ToLower(r)
+		if repl, ok := replacements[r]; ok {
+			r = repl
+		}
+		if !unicode.IsLetter(r) {
+			flush()
+			continue
+		}
+		if r == last {
+			continue
+		}
+		builder.WriteRune(r)
+		last = r
+	}
+	flush()
+	return result
+}
+
+func loadWords(content string) (map[string]struct{}, []string) {
+	exact := make(map[string]struct{})
+	var prefixes []string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		prefix := strings.HasSuffix(line, "*")
+		normalized := tokens(strings.TrimSuffix(line, "*"))
+		if len(normalized) != 1 {
+			continue
+		}
+		if prefix {
+			prefixes = append(prefixes, normalized[0])
+		} else {
+			exact[normalized[0]] = struct{}{}
+		}
+	}
+	return exact, prefixes
+}
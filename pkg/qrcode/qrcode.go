@@ -0,0 +1,59 @@
+package qrcode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	goqrcode "github.com/skip2/go-qrcode"
+)
+
+const DefaultSize = 256
+
+func PNG(content string, size int) ([]byte, error) {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return goqrcode.Encode(content, goqrcode.Medium, size)
+}
+
+// PNGDataURI, içeriği <img src="..."> içinde doğrudan kullanılabilecek
+// base64 kodlu bir PNG olarak döner.
+func PNGDataURI(content string, size int) (string, error) {
+	png, err := PNG(content, size)
+	if err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png), nil
+}
+
+// SVG, içeriği baskıda ölçeklenebilen bir SVG olarak döner. Her koyu modül
+// tek bir path içinde 1x1 kare olarak çizilir; size yalnızca varsayılan
+// görüntüleme boyutunu belirler.
+func SVG(content string, size int) ([]byte, error) {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	code, err := goqrcode.New(content, goqrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	bitmap := code.Bitmap()
+	modules := len(bitmap)
+
+	var path bytes.Buffer
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/>`, modules, modules)
+	fmt.Fprintf(&b, `<path fill="#000" d="%s"/>`, path.String())
+	b.WriteString(`</svg>`)
+	return b.Bytes(), nil
+}
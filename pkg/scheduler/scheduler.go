@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/logconfig"
+
+	"go.uber.org/zap"
+)
+
+// Every, job'ı hemen ve ardından her interval sonunda ctx iptal edilene kadar
+// arka planda çalıştırır. Bir çalıştırmadaki panik sonraki çalıştırmaları
+// durdurmaz.
+func Every(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			run(ctx, name, job)
+
+			select {
+			case <-ctx.Done():
+				logconfig.Log.Info("Zamanlanmış görev durduruldu", zap.String("job", name))
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func run(ctx context.Context, name string, job func(ctx context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			logconfig.Log.Error("Zamanlanmış görev panik ile sonlandı",
+				zap.String("job", name),
+				zap.Any("panic", r),
+			)
+		}
+	}()
+	job(ctx)
+}
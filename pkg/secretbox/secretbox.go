@@ -0,0 +1,76 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// prefix, mühürlenmiş değerleri düz metinden ayırır ve biçim değişirse
+// sürümlemeye izin verir.
+const prefix = "v1:"
+
+var (
+	ErrNotSealed = errors.New("değer şifrelenmemiş")
+	ErrOpen      = errors.New("şifreli değer çözülemedi")
+)
+
+// Seal, değeri AES-256-GCM ile şifreler. Anahtar uygulama anahtarından amaç
+// adıyla türetilir; amaç ayrıca ek doğrulama verisi olarak kullanıldığından
+// bir amaç için mühürlenen değer başka bir amaçla açılamaz.
+func Seal(key []byte, purpose, plaintext string) (string, error) {
+	aead, err := newAEAD(key, purpose)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(purpose))
+	return prefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open, Seal ile üretilmiş değeri çözer. Değer mühürlenmemişse ErrNotSealed,
+// anahtar veya amaç uyuşmuyorsa ya da değer bozulmuşsa ErrOpen döner.
+func Open(key []byte, purpose, sealed string) (string, error) {
+	if !IsSealed(sealed) {
+		return "", ErrNotSealed
+	}
+	raw, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(sealed, prefix))
+	if err != nil {
+		return "", ErrOpen
+	}
+	aead, err := newAEAD(key, purpose)
+	if err != nil {
+		return "", err
+	}
+	if len(raw) < aead.NonceSize() {
+		return "", ErrOpen
+	}
+	nonce, ciphertext := raw[:aead.NonceSize()], raw[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(purpose))
+	if err != nil {
+		return "", ErrOpen
+	}
+	return string(plaintext), nil
+}
+
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+func newAEAD(key []byte, purpose string) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("secretbox\x00" + purpose))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,67 @@
+package secretbox
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestSealOpen(t *testing.T) {
+	for _, plaintext := range []string{"GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", "", "çok baytlı değer"} {
+		sealed, err := Seal(testKey, "totp", plaintext)
+		if err != nil {
+			t.Fatalf("Seal(%q) hata = %v", plaintext, err)
+		}
+		if !IsSealed(sealed) || (plaintext != "" && strings.Contains(sealed, plaintext)) {
+			t.Fatalf("Seal(%q) = %q, düz metin açıkta", plaintext, sealed)
+		}
+		got, err := Open(testKey, "totp", sealed)
+		if err != nil || got != plaintext {
+			t.Errorf("Open = %q, %v; beklenen %q", got, err, plaintext)
+		}
+	}
+}
+
+func TestSealUsesFreshNonce(t *testing.T) {
+	first, _ := Seal(testKey, "totp", "gizli")
+	second, _ := Seal(testKey, "totp", "gizli")
+	if first == second {
+		t.Error("aynı değer iki kez aynı şifreli metni üretti")
+	}
+}
+
+func TestOpenRejects(t *testing.T) {
+	sealed, err := Seal(testKey, "totp", "gizli")
+	if err != nil {
+		t.Fatalf("Seal hata = %v", err)
+	}
+	tampered := sealed[:len(sealed)-2] + "AA"
+	if tampered == sealed {
+		tampered = sealed[:len(sealed)-2] + "BB"
+	}
+
+	tests := []struct {
+		name    string
+		key     []byte
+		purpose string
+		value   string
+		wantErr error
+	}{
+		{"farklı anahtar", []byte("fedcba9876543210fedcba9876543210"), "totp", sealed, ErrOpen},
+		{"farklı amaç", testKey, "oauth", sealed, ErrOpen},
+		{"bozulmuş değer", testKey, "totp", tampered, ErrOpen},
+		{"geçersiz base64", testKey, "totp", prefix + "!!!", ErrOpen},
+		{"kısa değer", testKey, "totp", prefix + "AAAA", ErrOpen},
+		{"düz metin", testKey, "totp", "GEZDGNBVGY3TQOJQ", ErrNotSealed},
+		{"boş", testKey, "totp", "", ErrNotSealed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Open(tt.key, tt.purpose, tt.value); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Open hata = %v, beklenen %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// signatureLength, HMAC-SHA256 çıktısının bağlantılarda kullanılan kısmıdır
+// (128 bit).
+const signatureLength = 16
+
+// Sign, parçaları birleştirip HMAC-SHA256 ile imzalar ve URL'de güvenle
+// kullanılabilecek bir imza döndürür. Farklı amaçlar için ilk parça olarak
+// bir kapsam adı verilmelidir (ör. "guest").
+func Sign(key []byte, parts ...string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.Join(parts, "\x00")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureLength])
+}
+
+func Verify(key []byte, signature string, parts ...string) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(key, parts...)))
+}
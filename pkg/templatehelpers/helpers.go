@@ -1,9 +1,15 @@
 package templatehelpers
 
 import (
+	htmltemplate "html/template"
 	"net/url"
 	"text/template"
 	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/calendar"
+	"zatrano/pkg/iban"
+	"zatrano/pkg/phone"
 )
 
 func TemplateHelpers() template.FuncMap {
@@ -24,6 +30,13 @@ func TemplateHelpers() template.FuncMap {
 			}
 			return b
 		},
+		// Percent, oranı 0-100 arası tam sayı olarak döndürür; grafik çubukları için.
+		"Percent": func(part, total int64) int64 {
+			if total <= 0 {
+				return 0
+			}
+			return part * 100 / total
+		},
 		"Iterate": func(start, end int) []int {
 			count := end - start + 1
 			if count <= 0 {
@@ -36,6 +49,16 @@ func TemplateHelpers() template.FuncMap {
 			return items
 		},
 		"urlquery": func(s string) string { return url.QueryEscape(s) },
+		// FormatIBAN, IBAN'ı dörderli gruplar halinde gösterir.
+		"FormatIBAN": iban.Format,
+		// FormatPhone, telefonu 0532 123 45 67 biçiminde gösterir. TelURL
+		// html/template'in tel: bağlantılarını engellememesi için güvenli URL
+		// döndürür; WhatsAppURL sabit hatlarda boştur.
+		"FormatPhone": phone.Format,
+		"TelURL": func(value string) htmltemplate.URL {
+			return htmltemplate.URL(phone.TelURI(value))
+		},
+		"WhatsAppURL": phone.WhatsAppURL,
 		"dict": func(values ...interface{}) map[string]interface{} {
 			dict := make(map[string]interface{})
 			if len(values)%2 != 0 {
@@ -58,6 +81,13 @@ func TemplateHelpers() template.FuncMap {
 			return t.Format(layout)
 		},
 
+		"FormatTimeIn": func(t *time.Time, loc *time.Location, layout string) string {
+			if t == nil || t.IsZero() {
+				return ""
+			}
+			return t.In(loc).Format(layout)
+		},
+
 		"FormatDate": func(t time.Time) string {
 			if t.IsZero() {
 				return ""
@@ -72,6 +102,34 @@ func TemplateHelpers() template.FuncMap {
 			return t.Format("02.01.2006 15:04")
 		},
 
+		"GoogleCalendarURL": func(invitation *models.Invitation) string {
+			if invitation == nil || invitation.EventAt.IsZero() {
+				return ""
+			}
+			return calendar.FromInvitation(invitation).GoogleURL()
+		},
+
+		"OutlookCalendarURL": func(invitation *models.Invitation) string {
+			if invitation == nil || invitation.EventAt.IsZero() {
+				return ""
+			}
+			return calendar.FromInvitation(invitation).OutlookURL()
+		},
+
+		"EventGoogleCalendarURL": func(invitation *models.Invitation, event models.InvitationEvent) string {
+			if invitation == nil || event.StartAt.IsZero() {
+				return ""
+			}
+			return calendar.FromInvitationEvent(invitation, &event).GoogleURL()
+		},
+
+		"EventOutlookCalendarURL": func(invitation *models.Invitation, event models.InvitationEvent) string {
+			if invitation == nil || event.StartAt.IsZero() {
+				return ""
+			}
+			return calendar.FromInvitationEvent(invitation, &event).OutlookURL()
+		},
+
 		"hasPrefix": func(s, prefix string) bool {
 			return len(s) >= len(prefix) && s[:len(prefix)] == prefix
 		},
@@ -0,0 +1,165 @@
+package totp
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfcSecret, RFC 6238 Ek B'deki SHA1 anahtarı "12345678901234567890"ın
+// base32 karşılığıdır.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// TestGenerateCodeRFC6238, RFC 6238 Ek B SHA1 test vektörlerini doğrular. RFC
+// sekiz haneli kodlar verir; altı haneli kodlar bunların son altı hanesidir.
+func TestGenerateCodeRFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		rfc  string
+		want string
+	}{
+		{59, "94287082", "287082"},
+		{1111111109, "07081804", "081804"},
+		{1111111111, "14050471", "050471"},
+		{1234567890, "89005924", "005924"},
+		{2000000000, "69279037", "279037"},
+		{20000000000, "65353130", "353130"},
+	}
+	for _, tt := range tests {
+		got, err := GenerateCode(rfcSecret, time.Unix(tt.unix, 0).UTC())
+		if err != nil {
+			t.Fatalf("GenerateCode(%d) hata = %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("GenerateCode(%d) = %q, beklenen %q (RFC: %s)", tt.unix, got, tt.want, tt.rfc)
+		}
+	}
+}
+
+func TestGenerateCodeSecretFormat(t *testing.T) {
+	want, _ := GenerateCode(rfcSecret, time.Unix(59, 0))
+	for _, secret := range []string{"gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "  " + rfcSecret + "\n"} {
+		got, err := GenerateCode(secret, time.Unix(59, 0))
+		if err != nil || got != want {
+			t.Errorf("GenerateCode(%q) = %q, %v; beklenen %q", secret, got, err, want)
+		}
+	}
+	for _, secret := range []string{"", "1", "GEZDGNBV!", "GEZDGNBVGY3TQOJQ===="} {
+		if _, err := GenerateCode(secret, time.Unix(59, 0)); !errors.Is(err, ErrInvalidSecret) {
+			t.Errorf("GenerateCode(%q) hata = %v, beklenen %v", secret, err, ErrInvalidSecret)
+		}
+	}
+}
+
+func TestValidateWindow(t *testing.T) {
+	// 1111111111 30 saniyelik 37037037. adımın 1. saniyesidir.
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+	codeAt := func(offset int64) string {
+		code, err := GenerateCode(rfcSecret, time.Unix((current+offset)*Period, 0))
+		if err != nil {
+			t.Fatalf("GenerateCode hata = %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name   string
+		offset int64
+		skew   int
+		ok     bool
+	}{
+		{"geçerli adım", 0, 0, true},
+		{"önceki adım, tolerans yok", -1, 0, false},
+		{"sonraki adım, tolerans yok", 1, 0, false},
+		{"önceki adım", -1, 1, true},
+		{"sonraki adım", 1, 1, true},
+		{"iki adım önce", -2, 1, false},
+		{"iki adım sonra", 2, 1, false},
+		{"iki adım önce, tolerans 2", -2, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := codeAt(tt.offset)
+			step, ok := Validate(rfcSecret, code, now, tt.skew)
+			if ok != tt.ok {
+				t.Fatalf("Validate(%s, skew=%d) = %v, beklenen %v", code, tt.skew, ok, tt.ok)
+			}
+			if ok && step != current+tt.offset {
+				t.Errorf("eşleşen adım = %d, beklenen %d", step, current+tt.offset)
+			}
+			if !ok && step != 0 {
+				t.Errorf("reddedilen kod için adım = %d, beklenen 0", step)
+			}
+		})
+	}
+}
+
+func TestValidateStepBoundary(t *testing.T) {
+	// 59. saniyenin kodu 60. saniyede bir sonraki adıma geçer; tolerans
+	// olmadan reddedilir, bir adımlık toleransla kabul edilir.
+	code, _ := GenerateCode(rfcSecret, time.Unix(59, 0))
+	if _, ok := Validate(rfcSecret, code, time.Unix(60, 0), 0); ok {
+		t.Error("önceki adımın kodu toleranssız kabul edildi")
+	}
+	if step, ok := Validate(rfcSecret, code, time.Unix(60, 0), 1); !ok || step != 1 {
+		t.Errorf("Validate = %d, %v; beklenen 1, true", step, ok)
+	}
+	if _, ok := Validate(rfcSecret, code, time.Unix(30, 0), 0); !ok {
+		t.Error("aynı adımın ilk saniyesinde kod reddedildi")
+	}
+}
+
+func TestValidateMalformedInput(t *testing.T) {
+	now := time.Unix(59, 0)
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		ok     bool
+	}{
+		{"boşluklu kod", rfcSecret, " 287082 ", true},
+		{"eksik hane", rfcSecret, "28708", false},
+		{"fazla hane", rfcSecret, "94287082", false},
+		{"boş kod", rfcSecret, "", false},
+		{"harfli kod", rfcSecret, "28708a", false},
+		{"geçersiz anahtar", "!!!", "287082", false},
+		{"boş anahtar", "", "287082", false},
+	}
+	for _, tt := range tests {
+		if _, ok := Validate(tt.secret, tt.code, now, 1); ok != tt.ok {
+			t.Errorf("%s: Validate(%q, %q) = %v, beklenen %v", tt.name, tt.secret, tt.code, ok, tt.ok)
+		}
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret hata = %v", err)
+	}
+	key, err := decodeSecret(secret)
+	if err != nil || len(key) != SecretSize {
+		t.Fatalf("anahtar %q çözülemedi veya %d bayt değil: %v", secret, SecretSize, err)
+	}
+	if other, _ := GenerateSecret(); other == secret {
+		t.Error("iki çağrı aynı anahtarı üretti")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(ProvisioningURI("Zatrano", "ayse@example.com", rfcSecret))
+	if err != nil {
+		t.Fatalf("URI çözümlenemedi: %v", err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Zatrano:ayse@example.com" {
+		t.Errorf("URI = %s", uri)
+	}
+	query := uri.Query()
+	for key, want := range map[string]string{"secret": rfcSecret, "issuer": "Zatrano", "algorithm": "SHA1", "digits": "6", "period": "30"} {
+		if got := query.Get(key); got != want {
+			t.Errorf("%s = %q, beklenen %q", key, got, want)
+		}
+	}
+}
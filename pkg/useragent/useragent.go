@@ -0,0 +1,92 @@
+package useragent
+
+import "strings"
+
+type DeviceClass string
+
+const (
+	DeviceDesktop DeviceClass = "desktop"
+	DeviceMobile  DeviceClass = "mobile"
+	DeviceTablet  DeviceClass = "tablet"
+	DeviceBot     DeviceClass = "bot"
+	DeviceUnknown DeviceClass = "unknown"
+)
+
+// Info, User-Agent başlığından kaba bir tarayıcı, işletim sistemi ve cihaz
+// sınıfı çıkarımıdır; tam bir ayrıştırıcı değildir, yalnızca listeleme ve
+// istatistik amaçlıdır.
+type Info struct {
+	Browser string
+	OS      string
+	Device  DeviceClass
+}
+
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "facebookexternalhit", "whatsapp", "telegram", "preview", "curl", "wget", "python-requests", "go-http-client"}
+
+// Sıralama önemlidir: Edge ve Opera, Chrome imzasını da taşır.
+var browsers = []struct{ marker, name string }{
+	{"edg/", "Edge"},
+	{"opr/", "Opera"},
+	{"samsungbrowser", "Samsung Internet"},
+	{"yabrowser", "Yandex"},
+	{"firefox/", "Firefox"},
+	{"fxios", "Firefox"},
+	{"crios", "Chrome"},
+	{"chrome/", "Chrome"},
+	{"safari/", "Safari"},
+}
+
+var systems = []struct{ marker, name string }{
+	{"windows", "Windows"},
+	{"iphone", "iOS"},
+	{"ipad", "iPadOS"},
+	{"android", "Android"},
+	{"mac os x", "macOS"},
+	{"cros", "ChromeOS"},
+	{"linux", "Linux"},
+}
+
+func Parse(ua string) Info {
+	lower := strings.ToLower(ua)
+	info := Info{Browser: "Bilinmeyen tarayıcı", OS: "Bilinmeyen sistem", Device: DeviceUnknown}
+	if lower == "" {
+		return info
+	}
+
+	for _, marker := range botMarkers {
+		if strings.Contains(lower, marker) {
+			info.Browser = "Bot"
+			info.Device = DeviceBot
+			return info
+		}
+	}
+
+	for _, b := range browsers {
+		if strings.Contains(lower, b.marker) {
+			info.Browser = b.name
+			break
+		}
+	}
+	for _, s := range systems {
+		if strings.Contains(lower, s.marker) {
+			info.OS = s.name
+			break
+		}
+	}
+
+	switch {
+	case strings.Contains(lower, "ipad") || strings.Contains(lower, "tablet") ||
+		(strings.Contains(lower, "android") && !strings.Contains(lower, "mobile")):
+		info.Device = DeviceTablet
+	case strings.Contains(lower, "mobi") || strings.Contains(lower, "iphone"):
+		info.Device = DeviceMobile
+	default:
+		info.Device = DeviceDesktop
+	}
+	return info
+}
+
+// String, "Chrome · Windows" biçiminde kısa bir etiket döner.
+func (i Info) String() string {
+	return i.Browser + " · " + i.OS
+}
@@ -0,0 +1,88 @@
+package vcard
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"zatrano/configs/fileconfig"
+	"zatrano/models"
+)
+
+// maxPhotoBytes, vCard'a gömülecek fotoğrafın üst sınırıdır; daha büyük
+// dosyalar bazı rehber uygulamalarında içe aktarmayı bozduğu için atlanır.
+const maxPhotoBytes = 512 * 1024
+
+// FromCard, kartvizitten vCard üretir. Sosyal medya bağlantıları için
+// CardSocialMedia.SocialMedia önceden yüklenmiş olmalıdır.
+func FromCard(card *models.Card) Card {
+	baseURL := strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")
+	pageURL := ""
+	if baseURL != "" {
+		pageURL = baseURL + "/@" + card.Slug
+	}
+
+	v := Card{
+		UID:           "urn:zatrano:card:" + card.Slug,
+		FormattedName: strings.TrimSpace(card.Name),
+		Title:         card.Title,
+		Telephone:     card.Telephone,
+		Email:         card.Email,
+		MapURL:        card.Location,
+		Address:       addressFromMapURL(card.Location),
+	}
+	if v.FormattedName == "" {
+		v.FormattedName = card.Slug
+	}
+	for _, u := range []string{pageURL, card.WebsiteUrl, card.StoreUrl} {
+		if u != "" {
+			v.URLs = append(v.URLs, u)
+		}
+	}
+	if pageURL != "" {
+		v.Source = pageURL + "/vcard.vcf"
+	}
+	for _, sm := range card.CardSocialMedia {
+		v.SocialProfiles = append(v.SocialProfiles, SocialProfile{Type: sm.SocialMedia.Name, URL: sm.URL})
+	}
+	v.Photo, v.PhotoMediaType = readPhoto(card.Photo)
+	return v
+}
+
+// addressFromMapURL, Google Haritalar bağlantısındaki arama metnini adres
+// olarak kullanır.
+func addressFromMapURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	q := u.Query()
+	for _, key := range []string{"query", "q"} {
+		if v := strings.TrimSpace(q.Get(key)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func readPhoto(fileName string) ([]byte, string) {
+	if fileName == "" {
+		return nil, ""
+	}
+	path := filepath.Join(fileconfig.Config.GetPath("cards"), filepath.Base(fileName))
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > maxPhotoBytes {
+		return nil, ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ""
+	}
+	mediaType := http.DetectContentType(data)
+	if !strings.HasPrefix(mediaType, "image/") {
+		return nil, ""
+	}
+	return data, mediaType
+}
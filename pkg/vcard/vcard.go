@@ -0,0 +1,147 @@
+package vcard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxLineOctets = 75
+
+type SocialProfile struct {
+	Type string
+	URL  string
+}
+
+// Card, RFC 6350 (vCard 4.0) biçiminde yazılacak kişi bilgisidir.
+type Card struct {
+	UID            string
+	FormattedName  string
+	Title          string
+	Telephone      string
+	Email          string
+	URLs           []string
+	MapURL         string
+	Address        string
+	Photo          []byte
+	PhotoMediaType string
+	SocialProfiles []SocialProfile
+	Source         string
+}
+
+// Bytes, kartı satırları CRLF ile biten ve 75 oktette katlanan bir vCard
+// belgesi olarak döndürür. Fotoğraf data URI olarak gömülür.
+func (c Card) Bytes(now time.Time) []byte {
+	var b bytes.Buffer
+
+	writeLine(&b, "BEGIN:VCARD")
+	writeLine(&b, "VERSION:4.0")
+	if c.UID != "" {
+		writeLine(&b, "UID:"+c.UID)
+	}
+	writeLine(&b, "FN:"+escapeText(c.FormattedName))
+	writeLine(&b, "N:"+structuredName(c.FormattedName))
+	if c.Title != "" {
+		writeLine(&b, "TITLE:"+escapeText(c.Title))
+	}
+	if tel := telURI(c.Telephone); tel != "" {
+		writeLine(&b, "TEL;VALUE=uri;TYPE=\"voice,cell\":"+tel)
+	}
+	if c.Email != "" {
+		writeLine(&b, "EMAIL;TYPE=work:"+escapeText(c.Email))
+	}
+	for _, u := range c.URLs {
+		if u != "" {
+			writeLine(&b, "URL:"+u)
+		}
+	}
+	if c.Address != "" {
+		writeLine(&b, "ADR;LABEL=\""+quoteParam(c.Address)+"\":;;"+escapeText(c.Address)+";;;;")
+	}
+	if c.MapURL != "" {
+		writeLine(&b, "URL;TYPE=map:"+c.MapURL)
+	}
+	for _, profile := range c.SocialProfiles {
+		if profile.URL == "" {
+			continue
+		}
+		line := "X-SOCIALPROFILE"
+		if profile.Type != "" {
+			line += ";TYPE=" + quoteParam(strings.ToLower(profile.Type))
+		}
+		writeLine(&b, line+":"+profile.URL)
+	}
+	if len(c.Photo) > 0 && c.PhotoMediaType != "" {
+		writeLine(&b, "PHOTO:data:"+c.PhotoMediaType+";base64,"+base64.StdEncoding.EncodeToString(c.Photo))
+	}
+	if c.Source != "" {
+		writeLine(&b, "SOURCE:"+c.Source)
+	}
+	writeLine(&b, "REV:"+now.UTC().Format("20060102T150405Z"))
+	writeLine(&b, "END:VCARD")
+	return b.Bytes()
+}
+
+// structuredName, tam adın son kelimesini soyadı, kalanını ad kabul eder.
+func structuredName(fullName string) string {
+	parts := strings.Fields(fullName)
+	if len(parts) == 0 {
+		return ";;;;"
+	}
+	family := parts[len(parts)-1]
+	given := strings.Join(parts[:len(parts)-1], " ")
+	return escapeText(family) + ";" + escapeText(given) + ";;;"
+}
+
+// telURI, numarayı RFC 3966 tel: URI'sine çevirir; rakam dışı ayraçlar atılır.
+func telURI(phone string) string {
+	var digits strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			digits.WriteRune(r)
+		}
+	}
+	if digits.Len() == 0 {
+		return ""
+	}
+	return "tel:" + digits.String()
+}
+
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// quoteParam, tırnaklı parametre değerinde kullanılamayan karakterleri atar.
+func quoteParam(s string) string {
+	return strings.NewReplacer(`"`, "", "\r", " ", "\n", " ").Replace(s)
+}
+
+// writeLine, satırı 75 oktetten uzunsa UTF-8 karakterlerini bölmeden katlar
+// ve CRLF ile sonlandırır.
+func writeLine(b *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,77 @@
+// IBAN alanları: data-iban. Aynı data-iban-group içindeki banka seçimi
+// (seçeneklerde data-bank-code="00010") TR IBAN'ının 5-9. karakterlerindeki
+// banka koduna göre otomatik yapılır. Kontrol basamakları hatalı IBAN'lar
+// form gönderilmeden işaretlenir; asıl doğrulama sunucuda yapılır.
+(function () {
+  function normalize(value) {
+    return (value || '').replace(/[\s-]/g, '').toUpperCase();
+  }
+
+  function mod97(value) {
+    const rearranged = value.slice(4) + value.slice(0, 4);
+    let remainder = 0;
+    for (const ch of rearranged) {
+      if (ch >= '0' && ch <= '9') {
+        remainder = (remainder * 10 + Number(ch)) % 97;
+      } else {
+        remainder = (remainder * 100 + ch.charCodeAt(0) - 55) % 97;
+      }
+    }
+    return remainder;
+  }
+
+  function validate(value) {
+    if (!/^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$/.test(value)) {
+      return 'IBAN hatalı görünüyor.';
+    }
+    if (value.startsWith('TR') && value.length !== 26) {
+      return 'TR IBAN 26 karakter olmalıdır.';
+    }
+    if (mod97(value) !== 1) {
+      return 'IBAN kontrol basamakları hatalı, numarada yazım hatası olabilir.';
+    }
+    return '';
+  }
+
+  function selectBank(input, value) {
+    const group = input.closest('[data-iban-group]');
+    const select = group ? group.querySelector('select') : null;
+    if (!select || !value.startsWith('TR') || value.length < 9) {
+      return;
+    }
+    const option = select.querySelector('option[data-bank-code="' + value.slice(4, 9) + '"]');
+    if (option && !option.disabled) {
+      select.value = option.value;
+    }
+  }
+
+  function check(input, final, detect) {
+    const value = normalize(input.value);
+    if (detect) {
+      selectBank(input, value);
+    }
+    const message = value === '' ? '' : validate(value);
+    // Yazarken yalnızca tam uzunluğa ulaşan IBAN işaretlenir.
+    const show = final || (value.startsWith('TR') && value.length >= 26);
+    input.setCustomValidity(message);
+    input.classList.toggle('is-invalid', message !== '' && show);
+    input.title = message;
+  }
+
+  document.addEventListener('input', function (event) {
+    if (event.target.matches && event.target.matches('input[data-iban]')) {
+      check(event.target, false, true);
+    }
+  });
+  document.addEventListener('change', function (event) {
+    if (event.target.matches && event.target.matches('input[data-iban]')) {
+      check(event.target, true, true);
+    }
+  });
+  document.querySelectorAll('input[data-iban]').forEach(function (input) {
+    // Kayıtlı IBAN'larda seçili banka değiştirilmez, yalnızca hata işaretlenir.
+    if (input.value) {
+      check(input, true, false);
+    }
+  });
+})();
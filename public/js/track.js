@@ -0,0 +1,66 @@
+// Herkese açık kartvizit ve davetiye sayfalarında tıklama istatistiği.
+// Kapsayıcı: data-track-endpoint="/@slug/etkilesim" data-csrf="..."
+// Bağlantılar: data-track="social" data-track-label="Instagram"
+// Kopyalama düğmeleri: data-copy="TR00 ..." (data-track ile birlikte)
+(function () {
+  const root = document.querySelector('[data-track-endpoint]');
+  if (!root) {
+    return;
+  }
+  const endpoint = root.getAttribute('data-track-endpoint');
+  const csrf = root.getAttribute('data-csrf') || '';
+
+  function send(kind, label) {
+    const data = new FormData();
+    data.append('kind', kind);
+    data.append('label', label || '');
+    if (csrf) {
+      data.append('csrf_token', csrf);
+    }
+    if (navigator.sendBeacon) {
+      navigator.sendBeacon(endpoint, data);
+    } else {
+      fetch(endpoint, { method: 'POST', body: data, keepalive: true, credentials: 'same-origin' });
+    }
+  }
+
+  function copy(text, button) {
+    const done = function () {
+      const original = button.getAttribute('data-copy-text') || button.textContent;
+      button.setAttribute('data-copy-text', original);
+      button.textContent = 'Kopyalandı';
+      setTimeout(function () { button.textContent = original; }, 2000);
+    };
+    if (navigator.clipboard && window.isSecureContext) {
+      navigator.clipboard.writeText(text).then(done);
+      return;
+    }
+    const area = document.createElement('textarea');
+    area.value = text;
+    area.setAttribute('readonly', '');
+    area.style.position = 'absolute';
+    area.style.left = '-9999px';
+    document.body.appendChild(area);
+    area.select();
+    try {
+      document.execCommand('copy');
+      done();
+    } finally {
+      document.body.removeChild(area);
+    }
+  }
+
+  root.addEventListener('click', function (event) {
+    const el = event.target.closest('[data-track], [data-copy]');
+    if (!el || !root.contains(el)) {
+      return;
+    }
+    if (el.hasAttribute('data-copy')) {
+      event.preventDefault();
+      copy(el.getAttribute('data-copy'), el);
+    }
+    if (el.hasAttribute('data-track')) {
+      send(el.getAttribute('data-track'), el.getAttribute('data-track-label'));
+    }
+  });
+})();
@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IAccountRepository interface {
+	FindCardByUser(userID uint) (*models.Card, error)
+	ListInvitationsByUser(userID uint) ([]models.Invitation, error)
+	ListDueDeletions(now time.Time) ([]models.User, error)
+	PurgeUser(ctx context.Context, user *models.User) error
+}
+
+type AccountRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountRepository() IAccountRepository {
+	return &AccountRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *AccountRepository) FindCardByUser(userID uint) (*models.Card, error) {
+	var card models.Card
+	err := r.db.
+		Preload("CardBanks.Bank").
+		Preload("CardSocialMedia.SocialMedia").
+		Where("user_id = ?", userID).
+		First(&card).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			logconfig.Log.Error("Kullanıcı kartı sorgulama hatası", zap.Uint("user_id", userID), zap.Error(err))
+		}
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *AccountRepository) ListInvitationsByUser(userID uint) ([]models.Invitation, error) {
+	var invitations []models.Invitation
+	err := r.db.
+		Preload("Category").
+		Preload("InvitationDetail").
+		Preload("Participants.EventResponses").
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order, id")
+		}).
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_at, sort_order, id")
+		}).
+		Preload("GuestbookEntries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at, id")
+		}).
+		Preload("Media", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order, id")
+		}).
+		Preload("GiftAccounts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order, id")
+		}).
+		Preload("GiftAccounts.Bank").
+		Preload("GiftNotes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at, id")
+		}).
+		Preload("GiftNotes.GiftAccount", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Preload("GiftNotes.GiftAccount.Bank").
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&invitations).Error
+	if err != nil {
+		logconfig.Log.Error("Kullanıcı davetiyeleri sorgulama hatası", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *AccountRepository) ListDueDeletions(now time.Time) ([]models.User, error) {
+	var users []models.User
+	err := r.db.
+		Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", now).
+		Find(&users).Error
+	if err != nil {
+		logconfig.Log.Error("Silinecek hesaplar sorgulama hatası", zap.Error(err))
+		return nil, err
+	}
+	return users, nil
+}
+
+// PurgeUser, kullanıcının kişisel verilerini tek işlemde siler. Davetiyeler,
+// katılımcılar ve kartvizitler bütün alt kayıtlarıyla kalıcı silinir; yumuşak
+// silinen satırlarda isim ve telefon gibi veriler kalacağından Unscoped
+// kullanılır. Kullanıcı satırı, e-posta benzersizliği korunacak şekilde
+// anonimleştirilip yumuşak silinir.
+func (r *AccountRepository) PurgeUser(ctx context.Context, user *models.User) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invitationIDs := tx.Unscoped().Model(&models.Invitation{}).Select("id").Where("user_id = ?", user.ID)
+		cardIDs := tx.Unscoped().Model(&models.Card{}).Select("id").Where("user_id = ?", user.ID)
+
+		// Ziyaret sayaçları davetiye ve kart ID'lerine bağlıdır; kayıtlar
+		// silinmeden önce temizlenmelidir.
+		for _, model := range []interface{}{&models.VisitCounter{}, &models.VisitUnique{}} {
+			if err := tx.Where("(target_type = ? AND target_id IN (?)) OR (target_type = ? AND target_id IN (?))",
+				models.VisitTargetInvitation, invitationIDs, models.VisitTargetCard, cardIDs).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		eventIDs := tx.Unscoped().Model(&models.InvitationEvent{}).Select("id").Where("invitation_id IN (?)", invitationIDs)
+		if err := tx.Where("event_id IN (?)", eventIDs).Delete(&models.InvitationEventResponse{}).Error; err != nil {
+			return err
+		}
+		// Galeri fotoğrafları diskten de silindiği için satırlar kalıcı silinir.
+		// Hediye hesaplarındaki IBAN'lar, misafir notları ve anı defteri
+		// mesajları da kişisel veridir.
+		for _, model := range []interface{}{
+			&models.InvitationParticipant{},
+			&models.InvitationQuestion{},
+			&models.InvitationEvent{},
+			&models.InvitationDetail{},
+			&models.InvitationMedia{},
+			&models.InvitationGiftNote{},
+			&models.InvitationGiftAccount{},
+			&models.InvitationGuestbookEntry{},
+		} {
+			if err := tx.Unscoped().Where("invitation_id IN (?)", invitationIDs).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&models.Invitation{}).Error; err != nil {
+			return err
+		}
+
+		// Kartvizit ve IBAN bilgileri doğrudan kişisel veridir; kalıcı silinir.
+		if err := tx.Unscoped().Where("card_id IN (?)", cardIDs).Delete(&models.CardBank{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("card_id IN (?)", cardIDs).Delete(&models.CardSocialMedia{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&models.Card{}).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&models.UserToken{},
+			&models.UserRecoveryCode{},
+			&models.UserSession{},
+			&models.RememberToken{},
+			&models.PasswordHistory{},
+		} {
+			if err := tx.Where("user_id = ?", user.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		// E-posta ile anahtarlanan deneme sayaçları, normalize edilmiş adresi
+		// tanımlayıcı olarak saklar.
+		if err := tx.Where("scope IN ? AND identifier = ?", []models.AuthThrottleScope{
+			models.ThrottleScopeLoginEmail,
+			models.ThrottleScopeTwoFactor,
+			models.ThrottleScopePasswordReset,
+			models.ThrottleScopeVerificationEmail,
+			models.ThrottleScopeEmailChange,
+		}, strings.ToLower(strings.TrimSpace(user.Email))).Delete(&models.AuthThrottle{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(user).Updates(map[string]interface{}{
+			"name":                  "Silinmiş Kullanıcı",
+			"email":                 fmt.Sprintf("silinmis-%d@silinmis.invalid", user.ID),
+			"pending_email":         "",
+			"password":              "",
+			"status":                false,
+			"email_verified":        false,
+			"provider":              "",
+			"provider_id":           "",
+			"two_factor_enabled":    false,
+			"two_factor_secret":     "",
+			"deletion_scheduled_at": nil,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(user).Error
+	})
+	if err != nil {
+		logconfig.Log.Error("Hesap verileri silme hatası", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+	return err
+}
@@ -16,8 +16,6 @@ type IAuthRepository interface {
 	FindUserByID(id uint) (*models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) error
 	CreateUser(ctx context.Context, user *models.User) error
-	FindUserByResetToken(token string) (*models.User, error)
-	FindUserByVerificationToken(token string) (*models.User, error)
 	FindByProviderAndID(provider, providerID string) (*models.User, error)
 }
 
@@ -80,22 +78,6 @@ func (r *AuthRepository) CreateUser(ctx context.Context, user *models.User) erro
 	)
 }
 
-func (r *AuthRepository) FindUserByResetToken(token string) (*models.User, error) {
-	return r.findUser(
-		r.db.Where("reset_token = ?", token),
-		"Kullanıcı sorgulama (reset token)",
-		zap.String("reset_token", token),
-	)
-}
-
-func (r *AuthRepository) FindUserByVerificationToken(token string) (*models.User, error) {
-	return r.findUser(
-		r.db.Where("verification_token = ?", token),
-		"Kullanıcı sorgulama (verification token)",
-		zap.String("verification_token", token),
-	)
-}
-
 func (r *AuthRepository) FindByProviderAndID(provider, providerID string) (*models.User, error) {
 	return r.findUser(
 		r.db.Where("provider = ? AND provider_id = ?", provider, providerID),
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IAuthThrottleRepository interface {
+	Find(scope models.AuthThrottleScope, identifier string) (*models.AuthThrottle, error)
+	Hit(ctx context.Context, scope models.AuthThrottleScope, identifier string, now time.Time, window time.Duration) (*models.AuthThrottle, error)
+	Lock(ctx context.Context, id uint, until time.Time) error
+	Reset(ctx context.Context, scope models.AuthThrottleScope, identifier string) error
+	FindLocked(now time.Time) ([]models.AuthThrottle, error)
+	FindByID(id uint) (*models.AuthThrottle, error)
+	DeleteByID(ctx context.Context, id uint) error
+}
+
+type AuthThrottleRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthThrottleRepository() IAuthThrottleRepository {
+	return &AuthThrottleRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *AuthThrottleRepository) Find(scope models.AuthThrottleScope, identifier string) (*models.AuthThrottle, error) {
+	var throttle models.AuthThrottle
+	err := r.db.Where("scope = ? AND identifier = ?", scope, identifier).First(&throttle).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			logconfig.Log.Error("Deneme sayacı sorgulama hatası",
+				zap.String("scope", string(scope)),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// Hit, sayacı tek sorguda artırır. Son denemenin üzerinden window kadar süre
+// geçmişse ve aktif bir kilit yoksa sayaç 1'den yeniden başlar; süresi dolmuş
+// kilit de bu sırada temizlenir.
+func (r *AuthThrottleRepository) Hit(ctx context.Context, scope models.AuthThrottleScope, identifier string, now time.Time, window time.Duration) (*models.AuthThrottle, error) {
+	const query = `
+INSERT INTO auth_throttles (scope, identifier, attempts, last_attempt_at, created_at, updated_at)
+VALUES (@scope, @identifier, 1, @now, @now, @now)
+ON CONFLICT (scope, identifier) DO UPDATE SET
+	attempts = CASE
+		WHEN auth_throttles.last_attempt_at < @window_start
+			AND (auth_throttles.locked_until IS NULL OR auth_throttles.locked_until <= @now)
+		THEN 1
+		ELSE auth_throttles.attempts + 1
+	END,
+	locked_until = CASE
+		WHEN auth_throttles.locked_until <= @now THEN NULL
+		ELSE auth_throttles.locked_until
+	END,
+	last_attempt_at = EXCLUDED.last_attempt_at,
+	updated_at = EXCLUDED.updated_at
+RETURNING *`
+
+	var throttle models.AuthThrottle
+	err := r.db.WithContext(ctx).Raw(query, map[string]interface{}{
+		"scope":        scope,
+		"identifier":   identifier,
+		"now":          now,
+		"window_start": now.Add(-window),
+	}).Scan(&throttle).Error
+	if err != nil {
+		logconfig.Log.Error("Deneme sayacı artırılamadı",
+			zap.String("scope", string(scope)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+func (r *AuthThrottleRepository) Lock(ctx context.Context, id uint, until time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.AuthThrottle{}).
+		Where("id = ?", id).
+		Update("locked_until", until).Error
+	if err != nil {
+		logconfig.Log.Error("Kilit kaydedilemedi", zap.Uint("throttle_id", id), zap.Error(err))
+	}
+	return err
+}
+
+func (r *AuthThrottleRepository) Reset(ctx context.Context, scope models.AuthThrottleScope, identifier string) error {
+	err := r.db.WithContext(ctx).
+		Where("scope = ? AND identifier = ?", scope, identifier).
+		Delete(&models.AuthThrottle{}).Error
+	if err != nil {
+		logconfig.Log.Error("Deneme sayacı sıfırlanamadı",
+			zap.String("scope", string(scope)),
+			zap.Error(err),
+		)
+	}
+	return err
+}
+
+func (r *AuthThrottleRepository) FindLocked(now time.Time) ([]models.AuthThrottle, error) {
+	var throttles []models.AuthThrottle
+	err := r.db.Where("locked_until > ?", now).Order("locked_until desc").Find(&throttles).Error
+	if err != nil {
+		logconfig.Log.Error("Kilitli kayıtlar getirilemedi", zap.Error(err))
+		return nil, err
+	}
+	return throttles, nil
+}
+
+func (r *AuthThrottleRepository) FindByID(id uint) (*models.AuthThrottle, error) {
+	var throttle models.AuthThrottle
+	if err := r.db.First(&throttle, id).Error; err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+func (r *AuthThrottleRepository) DeleteByID(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Delete(&models.AuthThrottle{}, id).Error
+	if err != nil {
+		logconfig.Log.Error("Kilit kaldırılamadı", zap.Uint("throttle_id", id), zap.Error(err))
+	}
+	return err
+}
+
+var _ IAuthThrottleRepository = (*AuthThrottleRepository)(nil)
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"zatrano/configs/databaseconfig"
 	"zatrano/models"
@@ -13,6 +14,7 @@ import (
 type IBankRepository interface {
 	GetAllBanks(params queryparams.ListParams) ([]models.Bank, int64, error)
 	GetBankByID(id uint) (*models.Bank, error)
+	GetBankByCode(code string) (*models.Bank, error)
 	CreateBank(ctx context.Context, bank *models.Bank) error
 	BulkCreateBanks(ctx context.Context, banks []models.Bank) error
 	UpdateBank(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
@@ -29,7 +31,7 @@ type BankRepository struct {
 
 func NewBankRepository() IBankRepository {
 	base := NewBaseRepository[models.Bank](databaseconfig.GetDB())
-	base.SetAllowedSortColumns([]string{"id", "name", "is_active", "created_at"})
+	base.SetAllowedSortColumns([]string{"id", "name", "code", "is_active", "created_at"})
 	return &BankRepository{base: base, db: databaseconfig.GetDB()}
 }
 
@@ -41,6 +43,20 @@ func (r *BankRepository) GetBankByID(id uint) (*models.Bank, error) {
 	return r.base.GetByID(id)
 }
 
+// GetBankByCode, EFT banka koduna sahip bankayı döndürür; aynı kodu taşıyan
+// birden fazla kayıt varsa aktif olan tercih edilir.
+func (r *BankRepository) GetBankByCode(code string) (*models.Bank, error) {
+	var bank models.Bank
+	err := r.db.Where("code = ?", code).Order("is_active DESC, id").First(&bank).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &bank, nil
+}
+
 func (r *BankRepository) CreateBank(ctx context.Context, bank *models.Bank) error {
 	return r.base.Create(ctx, bank)
 }
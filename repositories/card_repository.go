@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"zatrano/configs/databaseconfig"
 	"zatrano/models"
@@ -18,6 +19,7 @@ type ICardRepository interface {
 	DeleteCardWithRelations(ctx context.Context, id uint) error
 	GetCardCount() (int64, error)
 	IsSlugAvailable(slug string, excludeID uint) (bool, error)
+	GetActiveCardBySlug(ctx context.Context, slug string) (*models.Card, error)
 }
 
 type CardRepository struct {
@@ -114,3 +116,19 @@ func (r *CardRepository) IsSlugAvailable(slug string, excludeID uint) (bool, err
 	}
 	return count == 0, nil
 }
+
+func (r *CardRepository) GetActiveCardBySlug(ctx context.Context, slug string) (*models.Card, error) {
+	var card models.Card
+	err := r.db.WithContext(ctx).
+		Preload("CardSocialMedia.SocialMedia").
+		Preload("CardBanks.Bank").
+		Where("slug = ? AND is_active = ?", slug, true).
+		First(&card).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &card, nil
+}
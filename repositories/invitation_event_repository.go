@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IInvitationEventRepository interface {
+	ListByInvitation(ctx context.Context, invitationID uint) ([]models.InvitationEvent, error)
+	CountByInvitation(ctx context.Context, invitationID uint) (int64, error)
+	FindInInvitation(ctx context.Context, invitationID, eventID uint) (*models.InvitationEvent, error)
+	Create(ctx context.Context, event *models.InvitationEvent) error
+	Update(ctx context.Context, event *models.InvitationEvent) error
+	Delete(ctx context.Context, invitationID, eventID uint) error
+	CountAttending(ctx context.Context, invitationID uint) (map[uint]EventAttendance, error)
+}
+
+// EventAttendance, bir etkinlik için katılacağını bildiren yanıt ve kişi
+// sayısıdır.
+type EventAttendance struct {
+	Participants int64
+	Guests       int64
+}
+
+type InvitationEventRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationEventRepository() IInvitationEventRepository {
+	return &InvitationEventRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *InvitationEventRepository) ListByInvitation(ctx context.Context, invitationID uint) ([]models.InvitationEvent, error) {
+	var events []models.InvitationEvent
+	err := r.db.WithContext(ctx).
+		Where("invitation_id = ?", invitationID).
+		Order("start_at, sort_order, id").
+		Find(&events).Error
+	return events, err
+}
+
+func (r *InvitationEventRepository) CountByInvitation(ctx context.Context, invitationID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.InvitationEvent{}).
+		Where("invitation_id = ?", invitationID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *InvitationEventRepository) FindInInvitation(ctx context.Context, invitationID, eventID uint) (*models.InvitationEvent, error) {
+	var event models.InvitationEvent
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", eventID, invitationID).
+		First(&event).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *InvitationEventRepository) Create(ctx context.Context, event *models.InvitationEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *InvitationEventRepository) Update(ctx context.Context, event *models.InvitationEvent) error {
+	return r.db.WithContext(ctx).Model(event).
+		Select("name", "venue", "address", "latitude", "longitude", "start_at", "end_at", "rsvp_enabled", "sort_order").
+		Updates(event).Error
+}
+
+func (r *InvitationEventRepository) Delete(ctx context.Context, invitationID, eventID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", eventID, invitationID).
+		Delete(&models.InvitationEvent{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CountAttending, katılıyor durumundaki katılımcıların etkinlik bazında
+// katılım sayılarını döndürür.
+func (r *InvitationEventRepository) CountAttending(ctx context.Context, invitationID uint) (map[uint]EventAttendance, error) {
+	var rows []struct {
+		EventID      uint
+		Participants int64
+		Guests       int64
+	}
+	err := r.db.WithContext(ctx).Table("invitation_event_responses AS r").
+		Select("r.event_id, COUNT(*) AS participants, COALESCE(SUM(p.guest_count), 0) AS guests").
+		Joins("JOIN invitation_participants AS p ON p.id = r.participant_id").
+		Where("p.invitation_id = ? AND r.attending AND p.status = ? AND p.deleted_at IS NULL", invitationID, models.ParticipantAccepted).
+		Group("r.event_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]EventAttendance, len(rows))
+	for _, row := range rows {
+		counts[row.EventID] = EventAttendance{Participants: row.Participants, Guests: row.Guests}
+	}
+	return counts, nil
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IInvitationGiftRepository interface {
+	ListAccounts(ctx context.Context, invitationID uint) ([]models.InvitationGiftAccount, error)
+	CountAccounts(ctx context.Context, invitationID uint) (int64, error)
+	FindAccount(ctx context.Context, invitationID, accountID uint) (*models.InvitationGiftAccount, error)
+	CreateAccount(ctx context.Context, account *models.InvitationGiftAccount) error
+	UpdateAccount(ctx context.Context, account *models.InvitationGiftAccount) error
+	DeleteAccount(ctx context.Context, invitationID, accountID uint) error
+	ListNotes(ctx context.Context, invitationID uint) ([]models.InvitationGiftNote, error)
+	CreateNote(ctx context.Context, note *models.InvitationGiftNote) error
+	DeleteNote(ctx context.Context, invitationID, noteID uint) error
+}
+
+type InvitationGiftRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationGiftRepository() IInvitationGiftRepository {
+	return &InvitationGiftRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *InvitationGiftRepository) ListAccounts(ctx context.Context, invitationID uint) ([]models.InvitationGiftAccount, error) {
+	var accounts []models.InvitationGiftAccount
+	err := r.db.WithContext(ctx).
+		Preload("Bank").
+		Where("invitation_id = ?", invitationID).
+		Order("sort_order, id").
+		Find(&accounts).Error
+	return accounts, err
+}
+
+func (r *InvitationGiftRepository) CountAccounts(ctx context.Context, invitationID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.InvitationGiftAccount{}).
+		Where("invitation_id = ?", invitationID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *InvitationGiftRepository) FindAccount(ctx context.Context, invitationID, accountID uint) (*models.InvitationGiftAccount, error) {
+	var account models.InvitationGiftAccount
+	err := r.db.WithContext(ctx).
+		Preload("Bank").
+		Where("id = ? AND invitation_id = ?", accountID, invitationID).
+		First(&account).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *InvitationGiftRepository) CreateAccount(ctx context.Context, account *models.InvitationGiftAccount) error {
+	return r.db.WithContext(ctx).Omit("Invitation", "Bank").Create(account).Error
+}
+
+func (r *InvitationGiftRepository) UpdateAccount(ctx context.Context, account *models.InvitationGiftAccount) error {
+	return r.db.WithContext(ctx).Model(account).
+		Select("bank_id", "iban", "account_holder", "description", "sort_order").
+		Updates(account).Error
+}
+
+func (r *InvitationGiftRepository) DeleteAccount(ctx context.Context, invitationID, accountID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", accountID, invitationID).
+		Delete(&models.InvitationGiftAccount{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListNotes, notları en yeniden eskiye döndürür. Silinmiş hesaplara bırakılan
+// notlar da hesap bilgisiyle birlikte listelenir.
+func (r *InvitationGiftRepository) ListNotes(ctx context.Context, invitationID uint) ([]models.InvitationGiftNote, error) {
+	var notes []models.InvitationGiftNote
+	err := r.db.WithContext(ctx).
+		Preload("GiftAccount", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Preload("GiftAccount.Bank").
+		Preload("Participant").
+		Where("invitation_id = ?", invitationID).
+		Order("created_at DESC, id DESC").
+		Find(&notes).Error
+	return notes, err
+}
+
+func (r *InvitationGiftRepository) CreateNote(ctx context.Context, note *models.InvitationGiftNote) error {
+	return r.db.WithContext(ctx).Omit("Invitation", "Participant", "GiftAccount").Create(note).Error
+}
+
+func (r *InvitationGiftRepository) DeleteNote(ctx context.Context, invitationID, noteID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", noteID, invitationID).
+		Delete(&models.InvitationGiftNote{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IInvitationGuestbookRepository interface {
+	ListByInvitation(ctx context.Context, invitationID uint, status models.GuestbookStatus) ([]models.InvitationGuestbookEntry, error)
+	ListApproved(ctx context.Context, invitationID uint, limit int) ([]models.InvitationGuestbookEntry, error)
+	CountByStatus(ctx context.Context, invitationID uint) (map[models.GuestbookStatus]int64, error)
+	Create(ctx context.Context, entry *models.InvitationGuestbookEntry) error
+	SetStatus(ctx context.Context, invitationID, entryID uint, status models.GuestbookStatus, at time.Time) error
+	Delete(ctx context.Context, invitationID, entryID uint) error
+}
+
+type InvitationGuestbookRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationGuestbookRepository() IInvitationGuestbookRepository {
+	return &InvitationGuestbookRepository{db: databaseconfig.GetDB()}
+}
+
+// ListByInvitation, mesajları en yeniden eskiye döndürür; status boşsa tümü
+// listelenir.
+func (r *InvitationGuestbookRepository) ListByInvitation(ctx context.Context, invitationID uint, status models.GuestbookStatus) ([]models.InvitationGuestbookEntry, error) {
+	var entries []models.InvitationGuestbookEntry
+	query := r.db.WithContext(ctx).Where("invitation_id = ?", invitationID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC, id DESC").Find(&entries).Error
+	return entries, err
+}
+
+func (r *InvitationGuestbookRepository) ListApproved(ctx context.Context, invitationID uint, limit int) ([]models.InvitationGuestbookEntry, error) {
+	var entries []models.InvitationGuestbookEntry
+	err := r.db.WithContext(ctx).
+		Where("invitation_id = ? AND status = ?", invitationID, models.GuestbookApproved).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *InvitationGuestbookRepository) CountByStatus(ctx context.Context, invitationID uint) (map[models.GuestbookStatus]int64, error) {
+	var rows []struct {
+		Status models.GuestbookStatus
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.InvitationGuestbookEntry{}).
+		Select("status, COUNT(*) AS count").
+		Where("invitation_id = ?", invitationID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[models.GuestbookStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *InvitationGuestbookRepository) Create(ctx context.Context, entry *models.InvitationGuestbookEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// SetStatus, mesajın durumunu değiştirir; onay zamanı yalnızca ilk onayda
+// yazılır.
+func (r *InvitationGuestbookRepository) SetStatus(ctx context.Context, invitationID, entryID uint, status models.GuestbookStatus, at time.Time) error {
+	updates := map[string]interface{}{"status": status}
+	if status == models.GuestbookApproved {
+		updates["approved_at"] = gorm.Expr("COALESCE(approved_at, ?)", at)
+	}
+	result := r.db.WithContext(ctx).Model(&models.InvitationGuestbookEntry{}).
+		Where("id = ? AND invitation_id = ?", entryID, invitationID).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *InvitationGuestbookRepository) Delete(ctx context.Context, invitationID, entryID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", entryID, invitationID).
+		Delete(&models.InvitationGuestbookEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrMediaQuotaExceeded, yeni fotoğraflar davetiyenin galeri kotasını
+// aştığında döner.
+var ErrMediaQuotaExceeded = errors.New("galeri kotası dolu")
+
+type IInvitationMediaRepository interface {
+	ListByInvitation(ctx context.Context, invitationID uint, status models.MediaStatus) ([]models.InvitationMedia, error)
+	ListApproved(ctx context.Context, invitationID uint) ([]models.InvitationMedia, error)
+	CountByStatus(ctx context.Context, invitationID uint) (map[models.MediaStatus]int64, error)
+	FindInInvitation(ctx context.Context, invitationID, mediaID uint) (*models.InvitationMedia, error)
+	CreateWithinQuota(ctx context.Context, invitationID uint, media []models.InvitationMedia, quota int) error
+	Update(ctx context.Context, invitationID, mediaID uint, caption string, sortOrder int) error
+	SetStatus(ctx context.Context, invitationID, mediaID uint, status models.MediaStatus) error
+	Delete(ctx context.Context, invitationID, mediaID uint) error
+}
+
+type InvitationMediaRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationMediaRepository() IInvitationMediaRepository {
+	return &InvitationMediaRepository{db: databaseconfig.GetDB()}
+}
+
+// ListByInvitation, fotoğrafları galeri sırasıyla döndürür; status boşsa
+// tümü listelenir.
+func (r *InvitationMediaRepository) ListByInvitation(ctx context.Context, invitationID uint, status models.MediaStatus) ([]models.InvitationMedia, error) {
+	var media []models.InvitationMedia
+	query := r.db.WithContext(ctx).Where("invitation_id = ?", invitationID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("sort_order ASC, id ASC").Find(&media).Error
+	return media, err
+}
+
+func (r *InvitationMediaRepository) ListApproved(ctx context.Context, invitationID uint) ([]models.InvitationMedia, error) {
+	return r.ListByInvitation(ctx, invitationID, models.MediaApproved)
+}
+
+func (r *InvitationMediaRepository) CountByStatus(ctx context.Context, invitationID uint) (map[models.MediaStatus]int64, error) {
+	var rows []struct {
+		Status models.MediaStatus
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.InvitationMedia{}).
+		Select("status, COUNT(*) AS count").
+		Where("invitation_id = ?", invitationID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[models.MediaStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *InvitationMediaRepository) FindInInvitation(ctx context.Context, invitationID, mediaID uint) (*models.InvitationMedia, error) {
+	var media models.InvitationMedia
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", mediaID, invitationID).
+		First(&media).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &media, nil
+}
+
+// CreateWithinQuota, fotoğrafları galerinin sonuna ekler. Davetiye satırı
+// işlem boyunca kilitlenir; böylece eşzamanlı yüklemeler kotayı aşamaz.
+// quota 0 ise sınır uygulanmaz.
+func (r *InvitationMediaRepository) CreateWithinQuota(ctx context.Context, invitationID uint, media []models.InvitationMedia, quota int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockInvitation(tx, invitationID); err != nil {
+			return err
+		}
+
+		var stats struct {
+			Count   int64
+			MaxSort int
+		}
+		err := tx.Model(&models.InvitationMedia{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(sort_order), 0) AS max_sort").
+			Where("invitation_id = ?", invitationID).
+			Scan(&stats).Error
+		if err != nil {
+			return err
+		}
+		if quota > 0 && stats.Count+int64(len(media)) > int64(quota) {
+			return ErrMediaQuotaExceeded
+		}
+
+		for i := range media {
+			media[i].InvitationID = invitationID
+			media[i].SortOrder = stats.MaxSort + i + 1
+		}
+		return tx.Omit("Invitation").Create(&media).Error
+	})
+}
+
+func (r *InvitationMediaRepository) Update(ctx context.Context, invitationID, mediaID uint, caption string, sortOrder int) error {
+	result := r.db.WithContext(ctx).Model(&models.InvitationMedia{}).
+		Where("id = ? AND invitation_id = ?", mediaID, invitationID).
+		Updates(map[string]interface{}{"caption": caption, "sort_order": sortOrder})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *InvitationMediaRepository) SetStatus(ctx context.Context, invitationID, mediaID uint, status models.MediaStatus) error {
+	result := r.db.WithContext(ctx).Model(&models.InvitationMedia{}).
+		Where("id = ? AND invitation_id = ?", mediaID, invitationID).
+		Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *InvitationMediaRepository) Delete(ctx context.Context, invitationID, mediaID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", mediaID, invitationID).
+		Delete(&models.InvitationMedia{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCapacityExceeded, davetiyenin kişi kapasitesi yanıtı karşılamadığında
+// döner.
+var ErrCapacityExceeded = errors.New("davetiye kapasitesi dolu")
+
+// ErrAlreadyResponded, bağlantısız yanıttaki telefon numarasıyla davetiyede
+// daha önce yanıt verilmişse döner.
+var ErrAlreadyResponded = errors.New("bu telefon numarasıyla daha önce yanıt verilmiş")
+
+// ParticipantStatusCount, bir durumdaki yanıt ve kişi sayısıdır.
+type ParticipantStatusCount struct {
+	Participants int64
+	Guests       int64
+}
+
+type IInvitationParticipantRepository interface {
+	ListByInvitation(ctx context.Context, invitationID uint, status models.ParticipantStatus) ([]models.InvitationParticipant, error)
+	CountByStatus(ctx context.Context, invitationID uint) (map[models.ParticipantStatus]ParticipantStatusCount, error)
+	AcceptedGuests(ctx context.Context, invitationID uint) (int64, error)
+	FindInInvitation(ctx context.Context, invitationID, participantID uint) (*models.InvitationParticipant, error)
+	CreateMany(ctx context.Context, participants []models.InvitationParticipant) error
+	Delete(ctx context.Context, invitationID, participantID uint) error
+	MarkOpened(ctx context.Context, participantID uint, at time.Time) error
+	SaveResponse(ctx context.Context, participant *models.InvitationParticipant) error
+	Promote(ctx context.Context, invitationID, participantID uint) error
+}
+
+type InvitationParticipantRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationParticipantRepository() IInvitationParticipantRepository {
+	return &InvitationParticipantRepository{db: databaseconfig.GetDB()}
+}
+
+// ListByInvitation, davetiyenin katılımcılarını döndürür; status boşsa tümü,
+// "invited" ise henüz bağlantıyı açmamış olanlar listelenir.
+func (r *InvitationParticipantRepository) ListByInvitation(ctx context.Context, invitationID uint, status models.ParticipantStatus) ([]models.InvitationParticipant, error) {
+	var participants []models.InvitationParticipant
+	query := r.db.WithContext(ctx).Where("invitation_id = ?", invitationID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Preload("EventResponses").Order("title").Find(&participants).Error
+	return participants, err
+}
+
+func (r *InvitationParticipantRepository) CountByStatus(ctx context.Context, invitationID uint) (map[models.ParticipantStatus]ParticipantStatusCount, error) {
+	var rows []struct {
+		Status       models.ParticipantStatus
+		Participants int64
+		Guests       int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.InvitationParticipant{}).
+		Select("status, COUNT(*) AS participants, COALESCE(SUM(guest_count), 0) AS guests").
+		Where("invitation_id = ?", invitationID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[models.ParticipantStatus]ParticipantStatusCount, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = ParticipantStatusCount{Participants: row.Participants, Guests: row.Guests}
+	}
+	return counts, nil
+}
+
+func (r *InvitationParticipantRepository) AcceptedGuests(ctx context.Context, invitationID uint) (int64, error) {
+	return acceptedGuests(r.db.WithContext(ctx), invitationID, 0)
+}
+
+// acceptedGuests, katılıyor durumundaki yanıtların toplam kişi sayısını
+// döndürür; excludeID verilirse o katılımcı hesaba katılmaz.
+func acceptedGuests(db *gorm.DB, invitationID, excludeID uint) (int64, error) {
+	var total int64
+	err := db.Model(&models.InvitationParticipant{}).
+		Select("COALESCE(SUM(guest_count), 0)").
+		Where("invitation_id = ? AND status = ? AND id <> ?", invitationID, models.ParticipantAccepted, excludeID).
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *InvitationParticipantRepository) FindInInvitation(ctx context.Context, invitationID, participantID uint) (*models.InvitationParticipant, error) {
+	var participant models.InvitationParticipant
+	err := r.db.WithContext(ctx).
+		Preload("EventResponses").
+		Where("id = ? AND invitation_id = ?", participantID, invitationID).
+		First(&participant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &participant, nil
+}
+
+func (r *InvitationParticipantRepository) CreateMany(ctx context.Context, participants []models.InvitationParticipant) error {
+	return r.db.WithContext(ctx).CreateInBatches(participants, 200).Error
+}
+
+func (r *InvitationParticipantRepository) Delete(ctx context.Context, invitationID, participantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", participantID, invitationID).
+		Delete(&models.InvitationParticipant{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// MarkOpened, açılma sayacını artırır; ilk açılış zamanı yalnızca bir kez
+// yazılır ve henüz yanıt vermemiş misafirin durumu "opened" olur.
+func (r *InvitationParticipantRepository) MarkOpened(ctx context.Context, participantID uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.InvitationParticipant{}).
+		Where("id = ?", participantID).
+		Updates(map[string]interface{}{
+			"first_opened_at": gorm.Expr("COALESCE(first_opened_at, ?)", at),
+			"last_opened_at":  at,
+			"open_count":      gorm.Expr("open_count + 1"),
+			"status":          gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END", models.ParticipantInvited, models.ParticipantOpened),
+		}).Error
+}
+
+// SaveResponse, katılım yanıtını kaydeder. Davetiye satırı işlem boyunca
+// kilitlenir (SELECT ... FOR UPDATE); böylece eşzamanlı yanıtlar kapasiteyi
+// aşamaz. Kapasite yetmezse yedek liste açıksa yanıt "waitlisted" olarak
+// kaydedilir, değilse ErrCapacityExceeded döner.
+//
+// Bağlantısız yanıtlar telefon numarasıyla eşleştirilir: numara misafir
+// listesinde yanıt vermemiş biri olarak varsa o kayıt güncellenir, daha önce
+// yanıt verilmişse ErrAlreadyResponded döner.
+func (r *InvitationParticipantRepository) SaveResponse(ctx context.Context, participant *models.InvitationParticipant) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invitation, err := lockInvitation(tx, participant.InvitationID)
+		if err != nil {
+			return err
+		}
+
+		if participant.ID == 0 && participant.PhoneNumber != "" {
+			var existing models.InvitationParticipant
+			err := tx.Select("id", "responded_at").
+				Where("invitation_id = ? AND phone_number = ?", participant.InvitationID, participant.PhoneNumber).
+				Order("id").
+				First(&existing).Error
+			switch {
+			case err == nil && existing.RespondedAt != nil:
+				return ErrAlreadyResponded
+			case err == nil:
+				participant.ID = existing.ID
+			case !errors.Is(err, gorm.ErrRecordNotFound):
+				return err
+			}
+		}
+
+		if participant.Status == models.ParticipantAccepted || participant.Status == models.ParticipantWaitlisted {
+			fits, err := hasCapacity(tx, invitation, participant.ID, participant.GuestCount)
+			if err != nil {
+				return err
+			}
+			switch {
+			case fits:
+				participant.Status = models.ParticipantAccepted
+			case invitation.WaitlistEnabled:
+				participant.Status = models.ParticipantWaitlisted
+			default:
+				return ErrCapacityExceeded
+			}
+		}
+
+		if participant.ID == 0 {
+			err = tx.Omit("EventResponses").Create(participant).Error
+		} else {
+			err = tx.Model(participant).
+				Select("title", "phone_number", "guest_count", "status", "responded_at", "answers").
+				Updates(participant).Error
+		}
+		if err != nil {
+			return err
+		}
+		return replaceEventResponses(tx, participant)
+	})
+}
+
+// replaceEventResponses, katılımcının etkinlik yanıtlarını formdan gelenlerle
+// değiştirir.
+func replaceEventResponses(tx *gorm.DB, participant *models.InvitationParticipant) error {
+	if err := tx.Where("participant_id = ?", participant.ID).Delete(&models.InvitationEventResponse{}).Error; err != nil {
+		return err
+	}
+	if len(participant.EventResponses) == 0 {
+		return nil
+	}
+	for i := range participant.EventResponses {
+		participant.EventResponses[i].ParticipantID = participant.ID
+	}
+	return tx.Omit(clause.Associations).Create(&participant.EventResponses).Error
+}
+
+// Promote, yedek listedeki katılımcıyı kapasite yetiyorsa katılıyor durumuna
+// alır.
+func (r *InvitationParticipantRepository) Promote(ctx context.Context, invitationID, participantID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invitation, err := lockInvitation(tx, invitationID)
+		if err != nil {
+			return err
+		}
+
+		var participant models.InvitationParticipant
+		err = tx.Where("id = ? AND invitation_id = ? AND status = ?", participantID, invitationID, models.ParticipantWaitlisted).
+			First(&participant).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		fits, err := hasCapacity(tx, invitation, participant.ID, participant.GuestCount)
+		if err != nil {
+			return err
+		}
+		if !fits {
+			return ErrCapacityExceeded
+		}
+		return tx.Model(&participant).Update("status", models.ParticipantAccepted).Error
+	})
+}
+
+func lockInvitation(tx *gorm.DB, invitationID uint) (*models.Invitation, error) {
+	var invitation models.Invitation
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "max_guests", "waitlist_enabled").
+		First(&invitation, invitationID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func hasCapacity(tx *gorm.DB, invitation *models.Invitation, participantID uint, guestCount int) (bool, error) {
+	if invitation.MaxGuests <= 0 {
+		return true, nil
+	}
+	used, err := acceptedGuests(tx, invitation.ID, participantID)
+	if err != nil {
+		return false, err
+	}
+	return used+int64(guestCount) <= int64(invitation.MaxGuests), nil
+}
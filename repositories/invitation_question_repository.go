@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IInvitationQuestionRepository interface {
+	ListByInvitation(ctx context.Context, invitationID uint) ([]models.InvitationQuestion, error)
+	CountByInvitation(ctx context.Context, invitationID uint) (int64, error)
+	FindInInvitation(ctx context.Context, invitationID, questionID uint) (*models.InvitationQuestion, error)
+	Create(ctx context.Context, question *models.InvitationQuestion) error
+	Update(ctx context.Context, question *models.InvitationQuestion) error
+	Delete(ctx context.Context, invitationID, questionID uint) error
+}
+
+type InvitationQuestionRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationQuestionRepository() IInvitationQuestionRepository {
+	return &InvitationQuestionRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *InvitationQuestionRepository) ListByInvitation(ctx context.Context, invitationID uint) ([]models.InvitationQuestion, error) {
+	var questions []models.InvitationQuestion
+	err := r.db.WithContext(ctx).
+		Where("invitation_id = ?", invitationID).
+		Order("sort_order, id").
+		Find(&questions).Error
+	return questions, err
+}
+
+func (r *InvitationQuestionRepository) CountByInvitation(ctx context.Context, invitationID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.InvitationQuestion{}).
+		Where("invitation_id = ?", invitationID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *InvitationQuestionRepository) FindInInvitation(ctx context.Context, invitationID, questionID uint) (*models.InvitationQuestion, error) {
+	var question models.InvitationQuestion
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", questionID, invitationID).
+		First(&question).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &question, nil
+}
+
+func (r *InvitationQuestionRepository) Create(ctx context.Context, question *models.InvitationQuestion) error {
+	return r.db.WithContext(ctx).Create(question).Error
+}
+
+func (r *InvitationQuestionRepository) Update(ctx context.Context, question *models.InvitationQuestion) error {
+	return r.db.WithContext(ctx).Model(question).
+		Select("label", "type", "options", "is_required", "sort_order").
+		Updates(question).Error
+}
+
+func (r *InvitationQuestionRepository) Delete(ctx context.Context, invitationID, questionID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND invitation_id = ?", questionID, invitationID).
+		Delete(&models.InvitationQuestion{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"errors"
+	"time"
+
 	"zatrano/configs/databaseconfig"
 	"zatrano/models"
 	"zatrano/pkg/queryparams"
@@ -13,12 +15,18 @@ import (
 type IInvitationRepository interface {
 	GetAllInvitations(params queryparams.ListParams) ([]models.Invitation, int64, error)
 	GetInvitationByID(id uint) (*models.Invitation, error)
+	GetInvitationByIDForUser(id, userID uint) (*models.Invitation, error)
 	GetByInvitationKey(ctx context.Context, key string) (*models.Invitation, error) // YENİ METOT
 	CreateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error
 	UpdateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error
 	DeleteInvitationWithRelations(ctx context.Context, id uint) error
 	GetInvitationCount() (int64, error)
 	KeyExists(ctx context.Context, key string) (bool, error)
+	ArchiveEndedBefore(ctx context.Context, cutoff, archivedAt time.Time) (int64, error)
+	SetGuestbookEnabled(ctx context.Context, id uint, enabled bool) error
+	SetGallerySettings(ctx context.Context, id uint, guestUploads bool, uploadCode string) error
+	SetGiftNotesEnabled(ctx context.Context, id uint, enabled bool) error
+	SetVisibility(ctx context.Context, id uint, visibility models.InvitationVisibility, pinHash string) error
 }
 
 type InvitationRepository struct {
@@ -29,8 +37,8 @@ type InvitationRepository struct {
 func NewInvitationRepository() IInvitationRepository {
 	db := databaseconfig.GetDB()
 	base := NewBaseRepository[models.Invitation](db)
-	base.SetAllowedSortColumns([]string{"id", "title", "type", "date", "created_at"})
-	base.SetPreloads("InvitationDetail")
+	base.SetAllowedSortColumns([]string{"id", "title", "type", "event_at", "created_at"})
+	base.SetPreloads("InvitationDetail", "Category")
 	return &InvitationRepository{
 		base: base,
 		db:   db,
@@ -45,6 +53,21 @@ func (r *InvitationRepository) GetInvitationByID(id uint) (*models.Invitation, e
 	return r.base.GetByID(id)
 }
 
+// GetInvitationByIDForUser, davetiyeyi yalnızca verilen kullanıcıya aitse
+// döndürür; aksi halde ErrNotFound döner.
+func (r *InvitationRepository) GetInvitationByIDForUser(id, userID uint) (*models.Invitation, error) {
+	var result models.Invitation
+	query := r.db
+	for _, preload := range r.base.(*BaseRepository[models.Invitation]).preloads {
+		query = query.Preload(preload)
+	}
+	err := query.Where("user_id = ?", userID).First(&result, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	return &result, err
+}
+
 func (r *InvitationRepository) GetByInvitationKey(ctx context.Context, key string) (*models.Invitation, error) {
 	var result models.Invitation
 	query := r.db.WithContext(ctx)
@@ -52,6 +75,17 @@ func (r *InvitationRepository) GetByInvitationKey(ctx context.Context, key strin
 		query = query.Preload(preload)
 	}
 
+	// Katılım formu soruları sırasıyla gösterilir.
+	query = query.Preload("Questions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sort_order, id")
+	})
+	query = query.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("start_at, sort_order, id")
+	})
+	query = query.Preload("GiftAccounts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sort_order, id")
+	}).Preload("GiftAccounts.Bank")
+
 	err := query.Where("invitation_key = ?", key).First(&result).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -86,3 +120,77 @@ func (r *InvitationRepository) KeyExists(ctx context.Context, key string) (bool,
 	}
 	return count > 0, nil
 }
+
+// ArchiveEndedBefore, etkinlik zamanı cutoff'tan önce olan ve henüz
+// arşivlenmemiş davetiyeleri arşivler.
+func (r *InvitationRepository) ArchiveEndedBefore(ctx context.Context, cutoff, archivedAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Invitation{}).
+		Where("archived_at IS NULL AND event_at > ? AND event_at < ?", time.Time{}, cutoff).
+		Update("archived_at", archivedAt)
+	return result.RowsAffected, result.Error
+}
+
+func (r *InvitationRepository) SetGuestbookEnabled(ctx context.Context, id uint, enabled bool) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Invitation{}).
+		Where("id = ?", id).
+		Update("guestbook_enabled", enabled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *InvitationRepository) SetGallerySettings(ctx context.Context, id uint, guestUploads bool, uploadCode string) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Invitation{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"gallery_guest_uploads": guestUploads,
+			"gallery_upload_code":   uploadCode,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetVisibility, görünürlüğü ve şifre özetini birlikte yazar; şifresiz
+// modlarda özet boş gönderilir.
+func (r *InvitationRepository) SetVisibility(ctx context.Context, id uint, visibility models.InvitationVisibility, pinHash string) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Invitation{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"visibility":      visibility,
+			"access_pin_hash": pinHash,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *InvitationRepository) SetGiftNotesEnabled(ctx context.Context, id uint, enabled bool) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Invitation{}).
+		Where("id = ?", id).
+		Update("gift_notes_enabled", enabled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
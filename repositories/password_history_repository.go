@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IPasswordHistoryRepository interface {
+	Create(ctx context.Context, entry *models.PasswordHistory) error
+	ListRecent(userID uint, limit int) ([]models.PasswordHistory, error)
+	Prune(ctx context.Context, userID uint, keep int) error
+}
+
+type PasswordHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordHistoryRepository() IPasswordHistoryRepository {
+	return &PasswordHistoryRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *PasswordHistoryRepository) Create(ctx context.Context, entry *models.PasswordHistory) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		logconfig.Log.Error("Şifre geçmişi kaydetme hatası", zap.Uint("user_id", entry.UserID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *PasswordHistoryRepository) ListRecent(userID uint, limit int) ([]models.PasswordHistory, error) {
+	var entries []models.PasswordHistory
+	err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&entries).Error
+	if err != nil {
+		logconfig.Log.Error("Şifre geçmişi sorgulama hatası", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return entries, err
+}
+
+// Prune, kullanıcının en yeni keep kaydı dışındaki şifre geçmişini siler.
+func (r *PasswordHistoryRepository) Prune(ctx context.Context, userID uint, keep int) error {
+	keepIDs := r.db.Model(&models.PasswordHistory{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(keep)
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND id NOT IN (?)", userID, keepIDs).
+		Delete(&models.PasswordHistory{}).Error
+	if err != nil {
+		logconfig.Log.Warn("Şifre geçmişi temizlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return err
+}
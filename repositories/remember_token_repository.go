@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IRememberTokenRepository interface {
+	Create(ctx context.Context, token *models.RememberToken) error
+	FindBySelector(selector string) (*models.RememberToken, error)
+	MarkRotated(ctx context.Context, id uint, rotatedAt time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	RevokeByUser(ctx context.Context, userID uint, exceptFamilyID string, revokedAt time.Time) error
+	DeleteExpired(before time.Time) error
+}
+
+type RememberTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRememberTokenRepository() IRememberTokenRepository {
+	return &RememberTokenRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *RememberTokenRepository) Create(ctx context.Context, token *models.RememberToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		logconfig.Log.Error("Hatırlama tokenı oluşturma hatası",
+			zap.Uint("user_id", token.UserID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *RememberTokenRepository) FindBySelector(selector string) (*models.RememberToken, error) {
+	var token models.RememberToken
+	if err := r.db.Where("selector = ?", selector).First(&token).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			logconfig.Log.Error("Hatırlama tokenı sorgulama hatası", zap.Error(err))
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRotated, tokenı yalnızca henüz döndürülmemişse işaretler; eşzamanlı iki
+// istekten sadece biri yeni token alır.
+func (r *RememberTokenRepository) MarkRotated(ctx context.Context, id uint, rotatedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.RememberToken{}).
+		Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+		Update("rotated_at", rotatedAt)
+	if result.Error != nil {
+		logconfig.Log.Error("Hatırlama tokenı güncelleme hatası", zap.Uint("token_id", id), zap.Error(result.Error))
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *RememberTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RememberToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+	if err != nil {
+		logconfig.Log.Error("Hatırlama token ailesi iptal hatası", zap.String("family_id", familyID), zap.Error(err))
+	}
+	return err
+}
+
+func (r *RememberTokenRepository) RevokeByUser(ctx context.Context, userID uint, exceptFamilyID string, revokedAt time.Time) error {
+	query := r.db.WithContext(ctx).
+		Model(&models.RememberToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID)
+	if exceptFamilyID != "" {
+		query = query.Where("family_id <> ?", exceptFamilyID)
+	}
+	if err := query.Update("revoked_at", revokedAt).Error; err != nil {
+		logconfig.Log.Error("Kullanıcı hatırlama tokenları iptal hatası", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *RememberTokenRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&models.RememberToken{}).Error
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IReminderRepository interface {
+	ListDueInvitations(now time.Time, maxAttempts int) ([]models.Invitation, error)
+	MarkSent(ctx context.Context, participantID uint, sentAt time.Time) (bool, error)
+	RecordFailure(ctx context.Context, participantID uint, retryAt time.Time) error
+}
+
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository() IReminderRepository {
+	return &ReminderRepository{db: databaseconfig.GetDB()}
+}
+
+// ListDueInvitations, hatırlatma penceresine girmiş ve etkinliği henüz
+// başlamamış davetiyeleri, katılacağını bildiren, hatırlatma gönderilmemiş ve
+// deneme hakkı bitmemiş katılımcılarıyla döndürür. Koşullar
+// models.Invitation.ReminderDueAt ile aynıdır.
+func (r *ReminderRepository) ListDueInvitations(now time.Time, maxAttempts int) ([]models.Invitation, error) {
+	var invitations []models.Invitation
+	err := r.db.
+		Preload("Category").
+		Preload("InvitationDetail").
+		Preload("Participants",
+			"reminder_sent_at IS NULL AND phone_number <> '' AND status = ? AND reminder_attempts < ? AND (reminder_retry_at IS NULL OR reminder_retry_at <= ?)",
+			models.ParticipantAccepted, maxAttempts, now).
+		Where("reminder_enabled = ? AND archived_at IS NULL", true).
+		Where("event_at > ?", now).
+		Where("event_at - make_interval(hours => reminder_lead_hours) <= ?", now).
+		Where("expire_at IS NULL OR expire_at > ?", now).
+		Find(&invitations).Error
+	if err != nil {
+		logconfig.Log.Error("Hatırlatma bekleyen davetiyeler sorgulama hatası", zap.Error(err))
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// MarkSent, katılımcıyı yalnızca daha önce işaretlenmemişse işaretler; birden
+// fazla uygulama örneği çalışsa da hatırlatma bir kez gönderilir.
+func (r *ReminderRepository) MarkSent(ctx context.Context, participantID uint, sentAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.InvitationParticipant{}).
+		Where("id = ? AND reminder_sent_at IS NULL", participantID).
+		Update("reminder_sent_at", sentAt)
+	if result.Error != nil {
+		logconfig.Log.Error("Hatırlatma durumu güncellenemedi", zap.Uint("participant_id", participantID), zap.Error(result.Error))
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RecordFailure, başarısız gönderimden sonra işareti kaldırır, deneme sayısını
+// artırır ve bir sonraki denemeyi retryAt zamanına erteler.
+func (r *ReminderRepository) RecordFailure(ctx context.Context, participantID uint, retryAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.InvitationParticipant{}).
+		Where("id = ?", participantID).
+		Updates(map[string]interface{}{
+			"reminder_sent_at":  nil,
+			"reminder_attempts": gorm.Expr("reminder_attempts + 1"),
+			"reminder_retry_at": retryAt,
+		}).Error
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ITwoFactorRepository interface {
+	Enable(ctx context.Context, userID uint, secret string, step int64, codeHashes []string) error
+	Disable(ctx context.Context, userID uint) error
+	AdvanceLastStep(ctx context.Context, userID uint, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error
+	ConsumeRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) (bool, error)
+	CountRecoveryCodes(userID uint) (int64, error)
+}
+
+type TwoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository() ITwoFactorRepository {
+	return &TwoFactorRepository{db: databaseconfig.GetDB()}
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codeHashes []string) error {
+	if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	codes := make([]models.UserRecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, models.UserRecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	return tx.Create(&codes).Error
+}
+
+func (r *TwoFactorRepository) Enable(ctx context.Context, userID uint, secret string, step int64, codeHashes []string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"two_factor_enabled":   true,
+			"two_factor_secret":    secret,
+			"two_factor_last_step": step,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+	if err != nil {
+		logconfig.Log.Error("İki adımlı doğrulama etkinleştirilemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return err
+}
+
+func (r *TwoFactorRepository) Disable(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"two_factor_enabled":   false,
+			"two_factor_secret":    "",
+			"two_factor_last_step": 0,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, nil)
+	})
+	if err != nil {
+		logconfig.Log.Error("İki adımlı doğrulama kapatılamadı", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return err
+}
+
+// AdvanceLastStep, son kullanılan TOTP adımını yalnızca ileri taşır. Aynı kodun
+// (ya da daha eski bir kodun) ikinci kez kabul edilmesini engeller.
+func (r *TwoFactorRepository) AdvanceLastStep(ctx context.Context, userID uint, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	if result.Error != nil {
+		logconfig.Log.Error("TOTP adımı güncellenemedi", zap.Uint("user_id", userID), zap.Error(result.Error))
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+	if err != nil {
+		logconfig.Log.Error("Kurtarma kodları yenilenemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return err
+}
+
+func (r *TwoFactorRepository) ConsumeRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		logconfig.Log.Error("Kurtarma kodu kullanılamadı", zap.Uint("user_id", userID), zap.Error(result.Error))
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *TwoFactorRepository) CountRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+var _ ITwoFactorRepository = (*TwoFactorRepository)(nil)
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IUserSessionRepository interface {
+	Upsert(ctx context.Context, session *models.UserSession) error
+	Touch(sessionID, ip, userAgent string, now, staleBefore time.Time) error
+	ListByUser(userID uint, activeAfter time.Time) ([]models.UserSession, error)
+	DeleteBySessionID(sessionID string) error
+	DeleteByUser(ctx context.Context, userID uint, exceptSessionID string) ([]string, error)
+	DeleteInactive(before time.Time) error
+}
+
+type UserSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepository() IUserSessionRepository {
+	return &UserSessionRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *UserSessionRepository) Upsert(ctx context.Context, session *models.UserSession) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "session_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "ip_address", "user_agent", "last_seen_at", "updated_at"}),
+	}).Create(session).Error
+	if err != nil {
+		logconfig.Log.Error("Kullanıcı oturumu kaydetme hatası",
+			zap.Uint("user_id", session.UserID),
+			zap.Error(err),
+		)
+	}
+	return err
+}
+
+// Touch, son görülme zamanını yalnızca kayıt staleBefore'dan eskiyse
+// günceller; her istekte yazma yapılmasını önler.
+func (r *UserSessionRepository) Touch(sessionID, ip, userAgent string, now, staleBefore time.Time) error {
+	err := r.db.Model(&models.UserSession{}).
+		Where("session_id = ? AND last_seen_at < ?", sessionID, staleBefore).
+		Updates(map[string]interface{}{
+			"last_seen_at": now,
+			"ip_address":   ip,
+			"user_agent":   userAgent,
+		}).Error
+	if err != nil {
+		logconfig.Log.Warn("Kullanıcı oturumu güncellenemedi", zap.Error(err))
+	}
+	return err
+}
+
+func (r *UserSessionRepository) ListByUser(userID uint, activeAfter time.Time) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	err := r.db.
+		Where("user_id = ? AND last_seen_at > ?", userID, activeAfter).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		logconfig.Log.Error("Kullanıcı oturumları listeleme hatası",
+			zap.Uint("user_id", userID),
+			zap.Error(err),
+		)
+	}
+	return sessions, err
+}
+
+func (r *UserSessionRepository) DeleteBySessionID(sessionID string) error {
+	return r.db.Where("session_id = ?", sessionID).Delete(&models.UserSession{}).Error
+}
+
+// DeleteByUser, kullanıcının exceptSessionID dışındaki oturum kayıtlarını
+// siler ve oturum deposundan da silinebilmeleri için anahtarlarını döner.
+func (r *UserSessionRepository) DeleteByUser(ctx context.Context, userID uint, exceptSessionID string) ([]string, error) {
+	var deleted []models.UserSession
+	query := r.db.WithContext(ctx).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "session_id"}}}).
+		Where("user_id = ?", userID)
+	if exceptSessionID != "" {
+		query = query.Where("session_id <> ?", exceptSessionID)
+	}
+	if err := query.Delete(&deleted).Error; err != nil {
+		logconfig.Log.Error("Kullanıcı oturumları silme hatası",
+			zap.Uint("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(deleted))
+	for _, session := range deleted {
+		ids = append(ids, session.SessionID)
+	}
+	return ids, nil
+}
+
+func (r *UserSessionRepository) DeleteInactive(before time.Time) error {
+	return r.db.Where("last_seen_at < ?", before).Delete(&models.UserSession{}).Error
+}
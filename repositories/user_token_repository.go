@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IUserTokenRepository interface {
+	CreateToken(ctx context.Context, token *models.UserToken) error
+	FindBySelector(purpose models.UserTokenPurpose, selector string) (*models.UserToken, error)
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error)
+	InvalidateUserTokens(ctx context.Context, userID uint, purpose models.UserTokenPurpose, usedAt time.Time) error
+}
+
+type UserTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository() IUserTokenRepository {
+	return &UserTokenRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *UserTokenRepository) CreateToken(ctx context.Context, token *models.UserToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		logconfig.Log.Error("Kullanıcı tokenı oluşturma hatası",
+			zap.Uint("user_id", token.UserID),
+			zap.String("purpose", string(token.Purpose)),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *UserTokenRepository) FindBySelector(purpose models.UserTokenPurpose, selector string) (*models.UserToken, error) {
+	var token models.UserToken
+	err := r.db.Where("purpose = ? AND selector = ?", purpose, selector).First(&token).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			logconfig.Log.Error("Kullanıcı tokenı sorgulama hatası",
+				zap.String("purpose", string(purpose)),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed, tokenı yalnızca henüz kullanılmamışsa kullanıldı olarak işaretler.
+// Aynı token ile eşzamanlı iki isteğin ikisinin de başarılı olmasını engeller.
+func (r *UserTokenRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		logconfig.Log.Error("Kullanıcı tokenı güncelleme hatası", zap.Uint("token_id", id), zap.Error(result.Error))
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *UserTokenRepository) InvalidateUserTokens(ctx context.Context, userID uint, purpose models.UserTokenPurpose, usedAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", usedAt).Error
+	if err != nil {
+		logconfig.Log.Error("Kullanıcı tokenları geçersiz kılınamadı",
+			zap.Uint("user_id", userID),
+			zap.String("purpose", string(purpose)),
+			zap.Error(err),
+		)
+	}
+	return err
+}
+
+var _ IUserTokenRepository = (*UserTokenRepository)(nil)
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VisitDay struct {
+	Day     time.Time
+	Views   int64
+	Uniques int64
+}
+
+type VisitDimension struct {
+	Dimension string
+	Count     int64
+}
+
+type VisitTargetTotal struct {
+	TargetID uint
+	Label    string
+	Views    int64
+	Uniques  int64
+}
+
+type VisitTotals struct {
+	Views   int64
+	Uniques int64
+}
+
+type IVisitRepository interface {
+	SaltForDay(ctx context.Context, day time.Time, candidate string) (string, error)
+	Flush(ctx context.Context, counters []models.VisitCounter, uniques []models.VisitUnique) error
+	DailyTotals(ctx context.Context, targetType models.VisitTargetType, targetID uint, from time.Time) ([]VisitDay, error)
+	TopDimensions(ctx context.Context, targetType models.VisitTargetType, targetID uint, metric models.VisitMetric, from time.Time, limit int) ([]VisitDimension, error)
+	Totals(ctx context.Context, targetType models.VisitTargetType, from time.Time) (VisitTotals, error)
+	TopTargets(ctx context.Context, targetType models.VisitTargetType, from time.Time, limit int) ([]VisitTargetTotal, error)
+	DeleteBefore(ctx context.Context, day time.Time) error
+}
+
+type VisitRepository struct {
+	db *gorm.DB
+}
+
+func NewVisitRepository() IVisitRepository {
+	return &VisitRepository{db: databaseconfig.GetDB()}
+}
+
+// SaltForDay, günün tuzunu döndürür; henüz yoksa candidate kaydedilir. Aynı
+// anda yazan sunucular arasında ilk kaydedilen tuz kazanır.
+func (r *VisitRepository) SaltForDay(ctx context.Context, day time.Time, candidate string) (string, error) {
+	salt := models.VisitSalt{Day: day, Salt: candidate}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&salt).Error; err != nil {
+		return "", err
+	}
+
+	var stored models.VisitSalt
+	if err := r.db.WithContext(ctx).Where("day = ?", day).First(&stored).Error; err != nil {
+		return "", err
+	}
+	return stored.Salt, nil
+}
+
+// Flush, tamponda biriken sayaçları tek işlemde yazar. Tekil ziyaretçi
+// özetleri önce eklenir; yalnızca yeni eklenen özetler tekil sayaca yansır.
+func (r *VisitRepository) Flush(ctx context.Context, counters []models.VisitCounter, uniques []models.VisitUnique) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		type uniqueGroup struct {
+			targetType models.VisitTargetType
+			targetID   uint
+			day        time.Time
+		}
+		groups := make(map[uniqueGroup][]models.VisitUnique)
+		for _, u := range uniques {
+			key := uniqueGroup{u.TargetType, u.TargetID, u.Day}
+			groups[key] = append(groups[key], u)
+		}
+
+		now := time.Now()
+		for key, rows := range groups {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, 500)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				counters = append(counters, models.VisitCounter{
+					TargetType: key.targetType,
+					TargetID:   key.targetID,
+					Day:        key.day,
+					Metric:     models.VisitMetricUnique,
+					Count:      result.RowsAffected,
+					UpdatedAt:  now,
+				})
+			}
+		}
+
+		if len(counters) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "target_type"}, {Name: "target_id"}, {Name: "day"}, {Name: "metric"}, {Name: "dimension"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"count":      gorm.Expr("visit_counters.count + EXCLUDED.count"),
+				"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+			}),
+		}).CreateInBatches(counters, 500).Error
+	})
+}
+
+func (r *VisitRepository) DailyTotals(ctx context.Context, targetType models.VisitTargetType, targetID uint, from time.Time) ([]VisitDay, error) {
+	var days []VisitDay
+	err := r.db.WithContext(ctx).Model(&models.VisitCounter{}).
+		Select("day, "+
+			"COALESCE(SUM(count) FILTER (WHERE metric = ?), 0) AS views, "+
+			"COALESCE(SUM(count) FILTER (WHERE metric = ?), 0) AS uniques",
+			models.VisitMetricView, models.VisitMetricUnique).
+		Where("target_type = ? AND target_id = ? AND day >= ?", targetType, targetID, from).
+		Group("day").
+		Order("day").
+		Scan(&days).Error
+	return days, err
+}
+
+func (r *VisitRepository) TopDimensions(ctx context.Context, targetType models.VisitTargetType, targetID uint, metric models.VisitMetric, from time.Time, limit int) ([]VisitDimension, error) {
+	var dimensions []VisitDimension
+	err := r.db.WithContext(ctx).Model(&models.VisitCounter{}).
+		Select("dimension, SUM(count) AS count").
+		Where("target_type = ? AND target_id = ? AND metric = ? AND day >= ?", targetType, targetID, metric, from).
+		Group("dimension").
+		Order("count DESC, dimension").
+		Limit(limit).
+		Scan(&dimensions).Error
+	return dimensions, err
+}
+
+func (r *VisitRepository) Totals(ctx context.Context, targetType models.VisitTargetType, from time.Time) (VisitTotals, error) {
+	var totals VisitTotals
+	err := r.db.WithContext(ctx).Model(&models.VisitCounter{}).
+		Select("COALESCE(SUM(count) FILTER (WHERE metric = ?), 0) AS views, "+
+			"COALESCE(SUM(count) FILTER (WHERE metric = ?), 0) AS uniques",
+			models.VisitMetricView, models.VisitMetricUnique).
+		Where("target_type = ? AND day >= ?", targetType, from).
+		Scan(&totals).Error
+	return totals, err
+}
+
+// TopTargets, en çok görüntülenen kartvizit veya davetiyeleri ad/anahtar
+// bilgisiyle birlikte döndürür.
+func (r *VisitRepository) TopTargets(ctx context.Context, targetType models.VisitTargetType, from time.Time, limit int) ([]VisitTargetTotal, error) {
+	query := r.db.WithContext(ctx).Table("visit_counters AS vc").
+		Where("vc.target_type = ? AND vc.day >= ?", targetType, from).
+		Group("vc.target_id, label").
+		Order("views DESC").
+		Limit(limit)
+
+	totals := "COALESCE(SUM(vc.count) FILTER (WHERE vc.metric = 'view'), 0) AS views, " +
+		"COALESCE(SUM(vc.count) FILTER (WHERE vc.metric = 'unique'), 0) AS uniques"
+	switch targetType {
+	case models.VisitTargetCard:
+		query = query.Select("vc.target_id, COALESCE(NULLIF(cards.name, ''), cards.slug, '') AS label, " + totals).
+			Joins("LEFT JOIN cards ON cards.id = vc.target_id")
+	default:
+		query = query.Select("vc.target_id, COALESCE(invitations.invitation_key, '') AS label, " + totals).
+			Joins("LEFT JOIN invitations ON invitations.id = vc.target_id")
+	}
+
+	var result []VisitTargetTotal
+	err := query.Scan(&result).Error
+	return result, err
+}
+
+// DeleteBefore, artık gerekmeyen tekil ziyaretçi özetlerini ve tuzları siler.
+// Günlük sayaçlar silinmez.
+func (r *VisitRepository) DeleteBefore(ctx context.Context, day time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day < ?", day).Delete(&models.VisitUnique{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("day < ?", day).Delete(&models.VisitSalt{}).Error
+	})
+}
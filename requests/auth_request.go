@@ -9,20 +9,21 @@ import (
 
 type (
 	LoginRequest struct {
-		Email    string `form:"email" validate:"required,min=3"`
-		Password string `form:"password" validate:"required,min=6"`
+		Email      string `form:"email" validate:"required,min=3"`
+		Password   string `form:"password" validate:"required,min=6"`
+		RememberMe bool   `form:"remember_me"`
 	}
 
 	UpdatePasswordRequest struct {
 		CurrentPassword string `form:"current_password" validate:"required,min=6"`
-		NewPassword     string `form:"new_password" validate:"required,min=8,nefield=CurrentPassword"`
+		NewPassword     string `form:"new_password" validate:"required,nefield=CurrentPassword"`
 		ConfirmPassword string `form:"confirm_password" validate:"required,eqfield=NewPassword"`
 	}
 
 	RegisterRequest struct {
 		Name            string `form:"name" validate:"required,min=3"`
 		Email           string `form:"email" validate:"required,email"`
-		Password        string `form:"password" validate:"required,min=6"`
+		Password        string `form:"password" validate:"required"`
 		ConfirmPassword string `form:"confirm_password" validate:"required,eqfield=Password"`
 	}
 
@@ -32,7 +33,7 @@ type (
 
 	ResetPasswordRequest struct {
 		Token           string `form:"token" validate:"required"`
-		NewPassword     string `form:"new_password" validate:"required,min=8"`
+		NewPassword     string `form:"new_password" validate:"required"`
 		ConfirmPassword string `form:"confirm_password" validate:"required,eqfield=NewPassword"`
 	}
 
@@ -44,6 +45,14 @@ type (
 		Name  string `form:"name" validate:"required,min=3"`
 		Email string `form:"email" validate:"required,email"`
 	}
+
+	TwoFactorCodeRequest struct {
+		Code string `form:"code" validate:"required,min=6,max=20"`
+	}
+
+	DeleteAccountRequest struct {
+		Confirmation string `form:"confirmation" validate:"required,max=255"`
+	}
 )
 
 func validateRequest(c *fiber.Ctx, req interface{}, errorMessages map[string]string, redirectPath string) error {
@@ -88,13 +97,12 @@ func ValidateUpdatePasswordRequest(c *fiber.Ctx) error {
 		"CurrentPassword_required": "Mevcut şifre zorunludur",
 		"CurrentPassword_min":      "Mevcut şifre en az 6 karakter olmalıdır",
 		"NewPassword_required":     "Yeni şifre zorunludur",
-		"NewPassword_min":          "Yeni şifre en az 8 karakter olmalıdır",
 		"NewPassword_nefield":      "Yeni şifre mevcut şifreden farklı olmalıdır",
 		"ConfirmPassword_required": "Şifre tekrarı zorunludur",
 		"ConfirmPassword_eqfield":  "Yeni şifreler uyuşmuyor",
 	}
 
-	if err := validateRequest(c, &req, errorMessages, "/auth/update-password"); err != nil {
+	if err := validateRequest(c, &req, errorMessages, "/auth/profile"); err != nil {
 		return err
 	}
 
@@ -109,7 +117,6 @@ func ValidateRegisterRequest(c *fiber.Ctx) error {
 		"Email_required":           "E-posta zorunludur",
 		"Email_email":              "Geçerli bir e-posta adresi giriniz",
 		"Password_required":        "Şifre zorunludur",
-		"Password_min":             "Şifre en az 6 karakter olmalıdır",
 		"ConfirmPassword_required": "Şifre tekrarı zorunludur",
 		"ConfirmPassword_eqfield":  "Şifreler eşleşmiyor",
 	}
@@ -142,7 +149,6 @@ func ValidateResetPasswordRequest(c *fiber.Ctx) error {
 	errorMessages := map[string]string{
 		"Token_required":           "Token zorunludur",
 		"NewPassword_required":     "Yeni şifre zorunludur",
-		"NewPassword_min":          "Yeni şifre en az 8 karakter olmalıdır",
 		"ConfirmPassword_required": "Şifre onayı zorunludur",
 		"ConfirmPassword_eqfield":  "Şifreler eşleşmiyor",
 	}
@@ -185,3 +191,46 @@ func ValidateUpdateInfoRequest(c *fiber.Ctx) error {
 	c.Locals("updateInfoRequest", req)
 	return c.Next()
 }
+
+func ValidateDeleteAccountRequest(c *fiber.Ctx) error {
+	var req DeleteAccountRequest
+	errorMessages := map[string]string{
+		"Confirmation_required": "Hesap silme işlemini onaylamanız gerekiyor",
+		"Confirmation_max":      "Onay bilgisi çok uzun",
+	}
+
+	if err := validateRequest(c, &req, errorMessages, "/auth/profile"); err != nil {
+		return err
+	}
+
+	c.Locals("deleteAccountRequest", req)
+	return c.Next()
+}
+
+func validateTwoFactorCode(c *fiber.Ctx, redirectPath string) error {
+	var req TwoFactorCodeRequest
+	errorMessages := map[string]string{
+		"Code_required": "Doğrulama kodu zorunludur",
+		"Code_min":      "Doğrulama kodu en az 6 karakter olmalıdır",
+		"Code_max":      "Doğrulama kodu çok uzun",
+	}
+
+	if err := validateRequest(c, &req, errorMessages, redirectPath); err != nil {
+		return err
+	}
+
+	c.Locals("twoFactorCodeRequest", req)
+	return c.Next()
+}
+
+func ValidateTwoFactorChallengeRequest(c *fiber.Ctx) error {
+	return validateTwoFactorCode(c, "/auth/two-factor")
+}
+
+func ValidateTwoFactorSetupRequest(c *fiber.Ctx) error {
+	return validateTwoFactorCode(c, "/auth/profile/two-factor/setup")
+}
+
+func ValidateTwoFactorCodeRequest(c *fiber.Ctx) error {
+	return validateTwoFactorCode(c, "/auth/profile")
+}
@@ -2,6 +2,7 @@ package requests
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -9,6 +10,7 @@ import (
 
 type BankRequest struct {
 	Name     string `form:"name" validate:"required,min=2"`
+	Code     string `form:"code" validate:"omitempty,len=5,numeric"`
 	IsActive string `form:"is_active" validate:"required,oneof=true false"`
 }
 
@@ -18,6 +20,7 @@ func ParseAndValidateBankRequest(c *fiber.Ctx) (BankRequest, error) {
 	if err := c.BodyParser(&req); err != nil {
 		return req, errors.New("geçersiz istek formatı")
 	}
+	req.Code = strings.TrimSpace(req.Code)
 
 	validate := validator.New()
 	if err := validate.Struct(req); err != nil {
@@ -28,6 +31,8 @@ func ParseAndValidateBankRequest(c *fiber.Ctx) (BankRequest, error) {
 		errorMessages := map[string]string{
 			"Name_required":     "Banka adı zorunludur.",
 			"Name_min":          "Banka adı en az 2 karakter olmalıdır.",
+			"Code_len":          "Banka kodu 5 haneli olmalıdır.",
+			"Code_numeric":      "Banka kodu yalnızca rakamlardan oluşmalıdır.",
 			"IsActive_required": "Durum (Aktif/Pasif) seçilmelidir.",
 			"IsActive_oneof":    "Durum için geçersiz bir değer seçildi.",
 		}
@@ -2,6 +2,9 @@ package requests
 
 import (
 	"errors"
+	"fmt"
+
+	"zatrano/pkg/iban"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -60,5 +63,20 @@ func ParseAndValidateCardRequest(c *fiber.Ctx) (CardRequest, error) {
 		}
 		return req, errors.New("lütfen formdaki hataları düzeltin")
 	}
+
+	telephone, err := normalizePhone(req.Telephone)
+	if err != nil {
+		return req, err
+	}
+	req.Telephone = telephone
+
+	// IBAN'lar kaydedilmeden doğrulanır ve boşluksuz biçimde saklanır.
+	for i := range req.CardBanks {
+		normalized, err := iban.Parse(req.CardBanks[i].IBAN)
+		if err != nil {
+			return req, fmt.Errorf("%d. IBAN geçersiz: %s.", i+1, err.Error())
+		}
+		req.CardBanks[i].IBAN = normalized
+	}
 	return req, nil
 }
@@ -0,0 +1,102 @@
+package requests
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// InvitationEventRequest, davetiyeye eklenen etkinliktir (ör. kına, nikah).
+// Zamanlar davetiyenin saat diliminde girilir.
+type InvitationEventRequest struct {
+	Name        string `form:"name" validate:"required,min=2,max=100"`
+	Venue       string `form:"venue" validate:"max=255"`
+	Address     string `form:"address" validate:"max=255"`
+	Latitude    string `form:"latitude" validate:"omitempty,latitude"`
+	Longitude   string `form:"longitude" validate:"omitempty,longitude"`
+	StartAt     string `form:"start_at" validate:"required"`
+	EndAt       string `form:"end_at"`
+	RSVPEnabled string `form:"rsvp_enabled" validate:"required,oneof=true false"`
+	SortOrder   int    `form:"sort_order" validate:"min=0,max=1000"`
+}
+
+// EventSchedule, etkinlik zamanlarının ve konumunun çözülmüş halidir.
+type EventSchedule struct {
+	StartAt   time.Time
+	EndAt     *time.Time
+	Latitude  *float64
+	Longitude *float64
+}
+
+func ParseAndValidateInvitationEventRequest(c *fiber.Ctx) (InvitationEventRequest, error) {
+	var req InvitationEventRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"Name_required":        "Etkinlik adı zorunludur.",
+			"Name_min":             "Etkinlik adı en az 2 karakter olmalıdır.",
+			"Name_max":             "Etkinlik adı en fazla 100 karakter olabilir.",
+			"Venue_max":            "Mekan adı en fazla 255 karakter olabilir.",
+			"Address_max":          "Adres en fazla 255 karakter olabilir.",
+			"Latitude_latitude":    "Enlem geçerli bir değer olmalıdır.",
+			"Longitude_longitude":  "Boylam geçerli bir değer olmalıdır.",
+			"StartAt_required":     "Etkinlik başlangıç zamanı zorunludur.",
+			"RSVPEnabled_required": "Katılım yanıtı durumu seçilmelidir.",
+			"RSVPEnabled_oneof":    "Katılım yanıtı durumu için geçersiz bir değer seçildi.",
+			"SortOrder_min":        "Sıra en az 0 olmalıdır.",
+			"SortOrder_max":        "Sıra en fazla 1000 olabilir.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+	return req, nil
+}
+
+// Schedule, zamanları davetiyenin saat diliminde çözer. Koordinatlar ya
+// birlikte girilmeli ya da ikisi de boş bırakılmalıdır.
+func (r InvitationEventRequest) Schedule(loc *time.Location) (EventSchedule, error) {
+	var schedule EventSchedule
+
+	startAt, err := parseLocalDateTime(r.StartAt, loc)
+	if err != nil || startAt == nil {
+		return schedule, errors.New("Etkinlik başlangıç zamanı geçersiz.")
+	}
+	schedule.StartAt = *startAt
+	if schedule.EndAt, err = parseLocalDateTime(r.EndAt, loc); err != nil {
+		return schedule, errors.New("Etkinlik bitiş zamanı geçersiz.")
+	}
+	if schedule.EndAt != nil && !schedule.EndAt.After(schedule.StartAt) {
+		return schedule, errors.New("Etkinlik bitiş zamanı başlangıç zamanından sonra olmalıdır.")
+	}
+
+	latitude, longitude := strings.TrimSpace(r.Latitude), strings.TrimSpace(r.Longitude)
+	if (latitude == "") != (longitude == "") {
+		return schedule, errors.New("Enlem ve boylam birlikte girilmelidir.")
+	}
+	if latitude != "" {
+		lat, err := strconv.ParseFloat(latitude, 64)
+		if err != nil {
+			return schedule, errors.New("Enlem geçerli bir değer olmalıdır.")
+		}
+		lng, err := strconv.ParseFloat(longitude, 64)
+		if err != nil {
+			return schedule, errors.New("Boylam geçerli bir değer olmalıdır.")
+		}
+		schedule.Latitude, schedule.Longitude = &lat, &lng
+	}
+	return schedule, nil
+}
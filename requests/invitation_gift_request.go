@@ -0,0 +1,97 @@
+package requests
+
+import (
+	"errors"
+
+	"zatrano/pkg/iban"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// InvitationGiftAccountRequest, davetiyedeki hediye hesabıdır. IBAN boşluklu
+// girilebilir; doğrulamadan sonra boşluksuz ve büyük harfle döner. Banka
+// seçilmezse IBAN'daki banka kodundan bulunur.
+type InvitationGiftAccountRequest struct {
+	BankID        uint   `form:"bank_id"`
+	IBAN          string `form:"iban" validate:"required"`
+	AccountHolder string `form:"account_holder" validate:"required,min=2,max=100"`
+	Description   string `form:"description" validate:"max=255"`
+	SortOrder     int    `form:"sort_order" validate:"min=0,max=1000"`
+}
+
+// GiftNoteRequest, davetiye sayfasındaki hediye notu formudur. Website alanı
+// bal küpüdür; dolu gelirse istek bot kabul edilir.
+type GiftNoteRequest struct {
+	Name          string `form:"name" validate:"required,min=2,max=100"`
+	Note          string `form:"note" validate:"required,min=2,max=500"`
+	GiftAccountID uint   `form:"gift_account_id"`
+	Website       string `form:"website"`
+	GuestToken    string `form:"g"`
+}
+
+func ParseAndValidateInvitationGiftAccountRequest(c *fiber.Ctx) (InvitationGiftAccountRequest, error) {
+	var req InvitationGiftAccountRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"IBAN_required":          "IBAN zorunludur.",
+			"AccountHolder_required": "Hesap sahibi zorunludur.",
+			"AccountHolder_min":      "Hesap sahibi en az 2 karakter olmalıdır.",
+			"AccountHolder_max":      "Hesap sahibi en fazla 100 karakter olabilir.",
+			"Description_max":        "Açıklama en fazla 255 karakter olabilir.",
+			"SortOrder_min":          "Sıra en az 0 olmalıdır.",
+			"SortOrder_max":          "Sıra en fazla 1000 olabilir.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+
+	normalized, err := iban.Parse(req.IBAN)
+	if err != nil {
+		return req, errors.New("IBAN geçersiz: " + err.Error() + ".")
+	}
+	if iban.Country(normalized) != "TR" {
+		return req, errors.New("Hediye hesabı için TR ile başlayan bir IBAN girilmelidir.")
+	}
+	req.IBAN = normalized
+	return req, nil
+}
+
+func ParseAndValidateGiftNoteRequest(c *fiber.Ctx) (GiftNoteRequest, error) {
+	var req GiftNoteRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"Name_required": "Adınızı yazmalısınız.",
+			"Name_min":      "Adınız en az 2 karakter olmalıdır.",
+			"Name_max":      "Adınız en fazla 100 karakter olabilir.",
+			"Note_required": "Notunuzu yazmalısınız.",
+			"Note_min":      "Notunuz en az 2 karakter olmalıdır.",
+			"Note_max":      "Notunuz en fazla 500 karakter olabilir.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+	return req, nil
+}
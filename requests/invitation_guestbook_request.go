@@ -0,0 +1,46 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GuestbookRequest, davetiye sayfasındaki anı defteri formudur. Website
+// alanı gerçek ziyaretçilere gösterilmez (bal küpü); dolu gelirse istek bot
+// kabul edilir.
+type GuestbookRequest struct {
+	Name       string `form:"name" validate:"required,min=2,max=100"`
+	Message    string `form:"message" validate:"required,min=2,max=1000"`
+	Website    string `form:"website"`
+	GuestToken string `form:"g"`
+}
+
+func ParseAndValidateGuestbookRequest(c *fiber.Ctx) (GuestbookRequest, error) {
+	var req GuestbookRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"Name_required":    "Adınızı yazmalısınız.",
+			"Name_min":         "Adınız en az 2 karakter olmalıdır.",
+			"Name_max":         "Adınız en fazla 100 karakter olabilir.",
+			"Message_required": "Mesajınızı yazmalısınız.",
+			"Message_min":      "Mesajınız en az 2 karakter olmalıdır.",
+			"Message_max":      "Mesajınız en fazla 1000 karakter olabilir.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+	return req, nil
+}
@@ -0,0 +1,74 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// InvitationMediaRequest, galeri fotoğrafının açıklama ve sıra bilgisidir.
+type InvitationMediaRequest struct {
+	Caption   string `form:"caption" validate:"max=255"`
+	SortOrder int    `form:"sort_order" validate:"min=0,max=10000"`
+}
+
+// GalleryUploadRequest, davetiye sayfasındaki misafir fotoğraf yükleme
+// formudur; dosyalar ayrıca filemanager ile okunur.
+type GalleryUploadRequest struct {
+	Name       string `form:"name" validate:"required,min=2,max=100"`
+	Code       string `form:"code" validate:"required,max=32"`
+	GuestToken string `form:"g"`
+}
+
+func ParseAndValidateInvitationMediaRequest(c *fiber.Ctx) (InvitationMediaRequest, error) {
+	var req InvitationMediaRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"Caption_max":   "Açıklama en fazla 255 karakter olabilir.",
+			"SortOrder_min": "Sıra en az 0 olmalıdır.",
+			"SortOrder_max": "Sıra en fazla 10000 olabilir.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+	return req, nil
+}
+
+func ParseAndValidateGalleryUploadRequest(c *fiber.Ctx) (GalleryUploadRequest, error) {
+	var req GalleryUploadRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"Name_required": "Adınızı yazmalısınız.",
+			"Name_min":      "Adınız en az 2 karakter olmalıdır.",
+			"Name_max":      "Adınız en fazla 100 karakter olabilir.",
+			"Code_required": "Yükleme kodunu girmelisiniz.",
+			"Code_max":      "Yükleme kodu hatalı.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+	return req, nil
+}
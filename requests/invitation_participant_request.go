@@ -2,6 +2,9 @@ package requests
 
 import (
 	"errors"
+	"strings"
+
+	"zatrano/pkg/phone"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -9,7 +12,7 @@ import (
 
 type InvitationParticipantRequest struct {
 	Title       string `form:"title" validate:"required,min=2"`
-	PhoneNumber string `form:"phone_number" validate:"required,min=10"`
+	PhoneNumber string `form:"phone_number" validate:"required,max=32"`
 	GuestCount  string `form:"guest_count" validate:"required"`
 }
 
@@ -29,7 +32,7 @@ func ParseAndValidateInvitationParticipantRequest(c *fiber.Ctx) (InvitationParti
 			"Title_required":       "Ad Soyad zorunludur.",
 			"Title_min":            "Ad Soyad en az 2 karakter olmalıdır.",
 			"PhoneNumber_required": "Telefon numarası zorunludur.",
-			"PhoneNumber_min":      "Telefon numarası en az 10 karakter olmalıdır.",
+			"PhoneNumber_max":      "Telefon numarası en fazla 32 karakter olabilir.",
 			"GuestCount_required":  "Kişi sayısı zorunludur.",
 		}
 		if msg, ok := errorMessages[field+"_"+tag]; ok {
@@ -37,5 +40,104 @@ func ParseAndValidateInvitationParticipantRequest(c *fiber.Ctx) (InvitationParti
 		}
 		return req, errors.New("lütfen formdaki hataları düzeltin")
 	}
+
+	normalized, err := normalizePhone(req.PhoneNumber)
+	if err != nil {
+		return req, err
+	}
+	req.PhoneNumber = normalized
 	return req, nil
 }
+
+// RSVPRequest, davetiye sayfasındaki katılım formudur. Kişiye özel bağlantıyla
+// gelen misafirin imzalı anahtarı guest alanında taşınır.
+type RSVPRequest struct {
+	Title       string `form:"title" validate:"required,min=2,max=255"`
+	PhoneNumber string `form:"phone_number" validate:"required,max=32"`
+	GuestCount  int    `form:"guest_count" validate:"required,min=1,max=50"`
+	Attending   string `form:"attending" validate:"required,oneof=yes no"`
+	GuestToken  string `form:"g" validate:"omitempty,max=64"`
+}
+
+func ParseAndValidateRSVPRequest(c *fiber.Ctx) (RSVPRequest, error) {
+	var req RSVPRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"Title_required":       "Ad Soyad zorunludur.",
+			"Title_min":            "Ad Soyad en az 2 karakter olmalıdır.",
+			"Title_max":            "Ad Soyad en fazla 255 karakter olabilir.",
+			"PhoneNumber_required": "Telefon numarası zorunludur.",
+			"PhoneNumber_max":      "Telefon numarası en fazla 32 karakter olabilir.",
+			"GuestCount_required":  "Kişi sayısı zorunludur.",
+			"GuestCount_min":       "Kişi sayısı en az 1 olmalıdır.",
+			"GuestCount_max":       "Kişi sayısı en fazla 50 olabilir.",
+			"Attending_required":   "Katılım durumu seçilmelidir.",
+			"Attending_oneof":      "Katılım durumu için geçersiz bir değer seçildi.",
+			"GuestToken_max":       "Kişiye özel bağlantı geçersiz.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+
+	normalized, err := normalizePhone(req.PhoneNumber)
+	if err != nil {
+		return req, err
+	}
+	req.PhoneNumber = normalized
+	return req, nil
+}
+
+// GuestListRequest, davetiye sahibinin tek tek veya toplu eklediği misafir
+// listesidir; her satır bir misafirdir.
+type GuestListRequest struct {
+	Guests string `form:"guests" validate:"required,max=100000"`
+}
+
+func ParseAndValidateGuestListRequest(c *fiber.Ctx) (GuestListRequest, error) {
+	var req GuestListRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"Guests_required": "Misafir listesi boş olamaz.",
+			"Guests_max":      "Misafir listesi çok uzun.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+	return req, nil
+}
+
+// normalizePhone, girilen telefon numarasını E.164 biçimine çevirir; aynı
+// numaranın farklı yazımları böylece tek bir değer olarak saklanır. Boş değer
+// olduğu gibi döner, zorunluluk validate etiketiyle denetlenir.
+func normalizePhone(value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized, err := phone.Parse(value)
+	if err != nil {
+		return value, errors.New("Telefon numarası geçersiz: " + err.Error() + ".")
+	}
+	return normalized, nil
+}
@@ -0,0 +1,50 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// InvitationQuestionRequest, katılım formuna eklenen sorudur. Seçimli
+// sorularda seçenekler her satıra bir tane olacak şekilde girilir.
+type InvitationQuestionRequest struct {
+	Label      string `form:"label" validate:"required,min=2,max=255"`
+	Type       string `form:"type" validate:"required,oneof=text single_choice multi_choice number boolean"`
+	Options    string `form:"options" validate:"max=5000"`
+	IsRequired string `form:"is_required" validate:"required,oneof=true false"`
+	SortOrder  int    `form:"sort_order" validate:"min=0,max=1000"`
+}
+
+func ParseAndValidateInvitationQuestionRequest(c *fiber.Ctx) (InvitationQuestionRequest, error) {
+	var req InvitationQuestionRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"Label_required":      "Soru metni zorunludur.",
+			"Label_min":           "Soru metni en az 2 karakter olmalıdır.",
+			"Label_max":           "Soru metni en fazla 255 karakter olabilir.",
+			"Type_required":       "Soru tipi seçilmelidir.",
+			"Type_oneof":          "Soru tipi için geçersiz bir değer seçildi.",
+			"Options_max":         "Seçenekler çok uzun.",
+			"IsRequired_required": "Zorunluluk durumu seçilmelidir.",
+			"IsRequired_oneof":    "Zorunluluk durumu için geçersiz bir değer seçildi.",
+			"SortOrder_min":       "Sıra en az 0 olmalıdır.",
+			"SortOrder_max":       "Sıra en fazla 1000 olabilir.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+	return req, nil
+}
@@ -2,28 +2,40 @@ package requests
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 )
 
 type InvitationRequest struct {
-	Image         string                  `form:"image" validate:"-"`
-	InvitationKey string                  `form:"invitation_key" validate:"-"`
-	CategoryID    uint                    `form:"category_id" validate:"required,gt=0"`
-	IsConfirmed   string                  `form:"is_confirmed" validate:"required,oneof=true false"`
-	IsParticipant string                  `form:"is_participant" validate:"required,oneof=true false"`
-	IsFree        string                  `form:"is_free" validate:"required,oneof=true false"`
-	Description   string                  `form:"description" validate:"-"`
-	Venue         string                  `form:"venue" validate:"-"`
-	Address       string                  `form:"address" validate:"-"`
-	Location      string                  `form:"location" validate:"-"`
-	Link          string                  `form:"link" validate:"-"`
-	Telephone     string                  `form:"telephone" validate:"-"`
-	Note          string                  `form:"note" validate:"-"`
-	Date          string                  `form:"date" validate:"-"`
-	Time          string                  `form:"time" validate:"-"`
-	Detail        InvitationDetailRequest `form:"detail" validate:"-"`
+	Image             string                  `form:"image" validate:"-"`
+	InvitationKey     string                  `form:"invitation_key" validate:"-"`
+	CategoryID        uint                    `form:"category_id" validate:"required,gt=0"`
+	IsConfirmed       string                  `form:"is_confirmed" validate:"required,oneof=true false"`
+	IsParticipant     string                  `form:"is_participant" validate:"required,oneof=true false"`
+	IsFree            string                  `form:"is_free" validate:"required,oneof=true false"`
+	Description       string                  `form:"description" validate:"-"`
+	Venue             string                  `form:"venue" validate:"-"`
+	Address           string                  `form:"address" validate:"-"`
+	Location          string                  `form:"location" validate:"-"`
+	Link              string                  `form:"link" validate:"-"`
+	Telephone         string                  `form:"telephone" validate:"-"`
+	Note              string                  `form:"note" validate:"-"`
+	Date              string                  `form:"date" validate:"required"`
+	Time              string                  `form:"time" validate:"required"`
+	Timezone          string                  `form:"timezone" validate:"-"`
+	PublishAt         string                  `form:"publish_at" validate:"-"`
+	ExpireAt          string                  `form:"expire_at" validate:"-"`
+	ReminderEnabled   string                  `form:"reminder_enabled" validate:"omitempty,oneof=true false"`
+	ReminderLeadHours int                     `form:"reminder_lead_hours" validate:"omitempty,min=1,max=168"`
+	ReminderChannel   string                  `form:"reminder_channel" validate:"omitempty,oneof=sms whatsapp"`
+	MaxGuests         int                     `form:"max_guests" validate:"omitempty,min=0,max=100000"`
+	MaxGuestsPerRSVP  int                     `form:"max_guests_per_rsvp" validate:"omitempty,min=0,max=50"`
+	RSVPDeadline      string                  `form:"rsvp_deadline" validate:"-"`
+	WaitlistEnabled   string                  `form:"waitlist_enabled" validate:"omitempty,oneof=true false"`
+	Detail            InvitationDetailRequest `form:"detail" validate:"-"`
 }
 
 type InvitationDetailRequest struct {
@@ -67,6 +79,17 @@ func ParseAndValidateInvitationRequest(c *fiber.Ctx) (InvitationRequest, error)
 		tag := validationErrors[0].Tag()
 		errorMessages := map[string]string{
 			"CategoryID_required":        "Kategori seçilmelidir.",
+			"Date_required":              "Etkinlik tarihi zorunludur.",
+			"Time_required":              "Etkinlik saati zorunludur.",
+			"ReminderEnabled_oneof":      "Hatırlatma durumu için geçersiz bir değer seçildi.",
+			"ReminderLeadHours_min":      "Hatırlatma süresi en az 1 saat olmalıdır.",
+			"ReminderLeadHours_max":      "Hatırlatma süresi en fazla 168 saat olabilir.",
+			"ReminderChannel_oneof":      "Hatırlatma kanalı için geçersiz bir değer seçildi.",
+			"MaxGuests_min":              "Kapasite negatif olamaz.",
+			"MaxGuests_max":              "Kapasite en fazla 100000 kişi olabilir.",
+			"MaxGuestsPerRSVP_min":       "Yanıt başına kişi sınırı negatif olamaz.",
+			"MaxGuestsPerRSVP_max":       "Yanıt başına kişi sınırı en fazla 50 olabilir.",
+			"WaitlistEnabled_oneof":      "Yedek liste durumu için geçersiz bir değer seçildi.",
 			"IsConfirmed_required":       "Onay durumu seçilmelidir.",
 			"IsConfirmed_oneof":          "Onay durumu için geçersiz bir değer seçildi.",
 			"IsParticipant_required":     "Katılımcı durumu seçilmelidir.",
@@ -91,5 +114,157 @@ func ParseAndValidateInvitationRequest(c *fiber.Ctx) (InvitationRequest, error)
 		}
 		return req, errors.New("lütfen formdaki hataları düzeltin")
 	}
+	if _, err := req.Schedule(); err != nil {
+		return req, err
+	}
+	telephone, err := normalizePhone(req.Telephone)
+	if err != nil {
+		return req, err
+	}
+	req.Telephone = telephone
+	return req, nil
+}
+
+// InvitationTimezones, davetiye formunda sunulan saat dilimleridir.
+var InvitationTimezones = []string{
+	"Europe/Istanbul",
+	"Europe/London",
+	"Europe/Berlin",
+	"Europe/Amsterdam",
+	"Europe/Paris",
+	"Europe/Vienna",
+	"Europe/Zurich",
+	"Europe/Moscow",
+	"Asia/Baku",
+	"Asia/Dubai",
+	"America/New_York",
+	"America/Chicago",
+	"America/Los_Angeles",
+	"Australia/Sydney",
+	"UTC",
+}
+
+type InvitationSchedule struct {
+	EventAt      time.Time
+	Timezone     string
+	PublishAt    *time.Time
+	ExpireAt     *time.Time
+	RSVPDeadline *time.Time
+}
+
+// Schedule, formdaki yerel tarih ve saatleri seçilen saat diliminde
+// yorumlayarak UTC zamanlara çevirir.
+func (r InvitationRequest) Schedule() (InvitationSchedule, error) {
+	schedule := InvitationSchedule{Timezone: strings.TrimSpace(r.Timezone)}
+	if schedule.Timezone == "" {
+		schedule.Timezone = "Europe/Istanbul"
+	}
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return schedule, errors.New("Geçersiz saat dilimi seçildi.")
+	}
+
+	eventAt, err := time.ParseInLocation("2006-01-02 15:04", strings.TrimSpace(r.Date)+" "+strings.TrimSpace(r.Time), loc)
+	if err != nil {
+		return schedule, errors.New("Etkinlik tarihi veya saati geçersiz.")
+	}
+	schedule.EventAt = eventAt.UTC()
+
+	if schedule.PublishAt, err = parseLocalDateTime(r.PublishAt, loc); err != nil {
+		return schedule, errors.New("Yayın başlangıç zamanı geçersiz.")
+	}
+	if schedule.ExpireAt, err = parseLocalDateTime(r.ExpireAt, loc); err != nil {
+		return schedule, errors.New("Yayın bitiş zamanı geçersiz.")
+	}
+	if schedule.PublishAt != nil && schedule.ExpireAt != nil && !schedule.ExpireAt.After(*schedule.PublishAt) {
+		return schedule, errors.New("Yayın bitiş zamanı başlangıç zamanından sonra olmalıdır.")
+	}
+	if schedule.ExpireAt != nil && schedule.ExpireAt.Before(schedule.EventAt) {
+		return schedule, errors.New("Yayın bitiş zamanı etkinlik zamanından önce olamaz.")
+	}
+	if schedule.RSVPDeadline, err = parseLocalDateTime(r.RSVPDeadline, loc); err != nil {
+		return schedule, errors.New("Katılım son yanıt zamanı geçersiz.")
+	}
+	if schedule.RSVPDeadline != nil && schedule.RSVPDeadline.After(schedule.EventAt) {
+		return schedule, errors.New("Katılım son yanıt zamanı etkinlik zamanından sonra olamaz.")
+	}
+	return schedule, nil
+}
+
+// parseLocalDateTime, datetime-local alanını çözer; boş değer nil döner.
+func parseLocalDateTime(value string, loc *time.Location) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04", value, loc)
+	if err != nil {
+		return nil, err
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// InvitationVisibilityRequest, davetiyenin görünürlük ayarıdır. Şifreli modda
+// PIN boş bırakılırsa mevcut şifre korunur.
+type InvitationVisibilityRequest struct {
+	Visibility string `form:"visibility" validate:"required,oneof=public unlisted protected"`
+	PIN        string `form:"pin" validate:"omitempty,min=6,max=32"`
+}
+
+func ParseAndValidateInvitationVisibilityRequest(c *fiber.Ctx) (InvitationVisibilityRequest, error) {
+	var req InvitationVisibilityRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+	req.PIN = strings.TrimSpace(req.PIN)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"Visibility_required": "Görünürlük seçilmelidir.",
+			"Visibility_oneof":    "Görünürlük için geçersiz bir değer seçildi.",
+			"PIN_min":             "Şifre en az 6 karakter olmalıdır.",
+			"PIN_max":             "Şifre en fazla 32 karakter olabilir.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
+	return req, nil
+}
+
+// InvitationPINRequest, şifreli davetiyenin giriş formudur.
+type InvitationPINRequest struct {
+	PIN string `form:"pin" validate:"required,max=32"`
+}
+
+func ParseAndValidateInvitationPINRequest(c *fiber.Ctx) (InvitationPINRequest, error) {
+	var req InvitationPINRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, errors.New("geçersiz istek formatı")
+	}
+	req.PIN = strings.TrimSpace(req.PIN)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		field := validationErrors[0].Field()
+		tag := validationErrors[0].Tag()
+		errorMessages := map[string]string{
+			"PIN_required": "Şifreyi yazmalısınız.",
+			"PIN_max":      "Şifre en fazla 32 karakter olabilir.",
+		}
+		if msg, ok := errorMessages[field+"_"+tag]; ok {
+			return req, errors.New(msg)
+		}
+		return req, errors.New("lütfen formdaki hataları düzeltin")
+	}
 	return req, nil
 }
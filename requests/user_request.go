@@ -8,16 +8,14 @@ import (
 )
 
 type UserRequest struct {
-	Name              string `form:"name" validate:"required,min=2"`
-	Email             string `form:"email" validate:"required,email"`
-	Password          string `form:"password"`
-	Status            string `form:"status" validate:"required,oneof=true false"`
-	Type              string `form:"type" validate:"required,oneof=dashboard panel"`
-	ResetToken        string `form:"reset_token"`
-	EmailVerified     string `form:"email_verified" validate:"required,oneof=true false"`
-	VerificationToken string `form:"verification_token"`
-	Provider          string `form:"provider"`
-	ProviderID        string `form:"provider_id"`
+	Name          string `form:"name" validate:"required,min=2"`
+	Email         string `form:"email" validate:"required,email"`
+	Password      string `form:"password"`
+	Status        string `form:"status" validate:"required,oneof=true false"`
+	Type          string `form:"type" validate:"required,oneof=dashboard panel"`
+	EmailVerified string `form:"email_verified" validate:"required,oneof=true false"`
+	Provider      string `form:"provider"`
+	ProviderID    string `form:"provider_id"`
 }
 
 func ParseAndValidateUserRequest(c *fiber.Ctx) (UserRequest, error) {
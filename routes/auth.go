@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"zatrano/configs/limiterconfig"
 	handlers "zatrano/handlers/auth"
 	"zatrano/middlewares"
 	"zatrano/requests"
@@ -10,25 +11,41 @@ import (
 
 func registerAuthRoutes(app *fiber.App) {
 	authHandler := handlers.NewAuthHandler()
+	authLimiter := limiterconfig.New(limiterconfig.PolicyAuth)
+	publicLimiter := limiterconfig.New(limiterconfig.PolicyPublic)
 
 	authGroup := app.Group("/auth")
 
-	authGroup.Get("/login", authHandler.ShowLogin)
-	authGroup.Post("/login", middlewares.GuestMiddleware, requests.ValidateLoginRequest, authHandler.Login)
+	authGroup.Get("/login", publicLimiter, authHandler.ShowLogin)
+	authGroup.Post("/login", authLimiter, middlewares.GuestMiddleware, requests.ValidateLoginRequest, authHandler.Login)
 
 	authGroup.Get("/logout", middlewares.AuthMiddleware, authHandler.Logout)
 	authGroup.Get("/profile", middlewares.AuthMiddleware, authHandler.Profile)
-	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, requests.ValidateUpdatePasswordRequest, authHandler.UpdatePassword)
-	authGroup.Post("/profile/update-info", middlewares.AuthMiddleware, requests.ValidateUpdateInfoRequest, authHandler.UpdateInfo)
-	authGroup.Get("/register", authHandler.ShowRegister)
-	authGroup.Post("/register", middlewares.GuestMiddleware, requests.ValidateRegisterRequest, authHandler.Register)
-	authGroup.Get("/forgot-password", authHandler.ShowForgotPassword)
-	authGroup.Post("/forgot-password", middlewares.GuestMiddleware, requests.ValidateForgotPasswordRequest, authHandler.ForgotPassword)
-	authGroup.Get("/reset-password", authHandler.ShowResetPassword)
-	authGroup.Post("/reset-password", middlewares.GuestMiddleware, requests.ValidateResetPasswordRequest, authHandler.ResetPassword)
-	authGroup.Get("/verify-email", authHandler.VerifyEmail)
-	authGroup.Get("/resend-verification", authHandler.ShowResendVerification)
-	authGroup.Post("/resend-verification", requests.ValidateResendVerificationRequest, authHandler.ResendVerification)
-	authGroup.Get("/google/login", handlers.GoogleLogin)
-	authGroup.Get("/google/callback", handlers.GoogleCallback)
+	authGroup.Post("/profile/update-password", authLimiter, middlewares.AuthMiddleware, requests.ValidateUpdatePasswordRequest, authHandler.UpdatePassword)
+	authGroup.Post("/profile/update-info", authLimiter, middlewares.AuthMiddleware, requests.ValidateUpdateInfoRequest, authHandler.UpdateInfo)
+	authGroup.Get("/profile/two-factor/setup", middlewares.AuthMiddleware, authHandler.ShowTwoFactorSetup)
+	authGroup.Post("/profile/two-factor/enable", authLimiter, middlewares.AuthMiddleware, requests.ValidateTwoFactorSetupRequest, authHandler.EnableTwoFactor)
+	authGroup.Post("/profile/two-factor/disable", authLimiter, middlewares.AuthMiddleware, requests.ValidateTwoFactorCodeRequest, authHandler.DisableTwoFactor)
+	authGroup.Post("/profile/two-factor/recovery-codes", authLimiter, middlewares.AuthMiddleware, requests.ValidateTwoFactorCodeRequest, authHandler.RegenerateRecoveryCodes)
+	authGroup.Get("/two-factor", publicLimiter, middlewares.GuestMiddleware, authHandler.ShowTwoFactorChallenge)
+	authGroup.Post("/two-factor", authLimiter, middlewares.GuestMiddleware, requests.ValidateTwoFactorChallengeRequest, authHandler.VerifyTwoFactorChallenge)
+	authGroup.Get("/register", publicLimiter, authHandler.ShowRegister)
+	authGroup.Post("/register", authLimiter, middlewares.GuestMiddleware, requests.ValidateRegisterRequest, authHandler.Register)
+	authGroup.Get("/forgot-password", publicLimiter, authHandler.ShowForgotPassword)
+	authGroup.Post("/forgot-password", authLimiter, middlewares.GuestMiddleware, requests.ValidateForgotPasswordRequest, authHandler.ForgotPassword)
+	authGroup.Get("/reset-password", publicLimiter, authHandler.ShowResetPassword)
+	authGroup.Post("/reset-password", authLimiter, middlewares.GuestMiddleware, requests.ValidateResetPasswordRequest, authHandler.ResetPassword)
+	authGroup.Get("/verify-email", authLimiter, authHandler.VerifyEmail)
+	authGroup.Get("/resend-verification", publicLimiter, authHandler.ShowResendVerification)
+	authGroup.Post("/resend-verification", authLimiter, requests.ValidateResendVerificationRequest, authHandler.ResendVerification)
+	authGroup.Post("/profile/cancel-email-change", middlewares.AuthMiddleware, authHandler.CancelEmailChange)
+	authGroup.Get("/confirm-email-change", authLimiter, authHandler.ConfirmEmailChange)
+	authGroup.Post("/profile/sessions/revoke-others", authLimiter, middlewares.AuthMiddleware, authHandler.RevokeOtherSessions)
+	authGroup.Get("/profile/export-data", authLimiter, middlewares.AuthMiddleware, authHandler.ExportData)
+	authGroup.Post("/profile/delete-account", authLimiter, middlewares.AuthMiddleware, requests.ValidateDeleteAccountRequest, authHandler.RequestAccountDeletion)
+	authGroup.Post("/profile/cancel-deletion", middlewares.AuthMiddleware, authHandler.CancelAccountDeletion)
+	authGroup.Post("/profile/unlink-provider", authLimiter, middlewares.AuthMiddleware, authHandler.UnlinkProvider)
+	authGroup.Get("/:provider/login", authLimiter, authHandler.OAuthLogin)
+	authGroup.Get("/:provider/callback", authLimiter, authHandler.OAuthCallback)
+	authGroup.Post("/:provider/callback", authLimiter, authHandler.OAuthFormPostCallback)
 }
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"zatrano/configs/limiterconfig"
 	handlers "zatrano/handlers/dashboard"
 	"zatrano/middlewares"
 	"zatrano/models"
@@ -11,11 +12,15 @@ import (
 func registerDashboardRoutes(app *fiber.App) {
 	dashboardGroup := app.Group("/dashboard")
 	dashboardGroup.Use(
+		limiterconfig.New(limiterconfig.PolicyAPI),
 		middlewares.AuthMiddleware,
 		middlewares.StatusMiddleware,
 		middlewares.TypeMiddleware(models.Dashboard),
+		middlewares.TwoFactorMiddleware,
 	)
 
+	uploadLimiter := limiterconfig.New(limiterconfig.PolicyUpload)
+
 	dashboardHomeHandler := handlers.NewDashboardHomeHandler()
 	dashboardGroup.Get("/home", dashboardHomeHandler.HomePage)
 
@@ -27,6 +32,10 @@ func registerDashboardRoutes(app *fiber.App) {
 	dashboardGroup.Post("/users/update/:id", userHandler.UpdateUser)
 	dashboardGroup.Delete("/users/delete/:id", userHandler.DeleteUser)
 
+	loginLockHandler := handlers.NewDashboardLoginLockHandler()
+	dashboardGroup.Get("/login-locks", loginLockHandler.ListLocks)
+	dashboardGroup.Post("/login-locks/unlock/:id", loginLockHandler.Unlock)
+
 	invitationCategoryHandler := handlers.NewDashboardInvitationCategoryHandler()
 	dashboardGroup.Get("/invitation-categories", invitationCategoryHandler.ListCategories)
 	dashboardGroup.Get("/invitation-categories/create", invitationCategoryHandler.ShowCreateCategory)
@@ -54,18 +63,51 @@ func registerDashboardRoutes(app *fiber.App) {
 	cardHandler := handlers.NewDashboardCardHandler()
 	dashboardGroup.Get("/cards", cardHandler.ListCards)
 	dashboardGroup.Get("/cards/create", cardHandler.ShowCreateCard)
-	dashboardGroup.Post("/cards/create", cardHandler.CreateCard)
+	dashboardGroup.Post("/cards/create", uploadLimiter, cardHandler.CreateCard)
 	dashboardGroup.Get("/cards/update/:id", cardHandler.ShowUpdateCard)
-	dashboardGroup.Post("/cards/update/:id", cardHandler.UpdateCard)
+	dashboardGroup.Post("/cards/update/:id", uploadLimiter, cardHandler.UpdateCard)
 	dashboardGroup.Delete("/cards/delete/:id", cardHandler.DeleteCard)
 	dashboardGroup.Get("/cards/slug-check", cardHandler.SlugCheck)
 
 	invitationHandler := handlers.NewDashboardInvitationHandler()
 	dashboardGroup.Get("/invitations", invitationHandler.ListInvitations)
 	dashboardGroup.Get("/invitations/create", invitationHandler.ShowCreateInvitation)
-	dashboardGroup.Post("/invitations/create", invitationHandler.CreateInvitation)
+	dashboardGroup.Post("/invitations/create", uploadLimiter, invitationHandler.CreateInvitation)
 	dashboardGroup.Get("/invitations/update/:id", invitationHandler.ShowUpdateInvitation)
-	dashboardGroup.Post("/invitations/update/:id", invitationHandler.UpdateInvitation)
+	dashboardGroup.Post("/invitations/update/:id", uploadLimiter, invitationHandler.UpdateInvitation)
 	dashboardGroup.Delete("/invitations/delete/:id", invitationHandler.DeleteInvitation)
-	//dashboardGroup.Get("/invitations/participants/:id", invitationHandler.ListParticipants)
+	dashboardGroup.Get("/invitations/participants/:id", invitationHandler.ListParticipants)
+	dashboardGroup.Post("/invitations/participants/:id", invitationHandler.AddParticipants)
+	dashboardGroup.Post("/invitations/participants/:id/promote/:participantID", invitationHandler.PromoteParticipant)
+	dashboardGroup.Delete("/invitations/participants/:id/delete/:participantID", invitationHandler.DeleteParticipant)
+	dashboardGroup.Get("/invitations/participants/:id/export", invitationHandler.ExportParticipants)
+	dashboardGroup.Get("/invitations/questions/:id", invitationHandler.ListQuestions)
+	dashboardGroup.Post("/invitations/questions/:id", invitationHandler.CreateQuestion)
+	dashboardGroup.Post("/invitations/questions/:id/update/:questionID", invitationHandler.UpdateQuestion)
+	dashboardGroup.Delete("/invitations/questions/:id/delete/:questionID", invitationHandler.DeleteQuestion)
+	dashboardGroup.Get("/invitations/events/:id", invitationHandler.ListEvents)
+	dashboardGroup.Post("/invitations/events/:id", invitationHandler.CreateEvent)
+	dashboardGroup.Post("/invitations/events/:id/update/:eventID", invitationHandler.UpdateEvent)
+	dashboardGroup.Delete("/invitations/events/:id/delete/:eventID", invitationHandler.DeleteEvent)
+	dashboardGroup.Get("/invitations/guestbook/:id", invitationHandler.ListGuestbook)
+	dashboardGroup.Get("/invitations/guestbook/:id/export", invitationHandler.ExportGuestbook)
+	dashboardGroup.Post("/invitations/guestbook/:id/settings", invitationHandler.UpdateGuestbookSettings)
+	dashboardGroup.Post("/invitations/guestbook/:id/status/:entryID", invitationHandler.SetGuestbookEntryStatus)
+	dashboardGroup.Delete("/invitations/guestbook/:id/delete/:entryID", invitationHandler.DeleteGuestbookEntry)
+	dashboardGroup.Get("/invitations/gallery/:id", invitationHandler.ListGallery)
+	dashboardGroup.Post("/invitations/gallery/:id", uploadLimiter, invitationHandler.UploadGalleryMedia)
+	dashboardGroup.Get("/invitations/gallery/:id/download", invitationHandler.DownloadGallery)
+	dashboardGroup.Post("/invitations/gallery/:id/settings", invitationHandler.UpdateGallerySettings)
+	dashboardGroup.Post("/invitations/gallery/:id/update/:mediaID", invitationHandler.UpdateGalleryMedia)
+	dashboardGroup.Post("/invitations/gallery/:id/status/:mediaID", invitationHandler.SetGalleryMediaStatus)
+	dashboardGroup.Delete("/invitations/gallery/:id/delete/:mediaID", invitationHandler.DeleteGalleryMedia)
+	dashboardGroup.Get("/invitations/gifts/:id", invitationHandler.ListGifts)
+	dashboardGroup.Post("/invitations/gifts/:id", invitationHandler.CreateGiftAccount)
+	dashboardGroup.Post("/invitations/gifts/:id/settings", invitationHandler.UpdateGiftSettings)
+	dashboardGroup.Post("/invitations/gifts/:id/update/:accountID", invitationHandler.UpdateGiftAccount)
+	dashboardGroup.Delete("/invitations/gifts/:id/delete/:accountID", invitationHandler.DeleteGiftAccount)
+	dashboardGroup.Get("/invitations/gifts/:id/notes/export", invitationHandler.ExportGiftNotes)
+	dashboardGroup.Delete("/invitations/gifts/:id/notes/delete/:noteID", invitationHandler.DeleteGiftNote)
+	dashboardGroup.Get("/invitations/access/:id", invitationHandler.ShowAccess)
+	dashboardGroup.Post("/invitations/access/:id", invitationHandler.UpdateAccess)
 }
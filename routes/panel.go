@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"zatrano/configs/limiterconfig"
 	handlers "zatrano/handlers/panel"
 	"zatrano/middlewares"
 	"zatrano/models"
@@ -11,28 +12,66 @@ import (
 func registerPanelRoutes(app *fiber.App) {
 	panelGroup := app.Group("/panel")
 	panelGroup.Use(
+		limiterconfig.New(limiterconfig.PolicyAPI),
 		middlewares.AuthMiddleware,
 		middlewares.StatusMiddleware,
 		middlewares.TypeMiddleware(models.Panel),
 		middlewares.VerifiedMiddleware,
 	)
 
+	uploadLimiter := limiterconfig.New(limiterconfig.PolicyUpload)
+
 	panelGroup.Get("/home", handlers.PanelHomeHandler)
 
 	panelCardHandler := handlers.NewPanelCardHandler()
 	panelGroup.Get("/cards", panelCardHandler.ListCards)
 	panelGroup.Get("/cards/create", panelCardHandler.ShowCreateCard)
-	panelGroup.Post("/cards/create", panelCardHandler.CreateCard)
+	panelGroup.Post("/cards/create", uploadLimiter, panelCardHandler.CreateCard)
 	panelGroup.Get("/cards/update/:id", panelCardHandler.ShowUpdateCard)
-	panelGroup.Post("/cards/update/:id", panelCardHandler.UpdateCard)
+	panelGroup.Post("/cards/update/:id", uploadLimiter, panelCardHandler.UpdateCard)
+	panelGroup.Get("/cards/stats/:id", panelCardHandler.ShowCardStats)
 	panelGroup.Delete("/cards/delete/:id", panelCardHandler.DeleteCard)
 
 	panelInvitationHandler := handlers.NewPanelInvitationHandler()
 	panelGroup.Get("/invitations", panelInvitationHandler.ListInvitations)
 	panelGroup.Get("/invitations/create", panelInvitationHandler.ShowCreateInvitation)
-	panelGroup.Post("/invitations/create", panelInvitationHandler.CreateInvitation)
+	panelGroup.Post("/invitations/create", uploadLimiter, panelInvitationHandler.CreateInvitation)
 	panelGroup.Get("/invitations/update/:id", panelInvitationHandler.ShowUpdateInvitation)
-	panelGroup.Post("/invitations/update/:id", panelInvitationHandler.UpdateInvitation)
+	panelGroup.Post("/invitations/update/:id", uploadLimiter, panelInvitationHandler.UpdateInvitation)
+	panelGroup.Get("/invitations/stats/:id", panelInvitationHandler.ShowInvitationStats)
 	panelGroup.Delete("/invitations/delete/:id", panelInvitationHandler.DeleteInvitation)
-	//panelGroup.Get("/invitations/participants/:id", panelInvitationHandler.ListParticipants)
+	panelGroup.Get("/invitations/participants/:id", panelInvitationHandler.ListParticipants)
+	panelGroup.Post("/invitations/participants/:id", panelInvitationHandler.AddParticipants)
+	panelGroup.Post("/invitations/participants/:id/promote/:participantID", panelInvitationHandler.PromoteParticipant)
+	panelGroup.Delete("/invitations/participants/:id/delete/:participantID", panelInvitationHandler.DeleteParticipant)
+	panelGroup.Get("/invitations/participants/:id/export", panelInvitationHandler.ExportParticipants)
+	panelGroup.Get("/invitations/questions/:id", panelInvitationHandler.ListQuestions)
+	panelGroup.Post("/invitations/questions/:id", panelInvitationHandler.CreateQuestion)
+	panelGroup.Post("/invitations/questions/:id/update/:questionID", panelInvitationHandler.UpdateQuestion)
+	panelGroup.Delete("/invitations/questions/:id/delete/:questionID", panelInvitationHandler.DeleteQuestion)
+	panelGroup.Get("/invitations/events/:id", panelInvitationHandler.ListEvents)
+	panelGroup.Post("/invitations/events/:id", panelInvitationHandler.CreateEvent)
+	panelGroup.Post("/invitations/events/:id/update/:eventID", panelInvitationHandler.UpdateEvent)
+	panelGroup.Delete("/invitations/events/:id/delete/:eventID", panelInvitationHandler.DeleteEvent)
+	panelGroup.Get("/invitations/guestbook/:id", panelInvitationHandler.ListGuestbook)
+	panelGroup.Get("/invitations/guestbook/:id/export", panelInvitationHandler.ExportGuestbook)
+	panelGroup.Post("/invitations/guestbook/:id/settings", panelInvitationHandler.UpdateGuestbookSettings)
+	panelGroup.Post("/invitations/guestbook/:id/status/:entryID", panelInvitationHandler.SetGuestbookEntryStatus)
+	panelGroup.Delete("/invitations/guestbook/:id/delete/:entryID", panelInvitationHandler.DeleteGuestbookEntry)
+	panelGroup.Get("/invitations/gallery/:id", panelInvitationHandler.ListGallery)
+	panelGroup.Post("/invitations/gallery/:id", uploadLimiter, panelInvitationHandler.UploadGalleryMedia)
+	panelGroup.Get("/invitations/gallery/:id/download", panelInvitationHandler.DownloadGallery)
+	panelGroup.Post("/invitations/gallery/:id/settings", panelInvitationHandler.UpdateGallerySettings)
+	panelGroup.Post("/invitations/gallery/:id/update/:mediaID", panelInvitationHandler.UpdateGalleryMedia)
+	panelGroup.Post("/invitations/gallery/:id/status/:mediaID", panelInvitationHandler.SetGalleryMediaStatus)
+	panelGroup.Delete("/invitations/gallery/:id/delete/:mediaID", panelInvitationHandler.DeleteGalleryMedia)
+	panelGroup.Get("/invitations/gifts/:id", panelInvitationHandler.ListGifts)
+	panelGroup.Post("/invitations/gifts/:id", panelInvitationHandler.CreateGiftAccount)
+	panelGroup.Post("/invitations/gifts/:id/settings", panelInvitationHandler.UpdateGiftSettings)
+	panelGroup.Post("/invitations/gifts/:id/update/:accountID", panelInvitationHandler.UpdateGiftAccount)
+	panelGroup.Delete("/invitations/gifts/:id/delete/:accountID", panelInvitationHandler.DeleteGiftAccount)
+	panelGroup.Get("/invitations/gifts/:id/notes/export", panelInvitationHandler.ExportGiftNotes)
+	panelGroup.Delete("/invitations/gifts/:id/notes/delete/:noteID", panelInvitationHandler.DeleteGiftNote)
+	panelGroup.Get("/invitations/access/:id", panelInvitationHandler.ShowAccess)
+	panelGroup.Post("/invitations/access/:id", panelInvitationHandler.UpdateAccess)
 }
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"zatrano/configs/limiterconfig"
 	handlers "zatrano/handlers/website"
 
 	"github.com/gofiber/fiber/v2"
@@ -8,12 +9,26 @@ import (
 
 func registerWebsiteRoutes(app *fiber.App) {
 	websiteHandler := handlers.NewWebsiteHandler()
-	app.Get("/", websiteHandler.ShowHomePage)
-	app.Get("/kullanim-sartlari", websiteHandler.ShowTermsOfUse)
-	// Statik sayfalar için tek bir route
-	app.Get("/:staticPageName", websiteHandler.ShowStaticPage)
-	// Davetiye rotası (ör: /123asd1)
+	publicLimiter := limiterconfig.New(limiterconfig.PolicyPublic)
+
+	app.Get("/", publicLimiter, websiteHandler.ShowHomePage)
+	app.Get("/kullanim-sartlari", publicLimiter, websiteHandler.ShowTermsOfUse)
+	// Kartvizit rotası (ör: /@serhan); genel parametreli rotalardan önce gelmeli.
+	app.Get("/@:cardSlug", publicLimiter, websiteHandler.ShowCard)
+	app.Get("/@:cardSlug/vcard.vcf", publicLimiter, websiteHandler.DownloadCardVCard)
+	app.Get("/@:cardSlug/qr.:format", publicLimiter, websiteHandler.CardQRCode)
+	app.Post("/@:cardSlug/etkilesim", publicLimiter, websiteHandler.TrackCardClick)
+	// Statik sayfalar için tek bir route; eşleşmeyen adlar davetiye rotasına düşer.
+	app.Get("/:staticPageName", publicLimiter, websiteHandler.ShowStaticPage)
+	app.Get("/:invitationKey/takvim.ics", publicLimiter, websiteHandler.DownloadInvitationCalendar)
+	app.Get("/:invitationKey/etkinlik/:eventID/takvim.ics", publicLimiter, websiteHandler.DownloadEventCalendar)
+	app.Get("/:invitationKey/qr.:format", publicLimiter, websiteHandler.InvitationQRCode)
+	app.Post("/:invitationKey/etkilesim", publicLimiter, websiteHandler.TrackInvitationClick)
+	app.Post("/:invitationKey/giris", limiterconfig.New(limiterconfig.PolicyPIN), websiteHandler.UnlockInvitation)
+	app.Post("/:invitationKey/katilim", limiterconfig.New(limiterconfig.PolicyRSVP), websiteHandler.SubmitRSVP)
+	app.Post("/:invitationKey/ani-defteri", limiterconfig.New(limiterconfig.PolicyGuestbook), websiteHandler.SubmitGuestbook)
+	app.Post("/:invitationKey/galeri", limiterconfig.New(limiterconfig.PolicyUpload), websiteHandler.UploadGalleryMedia)
+	app.Post("/:invitationKey/hediye-notu", limiterconfig.New(limiterconfig.PolicyGift), websiteHandler.SubmitGiftNote)
+	// Davetiye rotası (ör: /123asd1); hız sınırı statik sayfa rotasında uygulanır.
 	app.Get("/:invitationKey", websiteHandler.ShowInvitation)
-	// Kartvizit rotası (ör: /@serhan)
-	app.Get("/@:cardSlug", websiteHandler.ShowCard)
 }
@@ -0,0 +1,662 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/fileconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/filemanager"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrDeletionConfirmationInvalid ServiceError = "hesap silme onayı hatalı"
+	ErrDeletionAlreadyRequested    ServiceError = "hesap silme talebi zaten mevcut"
+	ErrNoPendingDeletion           ServiceError = "bekleyen hesap silme talebi yok"
+	ErrDataExportFailed            ServiceError = "veri dışa aktarımı oluşturulamadı"
+)
+
+func AccountDeletionGracePeriod() time.Duration {
+	return time.Duration(envconfig.GetEnvAsInt("ACCOUNT_DELETION_GRACE_DAYS", 30)) * 24 * time.Hour
+}
+
+type IAccountService interface {
+	ExportData(ctx context.Context, userID uint, w io.Writer) error
+	RequestDeletion(ctx context.Context, userID uint, confirmation string) (time.Time, error)
+	CancelDeletion(ctx context.Context, userID uint) error
+	PurgeDueAccounts(ctx context.Context) int
+}
+
+type AccountService struct {
+	repo     repositories.IAccountRepository
+	users    repositories.IAuthRepository
+	sessions ISessionService
+	remember IRememberMeService
+	now      func() time.Time
+}
+
+func NewAccountService() IAccountService {
+	return &AccountService{
+		repo:     repositories.NewAccountRepository(),
+		users:    repositories.NewAuthRepository(),
+		sessions: NewSessionService(),
+		remember: NewRememberMeService(),
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+type exportProfile struct {
+	ID                  uint       `json:"id"`
+	Name                string     `json:"name"`
+	Email               string     `json:"email"`
+	PendingEmail        string     `json:"pending_email,omitempty"`
+	Type                string     `json:"type"`
+	EmailVerified       bool       `json:"email_verified"`
+	Provider            string     `json:"provider,omitempty"`
+	TwoFactorEnabled    bool       `json:"two_factor_enabled"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type exportCardBank struct {
+	Bank string `json:"bank"`
+	IBAN string `json:"iban"`
+}
+
+type exportCardSocialMedia struct {
+	Platform string `json:"platform"`
+	URL      string `json:"url"`
+}
+
+type exportCard struct {
+	ID          uint                    `json:"id"`
+	Slug        string                  `json:"slug"`
+	IsActive    bool                    `json:"is_active"`
+	Name        string                  `json:"name"`
+	Title       string                  `json:"title"`
+	Photo       string                  `json:"photo,omitempty"`
+	Telephone   string                  `json:"telephone"`
+	Email       string                  `json:"email"`
+	Location    string                  `json:"location"`
+	WebsiteUrl  string                  `json:"website_url"`
+	StoreUrl    string                  `json:"store_url"`
+	Banks       []exportCardBank        `json:"banks"`
+	SocialMedia []exportCardSocialMedia `json:"social_media"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+type exportParticipant struct {
+	Title         string     `json:"title"`
+	PhoneNumber   string     `json:"phone_number"`
+	GuestCount    int        `json:"guest_count"`
+	Status        string     `json:"status"`
+	FirstOpenedAt *time.Time `json:"first_opened_at,omitempty"`
+	LastOpenedAt  *time.Time `json:"last_opened_at,omitempty"`
+	OpenCount     int        `json:"open_count"`
+	RespondedAt   *time.Time `json:"responded_at,omitempty"`
+	// Soru metni -> yanıt
+	Answers map[string]string `json:"answers,omitempty"`
+	// Etkinlik adı -> katılıyor mu
+	Events    map[string]bool `json:"events,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type exportGuestbookEntry struct {
+	Name       string     `json:"name"`
+	Message    string     `json:"message"`
+	Status     string     `json:"status"`
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type exportMedia struct {
+	FileName     string    `json:"file_name"`
+	Caption      string    `json:"caption,omitempty"`
+	SortOrder    int       `json:"sort_order"`
+	Source       string    `json:"source"`
+	UploaderName string    `json:"uploader_name,omitempty"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type exportGiftAccount struct {
+	Bank          string `json:"bank"`
+	IBAN          string `json:"iban"`
+	AccountHolder string `json:"account_holder"`
+	Description   string `json:"description,omitempty"`
+	SortOrder     int    `json:"sort_order"`
+}
+
+type exportGiftNote struct {
+	Name      string    `json:"name"`
+	Note      string    `json:"note"`
+	Account   string    `json:"account,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type exportEvent struct {
+	Name        string     `json:"name"`
+	Venue       string     `json:"venue"`
+	Address     string     `json:"address"`
+	Latitude    *float64   `json:"latitude,omitempty"`
+	Longitude   *float64   `json:"longitude,omitempty"`
+	StartAt     time.Time  `json:"start_at"`
+	EndAt       *time.Time `json:"end_at,omitempty"`
+	RSVPEnabled bool       `json:"rsvp_enabled"`
+}
+
+type exportQuestion struct {
+	Label      string   `json:"label"`
+	Type       string   `json:"type"`
+	Options    []string `json:"options,omitempty"`
+	IsRequired bool     `json:"is_required"`
+}
+
+type exportInvitation struct {
+	ID               uint                   `json:"id"`
+	InvitationKey    string                 `json:"invitation_key"`
+	Category         string                 `json:"category"`
+	Image            string                 `json:"image,omitempty"`
+	IsConfirmed      bool                   `json:"is_confirmed"`
+	IsParticipant    bool                   `json:"is_participant"`
+	Description      string                 `json:"description"`
+	Venue            string                 `json:"venue"`
+	Address          string                 `json:"address"`
+	Location         string                 `json:"location"`
+	Link             string                 `json:"link"`
+	Telephone        string                 `json:"telephone"`
+	Note             string                 `json:"note"`
+	EventAt          time.Time              `json:"event_at"`
+	Timezone         string                 `json:"timezone"`
+	PublishAt        *time.Time             `json:"publish_at,omitempty"`
+	ExpireAt         *time.Time             `json:"expire_at,omitempty"`
+	ArchivedAt       *time.Time             `json:"archived_at,omitempty"`
+	MaxGuests        int                    `json:"max_guests"`
+	MaxPerRSVP       int                    `json:"max_guests_per_rsvp"`
+	RSVPDeadline     *time.Time             `json:"rsvp_deadline,omitempty"`
+	Waitlist         bool                   `json:"waitlist_enabled"`
+	Guestbook        bool                   `json:"guestbook_enabled"`
+	Detail           map[string]interface{} `json:"detail,omitempty"`
+	Questions        []exportQuestion       `json:"questions"`
+	Events           []exportEvent          `json:"events"`
+	Participants     []exportParticipant    `json:"participants"`
+	GuestbookEntries []exportGuestbookEntry `json:"guestbook_entries"`
+	GalleryUploads   bool                   `json:"gallery_guest_uploads"`
+	Media            []exportMedia          `json:"media"`
+	GiftNotes        bool                   `json:"gift_notes_enabled"`
+	Visibility       string                 `json:"visibility"`
+	GiftAccounts     []exportGiftAccount    `json:"gift_accounts"`
+	GiftNoteEntries  []exportGiftNote       `json:"gift_notes"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+// ExportData, kullanıcının kişisel verilerini JSON dosyaları ve yüklediği
+// görsellerle birlikte ZIP arşivi olarak w'ye yazar.
+func (s *AccountService) ExportData(ctx context.Context, userID uint, w io.Writer) error {
+	user, err := s.users.FindUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return ErrDataExportFailed
+	}
+
+	card, err := s.repo.FindCardByUser(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrDataExportFailed
+	}
+
+	invitations, err := s.repo.ListInvitationsByUser(userID)
+	if err != nil {
+		return ErrDataExportFailed
+	}
+
+	archive := zip.NewWriter(w)
+	if err := s.writeArchive(archive, user, card, invitations); err != nil {
+		logconfig.Log.Error("Veri dışa aktarımı yazılamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrDataExportFailed
+	}
+	if err := archive.Close(); err != nil {
+		logconfig.Log.Error("Veri dışa aktarımı kapatılamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrDataExportFailed
+	}
+
+	logconfig.Log.Info("Kişisel veri dışa aktarımı oluşturuldu", zap.Uint("user_id", userID))
+	return nil
+}
+
+func (s *AccountService) writeArchive(archive *zip.Writer, user *models.User, card *models.Card, invitations []models.Invitation) error {
+	profile := exportProfile{
+		ID:                  user.ID,
+		Name:                user.Name,
+		Email:               user.Email,
+		PendingEmail:        user.PendingEmail,
+		Type:                string(user.Type),
+		EmailVerified:       user.EmailVerified,
+		Provider:            user.Provider,
+		TwoFactorEnabled:    user.TwoFactorEnabled,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+	}
+	if err := writeJSONEntry(archive, "profile.json", profile); err != nil {
+		return err
+	}
+
+	cards := []exportCard{}
+	if card != nil {
+		cards = append(cards, toExportCard(card))
+		if err := writeFileEntry(archive, "cards", card.Photo); err != nil {
+			return err
+		}
+	}
+	if err := writeJSONEntry(archive, "cards.json", cards); err != nil {
+		return err
+	}
+
+	exported := make([]exportInvitation, 0, len(invitations))
+	for i := range invitations {
+		exported = append(exported, toExportInvitation(&invitations[i]))
+		if err := writeFileEntry(archive, "invitations", invitations[i].Image); err != nil {
+			return err
+		}
+		for _, m := range invitations[i].Media {
+			if err := writeFileEntry(archive, MediaContentType, m.FileName); err != nil {
+				return err
+			}
+		}
+	}
+	return writeJSONEntry(archive, "invitations.json", exported)
+}
+
+func toExportCard(card *models.Card) exportCard {
+	result := exportCard{
+		ID:          card.ID,
+		Slug:        card.Slug,
+		IsActive:    card.IsActive,
+		Name:        card.Name,
+		Title:       card.Title,
+		Photo:       card.Photo,
+		Telephone:   card.Telephone,
+		Email:       card.Email,
+		Location:    card.Location,
+		WebsiteUrl:  card.WebsiteUrl,
+		StoreUrl:    card.StoreUrl,
+		Banks:       []exportCardBank{},
+		SocialMedia: []exportCardSocialMedia{},
+		CreatedAt:   card.CreatedAt,
+		UpdatedAt:   card.UpdatedAt,
+	}
+	for _, cb := range card.CardBanks {
+		result.Banks = append(result.Banks, exportCardBank{Bank: cb.Bank.Name, IBAN: cb.IBAN})
+	}
+	for _, cs := range card.CardSocialMedia {
+		result.SocialMedia = append(result.SocialMedia, exportCardSocialMedia{Platform: cs.SocialMedia.Name, URL: cs.URL})
+	}
+	return result
+}
+
+func toExportInvitation(invitation *models.Invitation) exportInvitation {
+	result := exportInvitation{
+		ID:               invitation.ID,
+		InvitationKey:    invitation.InvitationKey,
+		Image:            invitation.Image,
+		IsConfirmed:      invitation.IsConfirmed,
+		IsParticipant:    invitation.IsParticipant,
+		Description:      invitation.Description,
+		Venue:            invitation.Venue,
+		Address:          invitation.Address,
+		Location:         invitation.Location,
+		Link:             invitation.Link,
+		Telephone:        invitation.Telephone,
+		Note:             invitation.Note,
+		EventAt:          invitation.EventAt,
+		Timezone:         invitation.Timezone,
+		PublishAt:        invitation.PublishAt,
+		ExpireAt:         invitation.ExpireAt,
+		ArchivedAt:       invitation.ArchivedAt,
+		MaxGuests:        invitation.MaxGuests,
+		MaxPerRSVP:       invitation.MaxGuestsPerRSVP,
+		RSVPDeadline:     invitation.RSVPDeadline,
+		Waitlist:         invitation.WaitlistEnabled,
+		Guestbook:        invitation.GuestbookEnabled,
+		Questions:        []exportQuestion{},
+		Events:           []exportEvent{},
+		Participants:     []exportParticipant{},
+		GuestbookEntries: []exportGuestbookEntry{},
+		GalleryUploads:   invitation.GalleryGuestUploads,
+		Media:            []exportMedia{},
+		GiftNotes:        invitation.GiftNotesEnabled,
+		Visibility:       string(invitation.Visibility),
+		GiftAccounts:     []exportGiftAccount{},
+		GiftNoteEntries:  []exportGiftNote{},
+		CreatedAt:        invitation.CreatedAt,
+		UpdatedAt:        invitation.UpdatedAt,
+	}
+	if invitation.Category != nil {
+		result.Category = invitation.Category.Name
+	}
+	if invitation.InvitationDetail != nil {
+		result.Detail = detailFields(invitation.InvitationDetail)
+	}
+	for _, q := range invitation.Questions {
+		result.Questions = append(result.Questions, exportQuestion{
+			Label:      q.Label,
+			Type:       string(q.Type),
+			Options:    q.Options,
+			IsRequired: q.IsRequired,
+		})
+	}
+	for _, g := range invitation.GuestbookEntries {
+		result.GuestbookEntries = append(result.GuestbookEntries, exportGuestbookEntry{
+			Name:       g.Name,
+			Message:    g.Message,
+			Status:     string(g.Status),
+			ApprovedAt: g.ApprovedAt,
+			CreatedAt:  g.CreatedAt,
+		})
+	}
+	for _, m := range invitation.Media {
+		result.Media = append(result.Media, exportMedia{
+			FileName:     m.FileName,
+			Caption:      m.Caption,
+			SortOrder:    m.SortOrder,
+			Source:       string(m.Source),
+			UploaderName: m.UploaderName,
+			Status:       string(m.Status),
+			CreatedAt:    m.CreatedAt,
+		})
+	}
+	for _, a := range invitation.GiftAccounts {
+		result.GiftAccounts = append(result.GiftAccounts, exportGiftAccount{
+			Bank:          a.Bank.Name,
+			IBAN:          a.IBAN,
+			AccountHolder: a.AccountHolder,
+			Description:   a.Description,
+			SortOrder:     a.SortOrder,
+		})
+	}
+	for _, n := range invitation.GiftNotes {
+		note := exportGiftNote{Name: n.Name, Note: n.Note, CreatedAt: n.CreatedAt}
+		if n.GiftAccount != nil {
+			note.Account = n.GiftAccount.Bank.Name + " - " + n.GiftAccount.AccountHolder
+		}
+		result.GiftNoteEntries = append(result.GiftNoteEntries, note)
+	}
+	for _, e := range invitation.Events {
+		result.Events = append(result.Events, exportEvent{
+			Name:        e.Name,
+			Venue:       e.Venue,
+			Address:     e.Address,
+			Latitude:    e.Latitude,
+			Longitude:   e.Longitude,
+			StartAt:     e.StartAt,
+			EndAt:       e.EndAt,
+			RSVPEnabled: e.RSVPEnabled,
+		})
+	}
+	for _, p := range invitation.Participants {
+		var events map[string]bool
+		for _, e := range invitation.Events {
+			if response := p.EventResponse(e.ID); response != nil {
+				if events == nil {
+					events = make(map[string]bool)
+				}
+				events[e.Name] = response.Attending
+			}
+		}
+		var answers map[string]string
+		for _, q := range invitation.Questions {
+			if answer := p.AnswerFor(q); answer != "" {
+				if answers == nil {
+					answers = make(map[string]string)
+				}
+				answers[q.Label] = answer
+			}
+		}
+		result.Participants = append(result.Participants, exportParticipant{
+			Title:         p.Title,
+			PhoneNumber:   p.PhoneNumber,
+			GuestCount:    p.GuestCount,
+			Status:        string(p.Status),
+			FirstOpenedAt: p.FirstOpenedAt,
+			LastOpenedAt:  p.LastOpenedAt,
+			OpenCount:     p.OpenCount,
+			RespondedAt:   p.RespondedAt,
+			Answers:       answers,
+			Events:        events,
+			CreatedAt:     p.CreatedAt,
+		})
+	}
+	return result
+}
+
+// detailFields, davetiye detayının boş olmayan alanlarını döndürür; detay
+// tablosu kategoriye göre farklı alanlar kullandığından boş alanlar atlanır.
+func detailFields(d *models.InvitationDetail) map[string]interface{} {
+	fields := map[string]interface{}{
+		"title":                d.Title,
+		"person":               d.Person,
+		"mother_name":          d.MotherName,
+		"mother_surname":       d.MotherSurname,
+		"father_name":          d.FatherName,
+		"father_surname":       d.FatherSurname,
+		"bride_name":           d.BrideName,
+		"bride_surname":        d.BrideSurname,
+		"bride_mother_name":    d.BrideMotherName,
+		"bride_mother_surname": d.BrideMotherSurname,
+		"bride_father_name":    d.BrideFatherName,
+		"bride_father_surname": d.BrideFatherSurname,
+		"groom_name":           d.GroomName,
+		"groom_surname":        d.GroomSurname,
+		"groom_mother_name":    d.GroomMotherName,
+		"groom_mother_surname": d.GroomMotherSurname,
+		"groom_father_name":    d.GroomFatherName,
+		"groom_father_surname": d.GroomFatherSurname,
+	}
+	for key, value := range fields {
+		if value == "" {
+			delete(fields, key)
+		}
+	}
+	fields["is_mother_live"] = d.IsMotherLive
+	fields["is_father_live"] = d.IsFatherLive
+	fields["is_bride_mother_live"] = d.IsBrideMotherLive
+	fields["is_bride_father_live"] = d.IsBrideFatherLive
+	fields["is_groom_mother_live"] = d.IsGroomMotherLive
+	fields["is_groom_father_live"] = d.IsGroomFatherLive
+	return fields
+}
+
+func writeJSONEntry(archive *zip.Writer, name string, value interface{}) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// writeFileEntry, yüklenen dosyayı images/<contentType>/ altına ekler. Diskte
+// bulunmayan dosyalar dışa aktarımı engellemez.
+func writeFileEntry(archive *zip.Writer, contentType, fileName string) error {
+	if fileName == "" {
+		return nil
+	}
+	fileName = filepath.Base(fileName)
+
+	file, err := os.Open(filepath.Join(fileconfig.Config.GetPath(contentType), fileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logconfig.Log.Warn("Dışa aktarılacak dosya açılamadı",
+				zap.String("content_type", contentType),
+				zap.String("file", fileName),
+				zap.Error(err),
+			)
+		}
+		return nil
+	}
+	defer file.Close()
+
+	entry, err := archive.Create(path.Join("images", contentType, fileName))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, file)
+	return err
+}
+
+// RequestDeletion, hesabı bekleme süresi sonunda silinmek üzere işaretler ve
+// tüm oturumları kapatır. Şifresi olan kullanıcılar şifreleriyle, yalnızca
+// sağlayıcıyla giriş yapanlar e-posta adresleriyle onaylar.
+func (s *AccountService) RequestDeletion(ctx context.Context, userID uint, confirmation string) (time.Time, error) {
+	user, err := s.users.FindUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, ErrUserNotFound
+		}
+		return time.Time{}, ErrProfileGeneric
+	}
+	if user.DeletionScheduledAt != nil {
+		return time.Time{}, ErrDeletionAlreadyRequested
+	}
+
+	if user.Password != "" {
+		if user.CheckPassword(confirmation) != nil {
+			return time.Time{}, ErrDeletionConfirmationInvalid
+		}
+	} else if !strings.EqualFold(strings.TrimSpace(confirmation), user.Email) {
+		return time.Time{}, ErrDeletionConfirmationInvalid
+	}
+
+	now := s.now()
+	scheduledAt := now.Add(AccountDeletionGracePeriod())
+	user.DeletionRequestedAt = &now
+	user.DeletionScheduledAt = &scheduledAt
+	if err := s.users.UpdateUser(ctx, user); err != nil {
+		return time.Time{}, ErrDatabaseUpdateFailed
+	}
+
+	if err := s.sessions.RevokeAll(ctx, userID); err != nil {
+		logconfig.Log.Warn("Hesap silme talebinde oturumlar kapatılamadı", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	if err := s.remember.RevokeAll(ctx, userID, ""); err != nil {
+		logconfig.Log.Warn("Hesap silme talebinde hatırlama tokenları iptal edilemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	body := "Hesabınızın silinmesi talep edildi. Hesabınız ve tüm kişisel verileriniz " +
+		scheduledAt.Local().Format("02.01.2006 15:04") + " tarihinde kalıcı olarak silinecektir. " +
+		"Bu tarihe kadar giriş yapıp profil sayfanızdan talebi iptal edebilirsiniz."
+	if err := NewMailService().SendMail(user.Email, "Hesap Silme Talebi", body); err != nil {
+		logconfig.Log.Warn("Hesap silme bildirimi gönderilemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	logconfig.Log.Info("Hesap silme talep edildi",
+		zap.Uint("user_id", userID),
+		zap.Time("scheduled_at", scheduledAt),
+	)
+	return scheduledAt, nil
+}
+
+func (s *AccountService) CancelDeletion(ctx context.Context, userID uint) error {
+	user, err := s.users.FindUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return ErrProfileGeneric
+	}
+	if user.DeletionScheduledAt == nil {
+		return ErrNoPendingDeletion
+	}
+
+	user.DeletionRequestedAt = nil
+	user.DeletionScheduledAt = nil
+	if err := s.users.UpdateUser(ctx, user); err != nil {
+		return ErrDatabaseUpdateFailed
+	}
+
+	logconfig.Log.Info("Hesap silme talebi iptal edildi", zap.Uint("user_id", userID))
+	return nil
+}
+
+// PurgeDueAccounts, bekleme süresi dolan hesapların verilerini siler ve
+// silinen hesap sayısını döndürür.
+func (s *AccountService) PurgeDueAccounts(ctx context.Context) int {
+	users, err := s.repo.ListDueDeletions(s.now())
+	if err != nil {
+		return 0
+	}
+
+	purged := 0
+	for i := range users {
+		if ctx.Err() != nil {
+			break
+		}
+		if s.purge(ctx, &users[i]) {
+			purged++
+		}
+	}
+	if purged > 0 {
+		logconfig.Log.Info("Süresi dolan hesaplar silindi", zap.Int("count", purged))
+	}
+	return purged
+}
+
+func (s *AccountService) purge(ctx context.Context, user *models.User) bool {
+	// Dosya adları satırlar silinmeden önce toplanır; dosyalar yalnızca veritabanı
+	// işlemi başarılı olursa kaldırılır.
+	var photo string
+	if card, err := s.repo.FindCardByUser(user.ID); err == nil {
+		photo = card.Photo
+	}
+	invitations, err := s.repo.ListInvitationsByUser(user.ID)
+	if err != nil {
+		return false
+	}
+
+	if err := s.sessions.RevokeAll(ctx, user.ID); err != nil {
+		logconfig.Log.Warn("Silinen hesabın oturumları kapatılamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
+	email := user.Email
+	if err := s.repo.PurgeUser(ctx, user); err != nil {
+		return false
+	}
+
+	filemanager.DeleteFile("cards", photo)
+	for _, invitation := range invitations {
+		filemanager.DeleteFile("invitations", invitation.Image)
+		for _, m := range invitation.Media {
+			filemanager.DeleteFile(MediaContentType, m.FileName)
+		}
+	}
+
+	body := "Talebiniz üzerine hesabınız ve kişisel verileriniz kalıcı olarak silinmiştir."
+	if err := NewMailService().SendMail(email, "Hesabınız Silindi", body); err != nil {
+		logconfig.Log.Warn("Hesap silme onay e-postası gönderilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
+	logconfig.Log.Info("Hesap verileri silindi", zap.Uint("user_id", user.ID))
+	return true
+}
+
+var _ IAccountService = (*AccountService)(nil)
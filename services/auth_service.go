@@ -2,14 +2,16 @@ package services
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"zatrano/configs/envconfig"
 	"zatrano/configs/logconfig"
 	"zatrano/models"
+	"zatrano/pkg/oauth"
 	"zatrano/repositories"
 
 	"go.uber.org/zap"
@@ -28,34 +30,72 @@ const (
 	ErrUserNotFound             ServiceError = "kullanıcı bulunamadı"
 	ErrUserInactive             ServiceError = "kullanıcı aktif değil"
 	ErrCurrentPasswordIncorrect ServiceError = "mevcut şifre hatalı"
-	ErrPasswordTooShort         ServiceError = "yeni şifre en az 6 karakter olmalıdır"
-	ErrPasswordSameAsOld        ServiceError = "yeni şifre mevcut şifre ile aynı olamaz"
 	ErrAuthGeneric              ServiceError = "kimlik doğrulaması sırasında bir hata oluştu"
 	ErrProfileGeneric           ServiceError = "profil bilgileri alınırken hata"
 	ErrUpdatePasswordGeneric    ServiceError = "şifre güncellenirken bir hata oluştu"
 	ErrHashingFailed            ServiceError = "yeni şifre oluşturulurken hata"
 	ErrDatabaseUpdateFailed     ServiceError = "veritabanı güncellemesi başarısız oldu"
+	ErrEmailInUse               ServiceError = "bu e-posta adresi başka bir hesap tarafından kullanılıyor"
+	ErrEmailUnchanged           ServiceError = "e-posta adresi değişmedi"
+	ErrNoPendingEmailChange     ServiceError = "bekleyen e-posta değişikliği yok"
+	ErrOAuthEmailMissing        ServiceError = "sağlayıcı e-posta adresi paylaşmadı"
+	ErrOAuthEmailNotVerified    ServiceError = "sağlayıcıdaki e-posta adresi doğrulanmamış, hesap bağlanamadı"
+	ErrOAuthLinkedElsewhere     ServiceError = "bu e-posta adresi başka bir sağlayıcı ile bağlı"
+	ErrOAuthIdentityInUse       ServiceError = "bu sağlayıcı hesabı başka bir kullanıcıya bağlı"
+	ErrOAuthAlreadyLinked       ServiceError = "hesabınız zaten bir sağlayıcıya bağlı, önce mevcut bağlantıyı kaldırın"
+	ErrOAuthNotLinked           ServiceError = "hesabınız herhangi bir sağlayıcıya bağlı değil"
+	ErrOAuthUnlinkNoPassword    ServiceError = "bağlantıyı kaldırmadan önce şifremi unuttum adımıyla bir şifre belirleyin"
 )
 
 type IAuthService interface {
-	Authenticate(email, password string) (*models.User, error)
+	Authenticate(email, password, ip string) (*models.User, error)
 	GetUserProfile(id uint) (*models.User, error)
 	UpdatePassword(ctx context.Context, userID uint, currentPass, newPassword string) error
 	CreateUser(ctx context.Context, user *models.User) error
 	SendPasswordResetLink(email string) error
 	ResetPassword(token, newPassword string) error
+	SendVerificationLink(user *models.User) error
 	VerifyEmail(token string) error
 	ResendVerificationLink(email string) error
-	FindOrCreateUser(user models.User) (*models.User, error)
-	UpdateUserInfo(ctx context.Context, userID uint, name, email string) error
+	LoginWithOAuth(ctx context.Context, identity *oauth.Identity) (*models.User, error)
+	LinkOAuthProvider(ctx context.Context, userID uint, identity *oauth.Identity) error
+	UnlinkOAuthProvider(ctx context.Context, userID uint) error
+	UpdateUserInfo(ctx context.Context, userID uint, name string) error
+	RequestEmailChange(ctx context.Context, userID uint, newEmail string) error
+	ConfirmEmailChange(token string) error
+	CancelEmailChange(ctx context.Context, userID uint) error
 }
 
 type AuthService struct {
-	repo repositories.IAuthRepository
+	repo         repositories.IAuthRepository
+	tokenService IUserTokenService
+	protection   ILoginProtectionService
+	sessions     ISessionService
+	remember     IRememberMeService
+	passwords    IPasswordPolicyService
 }
 
 func NewAuthService() IAuthService {
-	return &AuthService{repo: repositories.NewAuthRepository()}
+	return &AuthService{
+		repo:         repositories.NewAuthRepository(),
+		tokenService: NewUserTokenService(),
+		protection:   NewLoginProtectionService(),
+		sessions:     NewSessionService(),
+		remember:     NewRememberMeService(),
+		passwords:    NewPasswordPolicyService(),
+	}
+}
+
+func passwordResetTokenTTL() time.Duration {
+	return time.Duration(envconfig.GetEnvAsInt("PASSWORD_RESET_TOKEN_TTL_MINUTES", 60)) * time.Minute
+}
+
+func emailVerificationTokenTTL() time.Duration {
+	return time.Duration(envconfig.GetEnvAsInt("EMAIL_VERIFICATION_TOKEN_TTL_HOURS", 48)) * time.Hour
+}
+
+func emailChangeTokenTTL() time.Duration {
+	return time.Duration(envconfig.GetEnvAsInt("EMAIL_CHANGE_TOKEN_TTL_HOURS", 24)) * time.Hour
 }
 
 func (s *AuthService) logAuthSuccess(email string, userID uint) {
@@ -112,9 +152,21 @@ func (s *AuthService) hashPassword(password string) (string, error) {
 	return string(hashedPassword), nil
 }
 
-func (s *AuthService) Authenticate(email, password string) (*models.User, error) {
+func (s *AuthService) Authenticate(email, password, ip string) (*models.User, error) {
+	if err := s.protection.CheckLogin(email, ip); err != nil {
+		s.logWarn("Giriş denemesi sınırlandı",
+			zap.String("email", email),
+			zap.String("ip", ip),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
 	user, err := s.getUserByEmail(email)
 	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			s.protection.RegisterLoginFailure(email, ip)
+		}
 		return nil, err
 	}
 
@@ -131,9 +183,11 @@ func (s *AuthService) Authenticate(email, password string) (*models.User, error)
 			zap.String("email", email),
 			zap.Uint("user_id", user.ID),
 		)
+		s.protection.RegisterLoginFailure(email, ip)
 		return nil, ErrInvalidCredentials
 	}
 
+	s.protection.RegisterLoginSuccess(email)
 	s.logAuthSuccess(email, user.ID)
 	return user, nil
 }
@@ -153,14 +207,8 @@ func (s *AuthService) UpdatePassword(ctx context.Context, userID uint, currentPa
 		return ErrCurrentPasswordIncorrect
 	}
 
-	if len(newPassword) < 6 {
-		s.logWarn("Yeni parola çok kısa", zap.Uint("user_id", userID))
-		return ErrPasswordTooShort
-	}
-
-	if currentPass == newPassword {
-		s.logWarn("Yeni parola eskiyle aynı", zap.Uint("user_id", userID))
-		return ErrPasswordSameAsOld
+	if err := s.passwords.Validate(user, newPassword); err != nil {
+		return err
 	}
 
 	hashedPassword, err := s.hashPassword(newPassword)
@@ -174,6 +222,15 @@ func (s *AuthService) UpdatePassword(ctx context.Context, userID uint, currentPa
 		s.logDBError("Kullanıcı güncelleme", err, zap.Uint("user_id", userID))
 		return ErrDatabaseUpdateFailed
 	}
+	_ = s.passwords.Remember(ctx, userID, hashedPassword)
+
+	// Parola değiştiğinde açık kalan tüm oturumlar geçersiz olmalıdır.
+	if err := s.sessions.RevokeAll(ctx, userID); err != nil {
+		s.logDBError("Oturumları sonlandırma", err, zap.Uint("user_id", userID))
+	}
+	if err := s.remember.RevokeAll(ctx, userID, ""); err != nil {
+		s.logDBError("Hatırlama tokenlarını iptal", err, zap.Uint("user_id", userID))
+	}
 
 	logconfig.Log.Info("Parola başarıyla güncellendi", zap.Uint("user_id", userID))
 	return nil
@@ -183,14 +240,25 @@ func (s *AuthService) CreateUser(ctx context.Context, user *models.User) error {
 	if user.Password == "" {
 		return errors.New("şifre alanı boş olamaz")
 	}
+	if err := s.passwords.Validate(user, user.Password); err != nil {
+		return err
+	}
 	if err := user.SetPassword(user.Password); err != nil {
 		logconfig.Log.Error("Şifre oluşturulamadı", zap.Error(err))
 		return errors.New("şifre oluşturulurken hata oluştu")
 	}
-	return s.repo.CreateUser(ctx, user)
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		return err
+	}
+	_ = s.passwords.Remember(ctx, user.ID, user.Password)
+	return nil
 }
 
 func (s *AuthService) SendPasswordResetLink(email string) error {
+	if err := s.protection.ThrottleMail(models.ThrottleScopePasswordReset, email); err != nil {
+		return err
+	}
+
 	user, err := s.repo.FindUserByEmail(email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -199,15 +267,11 @@ func (s *AuthService) SendPasswordResetLink(email string) error {
 		return ErrAuthGeneric
 	}
 
-	// Generate reset token
-	resetToken := generateToken() // Replace with actual token generation logic
-	user.ResetToken = resetToken
-
-	if err := s.repo.UpdateUser(context.Background(), user); err != nil {
-		return ErrDatabaseUpdateFailed
+	resetToken, err := s.tokenService.Issue(context.Background(), user.ID, models.TokenPurposePasswordReset, passwordResetTokenTTL())
+	if err != nil {
+		return err
 	}
 
-	// Send reset email
 	mailService := NewMailService()
 	resetLink := os.Getenv("APP_BASE_URL") + "/auth/reset-password?token=" + resetToken
 	emailBody := "Şifrenizi sıfırlamak için aşağıdaki bağlantıya tıklayın: " + resetLink
@@ -220,40 +284,74 @@ func (s *AuthService) SendPasswordResetLink(email string) error {
 }
 
 func (s *AuthService) ResetPassword(token, newPassword string) error {
-	user, err := s.repo.FindUserByResetToken(token)
+	ctx := context.Background()
+	userToken, err := s.tokenService.Peek(models.TokenPurposePasswordReset, token)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrUserNotFound
-		}
-		return ErrAuthGeneric
+		return err
+	}
+
+	user, err := s.getUserByID(userToken.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Politika ihlalinde bağlantı tüketilmez; kullanıcı aynı bağlantıyla
+	// başka bir şifre deneyebilir.
+	if err := s.passwords.Validate(user, newPassword); err != nil {
+		return err
+	}
+
+	if _, err := s.tokenService.Consume(ctx, models.TokenPurposePasswordReset, token); err != nil {
+		return err
 	}
 
 	if err := user.SetPassword(newPassword); err != nil {
 		return ErrHashingFailed
 	}
 
-	user.ResetToken = "" // Clear the token
-
-	if err := s.repo.UpdateUser(context.Background(), user); err != nil {
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
 		return ErrDatabaseUpdateFailed
 	}
+	_ = s.passwords.Remember(ctx, user.ID, user.Password)
 
+	s.protection.RegisterLoginSuccess(user.Email)
+	if err := s.sessions.RevokeAll(ctx, user.ID); err != nil {
+		s.logDBError("Oturumları sonlandırma", err, zap.Uint("user_id", user.ID))
+	}
+	if err := s.remember.RevokeAll(ctx, user.ID, ""); err != nil {
+		s.logDBError("Hatırlama tokenlarını iptal", err, zap.Uint("user_id", user.ID))
+	}
+	logconfig.Log.Info("Parola sıfırlandı", zap.Uint("user_id", user.ID))
 	return nil
 }
 
+func (s *AuthService) SendVerificationLink(user *models.User) error {
+	verificationToken, err := s.tokenService.Issue(context.Background(), user.ID, models.TokenPurposeEmailVerification, emailVerificationTokenTTL())
+	if err != nil {
+		return err
+	}
+
+	mailService := NewMailService()
+	verificationLink := os.Getenv("APP_BASE_URL") + "/auth/verify-email?token=" + verificationToken
+	emailBody := "Lütfen email adresinizi doğrulamak için aşağıdaki bağlantıya tıklayın: " + verificationLink
+	return mailService.SendMail(user.Email, "Email Doğrulama", emailBody)
+}
+
 func (s *AuthService) VerifyEmail(token string) error {
-	user, err := s.repo.FindUserByVerificationToken(token)
+	ctx := context.Background()
+	userToken, err := s.tokenService.Consume(ctx, models.TokenPurposeEmailVerification, token)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrUserNotFound
-		}
-		return ErrAuthGeneric
+		return err
+	}
+
+	user, err := s.getUserByID(userToken.UserID)
+	if err != nil {
+		return err
 	}
 
 	user.EmailVerified = true
-	user.VerificationToken = "" // Clear the token
 
-	if err := s.repo.UpdateUser(context.Background(), user); err != nil {
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
 		return ErrDatabaseUpdateFailed
 	}
 
@@ -261,6 +359,10 @@ func (s *AuthService) VerifyEmail(token string) error {
 }
 
 func (s *AuthService) ResendVerificationLink(email string) error {
+	if err := s.protection.ThrottleMail(models.ThrottleScopeVerificationEmail, email); err != nil {
+		return err
+	}
+
 	user, err := s.repo.FindUserByEmail(email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -271,48 +373,160 @@ func (s *AuthService) ResendVerificationLink(email string) error {
 	if user.EmailVerified {
 		return nil
 	}
-	verificationToken := generateToken()
-	user.VerificationToken = verificationToken
-	if err := s.repo.UpdateUser(context.Background(), user); err != nil {
+	return s.SendVerificationLink(user)
+}
+
+func (s *AuthService) findByIdentity(identity *oauth.Identity) (*models.User, error) {
+	user, err := s.repo.FindByProviderAndID(identity.Provider, identity.ProviderID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, ErrAuthGeneric
+	}
+	return user, nil
+}
+
+// LoginWithOAuth, sağlayıcı kimliğine bağlı kullanıcıyı döner. Bağlı kullanıcı
+// yoksa aynı e-postalı hesap, sağlayıcı e-postayı doğrulamışsa bu kimliğe
+// bağlanır; hiç hesap yoksa yeni bir panel kullanıcısı oluşturulur.
+func (s *AuthService) LoginWithOAuth(ctx context.Context, identity *oauth.Identity) (*models.User, error) {
+	user, err := s.findByIdentity(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		if identity.Email == "" {
+			s.logWarn("OAuth girişi", zap.String("provider", identity.Provider), zap.String("reason", "e-posta yok"))
+			return nil, ErrOAuthEmailMissing
+		}
+
+		user, err = s.repo.FindUserByEmail(identity.Email)
+		switch {
+		case err == nil:
+			if !identity.EmailVerified {
+				s.logWarn("OAuth hesap bağlama", zap.String("email", identity.Email), zap.String("reason", "e-posta doğrulanmamış"))
+				return nil, ErrOAuthEmailNotVerified
+			}
+			if user.Provider != "" && user.Provider != identity.Provider {
+				s.logWarn("OAuth hesap bağlama", zap.String("email", identity.Email), zap.String("linked_provider", user.Provider))
+				return nil, ErrOAuthLinkedElsewhere
+			}
+			if err := s.linkIdentity(ctx, user, identity); err != nil {
+				return nil, err
+			}
+		case err == gorm.ErrRecordNotFound:
+			user = &models.User{
+				Name:          identity.Name,
+				Email:         identity.Email,
+				Status:        true,
+				Type:          models.Panel,
+				Provider:      identity.Provider,
+				ProviderID:    identity.ProviderID,
+				EmailVerified: identity.EmailVerified,
+			}
+			if user.Name == "" {
+				user.Name = identity.Email
+			}
+			if err := s.repo.CreateUser(ctx, user); err != nil {
+				return nil, ErrAuthGeneric
+			}
+			logconfig.Log.Info("OAuth ile yeni kullanıcı oluşturuldu",
+				zap.Uint("user_id", user.ID),
+				zap.String("provider", identity.Provider),
+			)
+		default:
+			return nil, ErrAuthGeneric
+		}
+	}
+
+	if !user.Status {
+		s.logWarn("OAuth girişi", zap.Uint("user_id", user.ID), zap.String("reason", "kullanıcı aktif değil"))
+		return nil, ErrUserInactive
+	}
+
+	s.logAuthSuccess(user.Email, user.ID)
+	return user, nil
+}
+
+func (s *AuthService) linkIdentity(ctx context.Context, user *models.User, identity *oauth.Identity) error {
+	user.Provider = identity.Provider
+	user.ProviderID = identity.ProviderID
+	if identity.EmailVerified && identity.Email == user.Email {
+		user.EmailVerified = true
+	}
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		s.logDBError("OAuth hesap bağlama", err, zap.Uint("user_id", user.ID))
 		return ErrDatabaseUpdateFailed
 	}
-	mailService := NewMailService()
-	baseURL := os.Getenv("APP_BASE_URL")
-	verificationLink := baseURL + "/auth/verify-email?token=" + verificationToken
-	emailBody := "Lütfen email adresinizi doğrulamak için aşağıdaki bağlantıya tıklayın: " + verificationLink
-	return mailService.SendMail(user.Email, "Email Doğrulama", emailBody)
+	logconfig.Log.Info("OAuth sağlayıcısı hesaba bağlandı",
+		zap.Uint("user_id", user.ID),
+		zap.String("provider", identity.Provider),
+	)
+	return nil
 }
 
-func generateToken() string {
-	tokenBytes := make([]byte, 16) // 16 byte = 128 bit
-	if _, err := rand.Read(tokenBytes); err != nil {
-		logconfig.Log.Error("Token oluşturulamadı", zap.Error(err))
-		return ""
+func (s *AuthService) LinkOAuthProvider(ctx context.Context, userID uint, identity *oauth.Identity) error {
+	user, err := s.getUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	owner, err := s.findByIdentity(identity)
+	if err != nil {
+		return err
 	}
-	return hex.EncodeToString(tokenBytes)
+	if owner != nil {
+		if owner.ID == user.ID {
+			return nil
+		}
+		s.logWarn("OAuth hesap bağlama", zap.Uint("user_id", userID), zap.Uint("owner_id", owner.ID))
+		return ErrOAuthIdentityInUse
+	}
+
+	if user.Provider != "" {
+		return ErrOAuthAlreadyLinked
+	}
+
+	return s.linkIdentity(ctx, user, identity)
 }
 
-func (s *AuthService) FindOrCreateUser(user models.User) (*models.User, error) {
-	existingUser, err := s.repo.FindByProviderAndID(user.Provider, user.ProviderID)
-	if err == nil {
-		return existingUser, nil
+func (s *AuthService) UnlinkOAuthProvider(ctx context.Context, userID uint) error {
+	user, err := s.getUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Provider == "" {
+		return ErrOAuthNotLinked
+	}
+	// Şifresi olmayan kullanıcı bağlantıyı kaldırırsa hesabına giriş yolu kalmaz.
+	if user.Password == "" {
+		return ErrOAuthUnlinkNoPassword
 	}
 
-	if err := s.repo.CreateUser(context.Background(), &user); err != nil {
-		return nil, err
+	provider := user.Provider
+	user.Provider = ""
+	user.ProviderID = ""
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		s.logDBError("OAuth bağlantısını kaldırma", err, zap.Uint("user_id", userID))
+		return ErrDatabaseUpdateFailed
 	}
 
-	return &user, nil
+	logconfig.Log.Info("OAuth sağlayıcı bağlantısı kaldırıldı",
+		zap.Uint("user_id", userID),
+		zap.String("provider", provider),
+	)
+	return nil
 }
 
-func (s *AuthService) UpdateUserInfo(ctx context.Context, userID uint, name, email string) error {
+func (s *AuthService) UpdateUserInfo(ctx context.Context, userID uint, name string) error {
 	user, err := s.getUserByID(userID)
 	if err != nil {
 		return err
 	}
 
 	user.Name = name
-	user.Email = email
 
 	if err := s.repo.UpdateUser(ctx, user); err != nil {
 		s.logDBError("Kullanıcı bilgileri güncelleme", err, zap.Uint("user_id", userID))
@@ -323,4 +537,124 @@ func (s *AuthService) UpdateUserInfo(ctx context.Context, userID uint, name, ema
 	return nil
 }
 
+// ensureEmailAvailable, adresin başka bir kullanıcıya ait olmadığını doğrular.
+func (s *AuthService) ensureEmailAvailable(email string, userID uint) error {
+	owner, err := s.repo.FindUserByEmail(email)
+	if err == nil {
+		if owner.ID != userID {
+			return ErrEmailInUse
+		}
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return ErrAuthGeneric
+}
+
+// RequestEmailChange, yeni adresi bekleyen e-posta olarak kaydeder; adres
+// ancak yeni adrese gönderilen bağlantı onaylandığında değişir. Eski adrese
+// değişiklik talebi hakkında bilgilendirme gönderilir.
+func (s *AuthService) RequestEmailChange(ctx context.Context, userID uint, newEmail string) error {
+	newEmail = strings.TrimSpace(newEmail)
+
+	user, err := s.getUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(newEmail, user.Email) {
+		return ErrEmailUnchanged
+	}
+
+	if err := s.ensureEmailAvailable(newEmail, userID); err != nil {
+		s.logWarn("E-posta değişikliği", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	if err := s.protection.ThrottleMail(models.ThrottleScopeEmailChange, user.Email); err != nil {
+		return err
+	}
+
+	user.PendingEmail = newEmail
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		s.logDBError("Bekleyen e-posta kaydetme", err, zap.Uint("user_id", userID))
+		return ErrDatabaseUpdateFailed
+	}
+
+	changeToken, err := s.tokenService.Issue(ctx, user.ID, models.TokenPurposeEmailChange, emailChangeTokenTTL())
+	if err != nil {
+		return err
+	}
+
+	mailService := NewMailService()
+	confirmLink := os.Getenv("APP_BASE_URL") + "/auth/confirm-email-change?token=" + changeToken
+	confirmBody := "Hesabınızın e-posta adresini bu adresle değiştirmek için aşağıdaki bağlantıya tıklayın: " + confirmLink
+	if err := mailService.SendMail(newEmail, "E-posta Değişikliği Onayı", confirmBody); err != nil {
+		return fmt.Errorf("e-posta değişikliği onay e-postası gönderilemedi: %w", err)
+	}
+
+	noticeBody := "Hesabınızın e-posta adresinin " + newEmail + " olarak değiştirilmesi istendi. " +
+		"Değişiklik yeni adres onaylandığında tamamlanacaktır. Bu talebi siz yapmadıysanız şifrenizi hemen değiştirin."
+	if err := mailService.SendMail(user.Email, "E-posta Değişikliği Talebi", noticeBody); err != nil {
+		logconfig.Log.Warn("E-posta değişikliği bildirimi gönderilemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	logconfig.Log.Info("E-posta değişikliği talep edildi", zap.Uint("user_id", userID))
+	return nil
+}
+
+func (s *AuthService) ConfirmEmailChange(token string) error {
+	ctx := context.Background()
+	userToken, err := s.tokenService.Consume(ctx, models.TokenPurposeEmailChange, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.getUserByID(userToken.UserID)
+	if err != nil {
+		return err
+	}
+	if user.PendingEmail == "" {
+		return ErrNoPendingEmailChange
+	}
+
+	// Talep ile onay arasında adres başka bir hesaba alınmış olabilir.
+	if err := s.ensureEmailAvailable(user.PendingEmail, user.ID); err != nil {
+		return err
+	}
+
+	oldEmail := user.Email
+	user.Email = user.PendingEmail
+	user.PendingEmail = ""
+	user.EmailVerified = true
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		s.logDBError("E-posta değişikliği", err, zap.Uint("user_id", user.ID))
+		return ErrDatabaseUpdateFailed
+	}
+
+	logconfig.Log.Info("E-posta adresi değiştirildi",
+		zap.Uint("user_id", user.ID),
+		zap.String("old_email", oldEmail),
+		zap.String("new_email", user.Email),
+	)
+	return nil
+}
+
+func (s *AuthService) CancelEmailChange(ctx context.Context, userID uint) error {
+	user, err := s.getUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.PendingEmail == "" {
+		return ErrNoPendingEmailChange
+	}
+
+	user.PendingEmail = ""
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		s.logDBError("E-posta değişikliği iptali", err, zap.Uint("user_id", userID))
+		return ErrDatabaseUpdateFailed
+	}
+	return s.tokenService.Revoke(ctx, userID, models.TokenPurposeEmailChange)
+}
+
 var _ IAuthService = (*AuthService)(nil)
@@ -6,6 +6,7 @@ import (
 
 	"zatrano/configs/logconfig"
 	"zatrano/models"
+	"zatrano/pkg/iban"
 	"zatrano/pkg/queryparams"
 	"zatrano/repositories"
 
@@ -21,6 +22,12 @@ type IBankService interface {
 	GetBankCount() (int64, error)
 }
 
+const (
+	ErrIBANBankRequired ServiceError = "IBAN'ın bankası tanınamadı, lütfen bankayı seçin"
+	ErrIBANBankInvalid  ServiceError = "geçerli bir banka seçilmelidir"
+	ErrIBANBankMismatch ServiceError = "IBAN seçilen bankaya ait değil; banka veya IBAN hatalı olabilir"
+)
+
 type BankService struct {
 	repo repositories.IBankRepository
 }
@@ -67,6 +74,7 @@ func (s *BankService) UpdateBank(ctx context.Context, id uint, bankData *models.
 	}
 	updateData := map[string]interface{}{
 		"name":      bankData.Name,
+		"code":      bankData.Code,
 		"is_active": bankData.IsActive,
 	}
 	return s.repo.UpdateBank(ctx, id, updateData, updatedBy)
@@ -80,4 +88,33 @@ func (s *BankService) GetBankCount() (int64, error) {
 	return s.repo.GetBankCount()
 }
 
+// resolveIBANBank, IBAN'a ait bankayı belirler. Banka seçilmemişse TR
+// IBAN'ındaki banka kodundan bulunur; seçilmişse ve bankanın kodu biliniyorsa
+// IBAN'daki kodla eşleşmesi beklenir. IBAN'ın önceden doğrulandığı varsayılır.
+func resolveIBANBank(repo repositories.IBankRepository, bankID uint, value string) (*models.Bank, error) {
+	code := iban.TRBankCode(value)
+	if bankID == 0 {
+		if code == "" {
+			return nil, ErrIBANBankRequired
+		}
+		bank, err := repo.GetBankByCode(code)
+		if err != nil {
+			if !errors.Is(err, repositories.ErrNotFound) {
+				logconfig.Log.Error("Banka kodu sorgulanamadı", zap.String("code", code), zap.Error(err))
+			}
+			return nil, ErrIBANBankRequired
+		}
+		return bank, nil
+	}
+
+	bank, err := repo.GetBankByID(bankID)
+	if err != nil {
+		return nil, ErrIBANBankInvalid
+	}
+	if bank.Code != "" && code != "" && bank.Code != code {
+		return nil, ErrIBANBankMismatch
+	}
+	return bank, nil
+}
+
 var _ IBankService = (*BankService)(nil)
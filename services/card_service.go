@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"zatrano/configs/logconfig"
 	"zatrano/models"
 	"zatrano/pkg/queryparams"
@@ -19,14 +20,23 @@ type ICardService interface {
 	DeleteCardWithRelations(ctx context.Context, id uint) error
 	GetCardCount() (int64, error)
 	IsSlugAvailable(slug string, excludeID uint) (bool, error)
+	GetPublicCard(ctx context.Context, slug string) (*models.Card, error)
 }
 
+const (
+	ErrCardNotFound ServiceError = "kartvizit bulunamadı"
+)
+
 type CardService struct {
-	repo repositories.ICardRepository
+	repo     repositories.ICardRepository
+	bankRepo repositories.IBankRepository
 }
 
 func NewCardService() ICardService {
-	return &CardService{repo: repositories.NewCardRepository()}
+	return &CardService{
+		repo:     repositories.NewCardRepository(),
+		bankRepo: repositories.NewBankRepository(),
+	}
 }
 
 func (s *CardService) GetAllCards(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
@@ -57,10 +67,16 @@ func (s *CardService) GetCardByID(id uint) (*models.Card, error) {
 }
 
 func (s *CardService) CreateCardWithRelations(ctx context.Context, card *models.Card) error {
+	if err := s.resolveCardBanks(card); err != nil {
+		return err
+	}
 	return s.repo.CreateCardWithRelations(ctx, card)
 }
 
 func (s *CardService) UpdateCardWithRelations(ctx context.Context, card *models.Card) error {
+	if err := s.resolveCardBanks(card); err != nil {
+		return err
+	}
 	return s.repo.UpdateCardWithRelations(ctx, card)
 }
 
@@ -76,4 +92,29 @@ func (s *CardService) IsSlugAvailable(slug string, excludeID uint) (bool, error)
 	return s.repo.IsSlugAvailable(slug, excludeID)
 }
 
+// GetPublicCard, herkese açık kartvizit sayfası için yalnızca aktif kartları döndürür.
+func (s *CardService) GetPublicCard(ctx context.Context, slug string) (*models.Card, error) {
+	card, err := s.repo.GetActiveCardBySlug(ctx, slug)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Error("Kartvizit alınamadı", zap.String("slug", slug), zap.Error(err))
+		}
+		return nil, ErrCardNotFound
+	}
+	return card, nil
+}
+
+// resolveCardBanks, banka seçilmeden girilen IBAN'ların bankasını IBAN'dan
+// bulur ve seçilen bankayla uyuşmayan IBAN'ları reddeder.
+func (s *CardService) resolveCardBanks(card *models.Card) error {
+	for i := range card.CardBanks {
+		bank, err := resolveIBANBank(s.bankRepo, card.CardBanks[i].BankID, card.CardBanks[i].IBAN)
+		if err != nil {
+			return fmt.Errorf("%d. IBAN: %w", i+1, err)
+		}
+		card.CardBanks[i].BankID = bank.ID
+	}
+	return nil
+}
+
 var _ ICardService = (*CardService)(nil)
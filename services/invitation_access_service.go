@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/configs/secretconfig"
+	"zatrano/models"
+	"zatrano/pkg/signer"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const invitationAccessPart = "invitation_access"
+
+type IInvitationAccessService interface {
+	SetVisibility(ctx context.Context, invitationID uint, visibility models.InvitationVisibility, pin string) error
+	VerifyPIN(ctx context.Context, invitation *models.Invitation, pin, ip string) error
+	AccessToken(invitation *models.Invitation, now time.Time) (string, time.Time)
+	HasAccess(invitation *models.Invitation, token string, now time.Time) bool
+}
+
+const (
+	ErrInvitationVisibilityInvalid ServiceError = "geçersiz görünürlük seçildi"
+	ErrInvitationPINRequired       ServiceError = "şifreli davetiye için bir şifre belirlenmelidir"
+	ErrInvitationPINWrong          ServiceError = "şifre hatalı"
+	ErrInvitationPINThrottled      ServiceError = "çok fazla hatalı deneme yapıldı, lütfen daha sonra tekrar deneyin"
+)
+
+type invitationAccessPolicy struct {
+	maxAttempts       int
+	window            time.Duration
+	lockDuration      time.Duration
+	totalMaxAttempts  int
+	totalLockDuration time.Duration
+	accessTTL         time.Duration
+}
+
+func loadInvitationAccessPolicy() invitationAccessPolicy {
+	return invitationAccessPolicy{
+		maxAttempts:       envconfig.GetEnvAsInt("INVITATION_PIN_MAX_ATTEMPTS", 5),
+		window:            time.Duration(envconfig.GetEnvAsInt("INVITATION_PIN_WINDOW_MINUTES", 15)) * time.Minute,
+		lockDuration:      time.Duration(envconfig.GetEnvAsInt("INVITATION_PIN_LOCKOUT_MINUTES", 15)) * time.Minute,
+		totalMaxAttempts:  envconfig.GetEnvAsInt("INVITATION_PIN_TOTAL_MAX_ATTEMPTS", 20),
+		totalLockDuration: time.Duration(envconfig.GetEnvAsInt("INVITATION_PIN_TOTAL_LOCKOUT_MINUTES", 60)) * time.Minute,
+		accessTTL:         time.Duration(envconfig.GetEnvAsInt("INVITATION_ACCESS_DAYS", 30)) * 24 * time.Hour,
+	}
+}
+
+type InvitationAccessService struct {
+	invitationRepo repositories.IInvitationRepository
+	throttleRepo   repositories.IAuthThrottleRepository
+	policy         invitationAccessPolicy
+}
+
+func NewInvitationAccessService() IInvitationAccessService {
+	return &InvitationAccessService{
+		invitationRepo: repositories.NewInvitationRepository(),
+		throttleRepo:   repositories.NewAuthThrottleRepository(),
+		policy:         loadInvitationAccessPolicy(),
+	}
+}
+
+// SetVisibility, davetiyenin görünürlüğünü değiştirir. Şifreli modda pin boş
+// bırakılırsa mevcut şifre korunur; diğer modlarda şifre silinir. Şifre
+// değiştiğinde daha önce verilen erişim çerezleri geçersiz olur.
+func (s *InvitationAccessService) SetVisibility(ctx context.Context, invitationID uint, visibility models.InvitationVisibility, pin string) error {
+	if !visibility.IsValid() {
+		return ErrInvitationVisibilityInvalid
+	}
+
+	invitation, err := s.invitationRepo.GetInvitationByID(invitationID)
+	if err != nil {
+		return ErrInvitationNotFound
+	}
+
+	pinHash := ""
+	if visibility == models.VisibilityProtected {
+		pinHash = invitation.AccessPINHash
+		if pin != "" {
+			if err := invitation.SetAccessPIN(pin); err != nil {
+				logconfig.Log.Error("Davetiye şifresi özetlenemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+				return errors.New("şifre kaydedilirken bir hata oluştu")
+			}
+			pinHash = invitation.AccessPINHash
+		}
+		if pinHash == "" {
+			return ErrInvitationPINRequired
+		}
+	}
+
+	if err := s.invitationRepo.SetVisibility(ctx, invitationID, visibility, pinHash); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrInvitationNotFound
+		}
+		logconfig.Log.Error("Davetiye görünürlüğü güncellenemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("görünürlük ayarı güncellenirken bir hata oluştu")
+	}
+	logconfig.Log.Info("Davetiye görünürlüğü güncellendi", zap.Uint("invitation_id", invitationID), zap.String("visibility", string(visibility)))
+	return nil
+}
+
+// VerifyPIN, şifreli davetiyeye girilen şifreyi doğrular. Hatalı denemeler
+// hem davetiye+IP hem de yalnızca davetiye bazında sayılır; IP sınırı aşılınca
+// o IP, toplam sınır aşılınca farklı IP'lerden yapılan dağıtık denemelere
+// karşı davetiyenin tamamı bir süre kilitlenir. Erişim çerezi olan misafirler
+// kilitten etkilenmez. Kilitler dashboard'daki giriş kilitleri listesinden
+// kaldırılabilir.
+func (s *InvitationAccessService) VerifyPIN(ctx context.Context, invitation *models.Invitation, pin, ip string) error {
+	now := time.Now()
+	invitationKey := strconv.FormatUint(uint64(invitation.ID), 10)
+	identifier := invitationKey + ":" + ip
+
+	for _, key := range []struct {
+		scope      models.AuthThrottleScope
+		identifier string
+	}{
+		{models.ThrottleScopeInvitationPINTotal, invitationKey},
+		{models.ThrottleScopeInvitationPIN, identifier},
+	} {
+		throttle, err := s.throttleRepo.Find(key.scope, key.identifier)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("şifre doğrulanırken bir hata oluştu")
+		}
+		if throttle != nil && throttle.IsLocked(now) {
+			return ErrInvitationPINThrottled
+		}
+	}
+
+	if invitation.CheckAccessPIN(pin) == nil {
+		// Toplam sayaç başarılı girişte sıfırlanmaz; aksi halde gerçek
+		// misafirlerin girişleri dağıtık denemelerin sayacını temizlerdi.
+		_ = s.throttleRepo.Reset(ctx, models.ThrottleScopeInvitationPIN, identifier)
+		return nil
+	}
+
+	throttled := false
+	throttle, err := s.throttleRepo.Hit(ctx, models.ThrottleScopeInvitationPIN, identifier, now, s.policy.window)
+	if err == nil && throttle.Attempts >= s.policy.maxAttempts && throttle.LockedUntil == nil {
+		throttled = true
+		lockedUntil := now.Add(s.policy.lockDuration)
+		if err := s.throttleRepo.Lock(ctx, throttle.ID, lockedUntil); err == nil {
+			logconfig.Log.Warn("Davetiye şifre denemeleri kilitlendi",
+				zap.Uint("invitation_id", invitation.ID),
+				zap.String("ip", ip),
+				zap.Int("attempts", throttle.Attempts),
+				zap.Time("locked_until", lockedUntil),
+			)
+		}
+	}
+
+	throttle, err = s.throttleRepo.Hit(ctx, models.ThrottleScopeInvitationPINTotal, invitationKey, now, s.policy.window)
+	if err == nil && throttle.Attempts >= s.policy.totalMaxAttempts && throttle.LockedUntil == nil {
+		throttled = true
+		lockedUntil := now.Add(s.policy.totalLockDuration)
+		if err := s.throttleRepo.Lock(ctx, throttle.ID, lockedUntil); err == nil {
+			logconfig.Log.Warn("Davetiye tüm IP'lere karşı kilitlendi",
+				zap.Uint("invitation_id", invitation.ID),
+				zap.Int("attempts", throttle.Attempts),
+				zap.Time("locked_until", lockedUntil),
+			)
+		}
+	}
+
+	if throttled {
+		return ErrInvitationPINThrottled
+	}
+	return ErrInvitationPINWrong
+}
+
+// AccessToken, doğru şifreden sonra çereze yazılacak imzalı erişim anahtarını
+// ve son geçerlilik zamanını döndürür. İmza şifre özetine bağlıdır; şifre
+// değişince eski anahtarlar geçersiz olur.
+func (s *InvitationAccessService) AccessToken(invitation *models.Invitation, now time.Time) (string, time.Time) {
+	expires := now.Add(s.policy.accessTTL)
+	expiresAt := strconv.FormatInt(expires.Unix(), 10)
+	return expiresAt + "." + signer.Sign(secretconfig.Key(), invitationAccessPart, invitation.InvitationKey, invitation.AccessPINHash, expiresAt), expires
+}
+
+func (s *InvitationAccessService) HasAccess(invitation *models.Invitation, token string, now time.Time) bool {
+	expiresAt, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expires, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil || now.Unix() >= expires {
+		return false
+	}
+	return signer.Verify(secretconfig.Key(), signature, invitationAccessPart, invitation.InvitationKey, invitation.AccessPINHash, expiresAt)
+}
+
+var _ IInvitationAccessService = (*InvitationAccessService)(nil)
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const maxEventsPerInvitation = 10
+
+type EventInput struct {
+	Name        string
+	Venue       string
+	Address     string
+	Latitude    *float64
+	Longitude   *float64
+	StartAt     time.Time
+	EndAt       *time.Time
+	RSVPEnabled bool
+	SortOrder   int
+}
+
+// EventField, katılım formunda etkinliğin misafirin önceki yanıtıyla
+// birlikte gösterilmesi için kullanılır. Value "yes", "no" veya boştur.
+type EventField struct {
+	models.InvitationEvent
+	Value string
+}
+
+// EventAttendance, panelde etkinlik bazında katılım sayılarıdır.
+type EventAttendance struct {
+	Event        models.InvitationEvent
+	Participants int64
+	Guests       int64
+}
+
+type IInvitationEventService interface {
+	ListEvents(ctx context.Context, invitationID uint) ([]models.InvitationEvent, error)
+	GetEvent(ctx context.Context, invitationID, eventID uint) (*models.InvitationEvent, error)
+	CreateEvent(ctx context.Context, invitationID uint, input EventInput) error
+	UpdateEvent(ctx context.Context, invitationID, eventID uint, input EventInput) error
+	DeleteEvent(ctx context.Context, invitationID, eventID uint) error
+	Attendance(ctx context.Context, invitationID uint) ([]EventAttendance, error)
+	BuildResponses(events []models.InvitationEvent, raw map[string][]string, attending bool) ([]models.InvitationEventResponse, error)
+	FormFields(events []models.InvitationEvent, participant *models.InvitationParticipant) []EventField
+}
+
+const (
+	ErrEventNotFound     ServiceError = "etkinlik bulunamadı"
+	ErrEventLimitReached ServiceError = "bir davetiyeye en fazla 10 etkinlik eklenebilir"
+	ErrNoEventAttended   ServiceError = "katılacağınız en az bir etkinlik seçmelisiniz"
+)
+
+type InvitationEventService struct {
+	repo repositories.IInvitationEventRepository
+}
+
+func NewInvitationEventService() IInvitationEventService {
+	return &InvitationEventService{repo: repositories.NewInvitationEventRepository()}
+}
+
+func (s *InvitationEventService) ListEvents(ctx context.Context, invitationID uint) ([]models.InvitationEvent, error) {
+	events, err := s.repo.ListByInvitation(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Davetiye etkinlikleri alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("etkinlikler getirilirken bir hata oluştu")
+	}
+	return events, nil
+}
+
+func (s *InvitationEventService) GetEvent(ctx context.Context, invitationID, eventID uint) (*models.InvitationEvent, error) {
+	event, err := s.repo.FindInInvitation(ctx, invitationID, eventID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		logconfig.Log.Error("Davetiye etkinliği alınamadı", zap.Uint("event_id", eventID), zap.Error(err))
+		return nil, errors.New("etkinlik getirilirken bir hata oluştu")
+	}
+	return event, nil
+}
+
+func (s *InvitationEventService) CreateEvent(ctx context.Context, invitationID uint, input EventInput) error {
+	count, err := s.repo.CountByInvitation(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Davetiye etkinlikleri sayılamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("etkinlik eklenirken bir hata oluştu")
+	}
+	if count >= maxEventsPerInvitation {
+		return ErrEventLimitReached
+	}
+
+	event := &models.InvitationEvent{InvitationID: invitationID}
+	applyEventInput(event, input)
+	if err := s.repo.Create(ctx, event); err != nil {
+		logconfig.Log.Error("Davetiye etkinliği eklenemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("etkinlik eklenirken bir hata oluştu")
+	}
+	return nil
+}
+
+// UpdateEvent, etkinliği günceller. Katılım yanıtı kapatılan etkinliklerin
+// önceki yanıtları silinmez.
+func (s *InvitationEventService) UpdateEvent(ctx context.Context, invitationID, eventID uint, input EventInput) error {
+	event, err := s.GetEvent(ctx, invitationID, eventID)
+	if err != nil {
+		return err
+	}
+	applyEventInput(event, input)
+	if err := s.repo.Update(ctx, event); err != nil {
+		logconfig.Log.Error("Davetiye etkinliği güncellenemedi", zap.Uint("event_id", eventID), zap.Error(err))
+		return errors.New("etkinlik güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationEventService) DeleteEvent(ctx context.Context, invitationID, eventID uint) error {
+	if err := s.repo.Delete(ctx, invitationID, eventID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrEventNotFound
+		}
+		logconfig.Log.Error("Davetiye etkinliği silinemedi", zap.Uint("event_id", eventID), zap.Error(err))
+		return errors.New("etkinlik silinirken bir hata oluştu")
+	}
+	return nil
+}
+
+func applyEventInput(event *models.InvitationEvent, input EventInput) {
+	event.Name = strings.TrimSpace(input.Name)
+	event.Venue = strings.TrimSpace(input.Venue)
+	event.Address = strings.TrimSpace(input.Address)
+	event.Latitude = input.Latitude
+	event.Longitude = input.Longitude
+	event.StartAt = input.StartAt
+	event.EndAt = input.EndAt
+	event.RSVPEnabled = input.RSVPEnabled
+	event.SortOrder = input.SortOrder
+}
+
+// Attendance, katılım yanıtı açık etkinlikler için katılıyor durumundaki
+// misafir sayılarını etkinlik sırasıyla döndürür.
+func (s *InvitationEventService) Attendance(ctx context.Context, invitationID uint) ([]EventAttendance, error) {
+	events, err := s.ListEvents(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.CountAttending(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Etkinlik katılım sayıları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("etkinlik katılım sayıları getirilirken bir hata oluştu")
+	}
+
+	var attendance []EventAttendance
+	for _, event := range events {
+		if !event.RSVPEnabled {
+			continue
+		}
+		attendance = append(attendance, EventAttendance{
+			Event:        event,
+			Participants: counts[event.ID].Participants,
+			Guests:       counts[event.ID].Guests,
+		})
+	}
+	return attendance, nil
+}
+
+// BuildResponses, formdaki "event_<etkinlik ID>" alanlarını (yes/no; anahtar
+// etkinlik ID'si) katılım yanıtı açık etkinlikler için okur. Davete katılacağını bildiren misafir her
+// etkinliği yanıtlamalı ve en az birine katılmalıdır; katılmayacaksa
+// etkinlik yanıtı istenmez.
+func (s *InvitationEventService) BuildResponses(events []models.InvitationEvent, raw map[string][]string, attending bool) ([]models.InvitationEventResponse, error) {
+	if !attending {
+		return nil, nil
+	}
+
+	var responses []models.InvitationEventResponse
+	anyAttending := false
+	for _, event := range events {
+		if !event.RSVPEnabled {
+			continue
+		}
+		var value string
+		if values := raw[strconv.FormatUint(uint64(event.ID), 10)]; len(values) > 0 {
+			value = strings.TrimSpace(values[0])
+		}
+		switch value {
+		case "yes":
+			anyAttending = true
+			responses = append(responses, models.InvitationEventResponse{EventID: event.ID, Attending: true})
+		case "no":
+			responses = append(responses, models.InvitationEventResponse{EventID: event.ID, Attending: false})
+		case "":
+			return nil, fmt.Errorf("%q etkinliğine katılıp katılmayacağınızı seçmelisiniz", event.Name)
+		default:
+			return nil, fmt.Errorf("%q etkinliği için geçersiz bir değer seçildi", event.Name)
+		}
+	}
+	if len(responses) > 0 && !anyAttending {
+		return nil, ErrNoEventAttended
+	}
+	return responses, nil
+}
+
+func (s *InvitationEventService) FormFields(events []models.InvitationEvent, participant *models.InvitationParticipant) []EventField {
+	var fields []EventField
+	for _, event := range events {
+		if !event.RSVPEnabled {
+			continue
+		}
+		field := EventField{InvitationEvent: event}
+		if participant != nil {
+			if response := participant.EventResponse(event.ID); response != nil {
+				field.Value = "no"
+				if response.Attending {
+					field.Value = "yes"
+				}
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+var _ IInvitationEventService = (*InvitationEventService)(nil)
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const maxGiftAccountsPerInvitation = 5
+
+// GiftAccountInput, IBAN'ın istek katmanında doğrulanıp boşluksuz hale
+// getirilmiş olduğunu varsayar.
+type GiftAccountInput struct {
+	BankID        uint
+	IBAN          string
+	AccountHolder string
+	Description   string
+	SortOrder     int
+}
+
+type GiftNoteInput struct {
+	Name          string
+	Note          string
+	GiftAccountID uint
+}
+
+type IInvitationGiftService interface {
+	ListAccounts(ctx context.Context, invitationID uint) ([]models.InvitationGiftAccount, error)
+	GetAccount(ctx context.Context, invitationID, accountID uint) (*models.InvitationGiftAccount, error)
+	CreateAccount(ctx context.Context, invitationID uint, input GiftAccountInput) error
+	UpdateAccount(ctx context.Context, invitationID, accountID uint, input GiftAccountInput) error
+	DeleteAccount(ctx context.Context, invitationID, accountID uint) error
+	ListNotes(ctx context.Context, invitationID uint) ([]models.InvitationGiftNote, error)
+	SubmitNote(ctx context.Context, invitation *models.Invitation, guest *models.InvitationParticipant, input GiftNoteInput) error
+	DeleteNote(ctx context.Context, invitationID, noteID uint) error
+	SetNotesEnabled(ctx context.Context, invitationID uint, enabled bool) error
+	ExportNotesCSV(ctx context.Context, invitation *models.Invitation) ([]byte, error)
+}
+
+const (
+	ErrGiftAccountNotFound  ServiceError = "hesap bulunamadı"
+	ErrGiftAccountLimit     ServiceError = "bir davetiyeye en fazla 5 hesap eklenebilir"
+	ErrGiftBankInvalid      ServiceError = "geçerli bir banka seçilmelidir"
+	ErrGiftNotesDisabled    ServiceError = "bu davetiyede hediye notu kapalı"
+	ErrGiftNoteNotFound     ServiceError = "not bulunamadı"
+	ErrGiftNoteAccountWrong ServiceError = "seçilen hesap bu davetiyeye ait değil"
+)
+
+type InvitationGiftService struct {
+	repo           repositories.IInvitationGiftRepository
+	bankRepo       repositories.IBankRepository
+	invitationRepo repositories.IInvitationRepository
+}
+
+func NewInvitationGiftService() IInvitationGiftService {
+	return &InvitationGiftService{
+		repo:           repositories.NewInvitationGiftRepository(),
+		bankRepo:       repositories.NewBankRepository(),
+		invitationRepo: repositories.NewInvitationRepository(),
+	}
+}
+
+func (s *InvitationGiftService) ListAccounts(ctx context.Context, invitationID uint) ([]models.InvitationGiftAccount, error) {
+	accounts, err := s.repo.ListAccounts(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Hediye hesapları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("hesaplar getirilirken bir hata oluştu")
+	}
+	return accounts, nil
+}
+
+func (s *InvitationGiftService) GetAccount(ctx context.Context, invitationID, accountID uint) (*models.InvitationGiftAccount, error) {
+	account, err := s.repo.FindAccount(ctx, invitationID, accountID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrGiftAccountNotFound
+		}
+		logconfig.Log.Error("Hediye hesabı alınamadı", zap.Uint("account_id", accountID), zap.Error(err))
+		return nil, errors.New("hesap getirilirken bir hata oluştu")
+	}
+	return account, nil
+}
+
+func (s *InvitationGiftService) CreateAccount(ctx context.Context, invitationID uint, input GiftAccountInput) error {
+	if err := s.checkBank(&input); err != nil {
+		return err
+	}
+	count, err := s.repo.CountAccounts(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Hediye hesapları sayılamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("hesap eklenirken bir hata oluştu")
+	}
+	if count >= maxGiftAccountsPerInvitation {
+		return ErrGiftAccountLimit
+	}
+
+	account := &models.InvitationGiftAccount{InvitationID: invitationID}
+	applyGiftAccountInput(account, input)
+	if err := s.repo.CreateAccount(ctx, account); err != nil {
+		logconfig.Log.Error("Hediye hesabı eklenemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("hesap eklenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGiftService) UpdateAccount(ctx context.Context, invitationID, accountID uint, input GiftAccountInput) error {
+	if err := s.checkBank(&input); err != nil {
+		return err
+	}
+	account, err := s.GetAccount(ctx, invitationID, accountID)
+	if err != nil {
+		return err
+	}
+	applyGiftAccountInput(account, input)
+	if err := s.repo.UpdateAccount(ctx, account); err != nil {
+		logconfig.Log.Error("Hediye hesabı güncellenemedi", zap.Uint("account_id", accountID), zap.Error(err))
+		return errors.New("hesap güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGiftService) DeleteAccount(ctx context.Context, invitationID, accountID uint) error {
+	if err := s.repo.DeleteAccount(ctx, invitationID, accountID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrGiftAccountNotFound
+		}
+		logconfig.Log.Error("Hediye hesabı silinemedi", zap.Uint("account_id", accountID), zap.Error(err))
+		return errors.New("hesap silinirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGiftService) ListNotes(ctx context.Context, invitationID uint) ([]models.InvitationGiftNote, error) {
+	notes, err := s.repo.ListNotes(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Hediye notları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("notlar getirilirken bir hata oluştu")
+	}
+	return notes, nil
+}
+
+// SubmitNote, misafirin hediye notunu kaydeder. Kişiye özel bağlantıyla gelen
+// misafirin notu katılımcı kaydına bağlanır; hesap seçimi isteğe bağlıdır.
+func (s *InvitationGiftService) SubmitNote(ctx context.Context, invitation *models.Invitation, guest *models.InvitationParticipant, input GiftNoteInput) error {
+	if !invitation.GiftNotesEnabled {
+		return ErrGiftNotesDisabled
+	}
+
+	note := &models.InvitationGiftNote{
+		InvitationID: invitation.ID,
+		Name:         strings.TrimSpace(input.Name),
+		Note:         strings.TrimSpace(strings.ReplaceAll(input.Note, "\r\n", "\n")),
+	}
+	if guest != nil {
+		note.ParticipantID = &guest.ID
+	}
+	if input.GiftAccountID != 0 {
+		found := false
+		for _, account := range invitation.GiftAccounts {
+			if account.ID == input.GiftAccountID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ErrGiftNoteAccountWrong
+		}
+		note.GiftAccountID = &input.GiftAccountID
+	}
+
+	if err := s.repo.CreateNote(ctx, note); err != nil {
+		logconfig.Log.Error("Hediye notu kaydedilemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return errors.New("notunuz kaydedilirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGiftService) DeleteNote(ctx context.Context, invitationID, noteID uint) error {
+	if err := s.repo.DeleteNote(ctx, invitationID, noteID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrGiftNoteNotFound
+		}
+		logconfig.Log.Error("Hediye notu silinemedi", zap.Uint("note_id", noteID), zap.Error(err))
+		return errors.New("not silinirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGiftService) SetNotesEnabled(ctx context.Context, invitationID uint, enabled bool) error {
+	if err := s.invitationRepo.SetGiftNotesEnabled(ctx, invitationID, enabled); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrInvitationNotFound
+		}
+		logconfig.Log.Error("Hediye notu ayarı güncellenemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("hediye notu ayarı güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+// ExportNotesCSV, hediye notlarını teşekkür listesi hazırlamak için Excel'in
+// açabileceği noktalı virgül ayraçlı bir CSV olarak döndürür.
+func (s *InvitationGiftService) ExportNotesCSV(ctx context.Context, invitation *models.Invitation) ([]byte, error) {
+	notes, err := s.ListNotes(ctx, invitation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+
+	if err := w.Write([]string{"Ad Soyad", "Davetli", "Hesap", "Not", "Gönderim Zamanı"}); err != nil {
+		return nil, err
+	}
+
+	loc := invitation.TimeLocation()
+	for i := range notes {
+		n := &notes[i]
+		guest := ""
+		if n.Participant != nil {
+			guest = n.Participant.Title
+		}
+		account := ""
+		if n.GiftAccount != nil {
+			account = n.GiftAccount.Bank.Name + " - " + n.GiftAccount.AccountHolder
+		}
+		row := []string{
+			n.Name,
+			guest,
+			account,
+			n.Note,
+			n.CreatedAt.In(loc).Format("02.01.2006 15:04"),
+		}
+		for j := range row {
+			row[j] = csvSafe(row[j])
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// checkBank, banka seçilmemişse IBAN'dan bulur ve yalnızca aktif bankalara
+// hesap eklenmesine izin verir.
+func (s *InvitationGiftService) checkBank(input *GiftAccountInput) error {
+	bank, err := resolveIBANBank(s.bankRepo, input.BankID, input.IBAN)
+	if err != nil {
+		return err
+	}
+	if !bank.IsActive {
+		return ErrGiftBankInvalid
+	}
+	input.BankID = bank.ID
+	return nil
+}
+
+func applyGiftAccountInput(account *models.InvitationGiftAccount, input GiftAccountInput) {
+	account.BankID = input.BankID
+	account.IBAN = input.IBAN
+	account.AccountHolder = strings.TrimSpace(input.AccountHolder)
+	account.Description = strings.TrimSpace(input.Description)
+	account.SortOrder = input.SortOrder
+}
+
+var _ IInvitationGiftService = (*InvitationGiftService)(nil)
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/profanity"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+// maxPublicGuestbookEntries, davetiye sayfasında gösterilen en fazla onaylı
+// mesaj sayısıdır.
+const maxPublicGuestbookEntries = 200
+
+type GuestbookInput struct {
+	Name    string
+	Message string
+}
+
+type GuestbookStatusCount struct {
+	Status models.GuestbookStatus
+	Name   string
+	Count  int64
+}
+
+type GuestbookSummary struct {
+	Total    int64
+	Statuses []GuestbookStatusCount
+}
+
+type IInvitationGuestbookService interface {
+	ListEntries(ctx context.Context, invitationID uint, status models.GuestbookStatus) ([]models.InvitationGuestbookEntry, error)
+	Summary(ctx context.Context, invitationID uint) (*GuestbookSummary, error)
+	ApprovedEntries(ctx context.Context, invitationID uint) []models.InvitationGuestbookEntry
+	Submit(ctx context.Context, invitation *models.Invitation, input GuestbookInput) error
+	SetStatus(ctx context.Context, invitationID, entryID uint, status models.GuestbookStatus) error
+	DeleteEntry(ctx context.Context, invitationID, entryID uint) error
+	SetEnabled(ctx context.Context, invitationID uint, enabled bool) error
+	ExportCSV(ctx context.Context, invitation *models.Invitation) ([]byte, error)
+}
+
+const (
+	ErrGuestbookDisabled     ServiceError = "bu davetiyede anı defteri kapalı"
+	ErrGuestbookProfanity    ServiceError = "mesajınız uygunsuz ifadeler içeriyor"
+	ErrGuestbookEntryMissing ServiceError = "mesaj bulunamadı"
+	ErrGuestbookStatus       ServiceError = "geçersiz mesaj durumu"
+)
+
+type InvitationGuestbookService struct {
+	repo           repositories.IInvitationGuestbookRepository
+	invitationRepo repositories.IInvitationRepository
+}
+
+func NewInvitationGuestbookService() IInvitationGuestbookService {
+	return &InvitationGuestbookService{
+		repo:           repositories.NewInvitationGuestbookRepository(),
+		invitationRepo: repositories.NewInvitationRepository(),
+	}
+}
+
+func (s *InvitationGuestbookService) ListEntries(ctx context.Context, invitationID uint, status models.GuestbookStatus) ([]models.InvitationGuestbookEntry, error) {
+	entries, err := s.repo.ListByInvitation(ctx, invitationID, status)
+	if err != nil {
+		logconfig.Log.Error("Anı defteri mesajları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("mesajlar getirilirken bir hata oluştu")
+	}
+	return entries, nil
+}
+
+func (s *InvitationGuestbookService) Summary(ctx context.Context, invitationID uint) (*GuestbookSummary, error) {
+	counts, err := s.repo.CountByStatus(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Anı defteri mesaj sayıları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("mesaj sayıları getirilirken bir hata oluştu")
+	}
+
+	summary := &GuestbookSummary{}
+	for _, status := range models.GuestbookStatuses {
+		summary.Statuses = append(summary.Statuses, GuestbookStatusCount{
+			Status: status,
+			Name:   status.Name(),
+			Count:  counts[status],
+		})
+		summary.Total += counts[status]
+	}
+	return summary, nil
+}
+
+// ApprovedEntries, davetiye sayfasında gösterilecek onaylı mesajları
+// döndürür. Hata durumunda sayfa mesajsız gösterilir.
+func (s *InvitationGuestbookService) ApprovedEntries(ctx context.Context, invitationID uint) []models.InvitationGuestbookEntry {
+	entries, err := s.repo.ListApproved(ctx, invitationID, maxPublicGuestbookEntries)
+	if err != nil {
+		logconfig.Log.Warn("Onaylı anı defteri mesajları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil
+	}
+	return entries
+}
+
+// Submit, ziyaretçi mesajını onay bekleyen olarak kaydeder. Ad veya mesajda
+// listedeki uygunsuz ifadelerden biri geçiyorsa mesaj kabul edilmez.
+func (s *InvitationGuestbookService) Submit(ctx context.Context, invitation *models.Invitation, input GuestbookInput) error {
+	if !invitation.GuestbookEnabled {
+		return ErrGuestbookDisabled
+	}
+	name := strings.TrimSpace(input.Name)
+	message := strings.TrimSpace(strings.ReplaceAll(input.Message, "\r\n", "\n"))
+	if profanity.Contains(name) || profanity.Contains(message) {
+		return ErrGuestbookProfanity
+	}
+
+	entry := &models.InvitationGuestbookEntry{
+		InvitationID: invitation.ID,
+		Name:         name,
+		Message:      message,
+		Status:       models.GuestbookPending,
+	}
+	if err := s.repo.Create(ctx, entry); err != nil {
+		logconfig.Log.Error("Anı defteri mesajı kaydedilemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return errors.New("mesajınız kaydedilirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGuestbookService) SetStatus(ctx context.Context, invitationID, entryID uint, status models.GuestbookStatus) error {
+	if !status.IsValid() {
+		return ErrGuestbookStatus
+	}
+	if err := s.repo.SetStatus(ctx, invitationID, entryID, status, time.Now()); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrGuestbookEntryMissing
+		}
+		logconfig.Log.Error("Anı defteri mesaj durumu güncellenemedi", zap.Uint("entry_id", entryID), zap.Error(err))
+		return errors.New("mesaj güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGuestbookService) DeleteEntry(ctx context.Context, invitationID, entryID uint) error {
+	if err := s.repo.Delete(ctx, invitationID, entryID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrGuestbookEntryMissing
+		}
+		logconfig.Log.Error("Anı defteri mesajı silinemedi", zap.Uint("entry_id", entryID), zap.Error(err))
+		return errors.New("mesaj silinirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGuestbookService) SetEnabled(ctx context.Context, invitationID uint, enabled bool) error {
+	if err := s.invitationRepo.SetGuestbookEnabled(ctx, invitationID, enabled); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrInvitationNotFound
+		}
+		logconfig.Log.Error("Anı defteri ayarı güncellenemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("anı defteri ayarı güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+// ExportCSV, tüm mesajları durumlarıyla birlikte Excel'in açabileceği
+// noktalı virgül ayraçlı bir CSV olarak döndürür.
+func (s *InvitationGuestbookService) ExportCSV(ctx context.Context, invitation *models.Invitation) ([]byte, error) {
+	entries, err := s.ListEntries(ctx, invitation.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+
+	if err := w.Write([]string{"Ad Soyad", "Mesaj", "Durum", "Gönderim Zamanı", "Onay Zamanı"}); err != nil {
+		return nil, err
+	}
+
+	loc := invitation.TimeLocation()
+	for i := range entries {
+		e := &entries[i]
+		approvedAt := ""
+		if e.ApprovedAt != nil {
+			approvedAt = e.ApprovedAt.In(loc).Format("02.01.2006 15:04")
+		}
+		row := []string{
+			e.Name,
+			e.Message,
+			e.StatusName(),
+			e.CreatedAt.In(loc).Format("02.01.2006 15:04"),
+			approvedAt,
+		}
+		for j := range row {
+			row[j] = csvSafe(row[j])
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+var _ IInvitationGuestbookService = (*InvitationGuestbookService)(nil)
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/fileconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/filemanager"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+// MediaContentType, galeri fotoğraflarının yüklendiği uploads alt klasörüdür.
+const MediaContentType = "invitation_media"
+
+// MaxMediaPerUpload, tek seferde yüklenebilecek en fazla fotoğraf sayısıdır.
+const MaxMediaPerUpload = 10
+
+const uploadCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+var uploadCodePattern = regexp.MustCompile(`^[A-Z0-9]{4,32}$`)
+
+// InvitationMediaQuota, bir davetiyenin galerisinde tutulabilecek en fazla
+// fotoğraf sayısıdır; misafir yüklemeleri de bu sınıra dahildir.
+func InvitationMediaQuota() int {
+	return envconfig.GetEnvAsInt("INVITATION_MEDIA_QUOTA", 60)
+}
+
+type MediaUpload struct {
+	FileNames    []string
+	Source       models.MediaSource
+	UploaderName string
+}
+
+type MediaStatusCount struct {
+	Status models.MediaStatus
+	Name   string
+	Count  int64
+}
+
+type MediaSummary struct {
+	Total     int64
+	Quota     int
+	Remaining int
+	Statuses  []MediaStatusCount
+}
+
+type IInvitationMediaService interface {
+	ListMedia(ctx context.Context, invitationID uint, status models.MediaStatus) ([]models.InvitationMedia, error)
+	Summary(ctx context.Context, invitationID uint) (*MediaSummary, error)
+	ApprovedMedia(ctx context.Context, invitationID uint) []models.InvitationMedia
+	CheckUploadCode(invitation *models.Invitation, code string) error
+	AddMedia(ctx context.Context, invitationID uint, upload MediaUpload) error
+	UpdateMedia(ctx context.Context, invitationID, mediaID uint, caption string, sortOrder int) error
+	SetStatus(ctx context.Context, invitationID, mediaID uint, status models.MediaStatus) error
+	DeleteMedia(ctx context.Context, invitationID, mediaID uint) error
+	FileNames(ctx context.Context, invitationID uint) []string
+	UpdateSettings(ctx context.Context, invitationID uint, guestUploads bool, uploadCode string) (string, error)
+	WriteArchive(w io.Writer, media []models.InvitationMedia) error
+}
+
+const (
+	ErrMediaQuotaExceeded ServiceError = "galeri kotası doldu"
+	ErrMediaMissing       ServiceError = "fotoğraf bulunamadı"
+	ErrMediaStatus        ServiceError = "geçersiz fotoğraf durumu"
+	ErrGuestUploadsOff    ServiceError = "bu davetiyede misafir fotoğraf yüklemesi kapalı"
+	ErrUploadCodeInvalid  ServiceError = "yükleme kodu hatalı"
+	ErrUploadCodeFormat   ServiceError = "yükleme kodu 4-32 karakter olmalı ve yalnızca harf ile rakam içermelidir"
+)
+
+type InvitationMediaService struct {
+	repo           repositories.IInvitationMediaRepository
+	invitationRepo repositories.IInvitationRepository
+}
+
+func NewInvitationMediaService() IInvitationMediaService {
+	return &InvitationMediaService{
+		repo:           repositories.NewInvitationMediaRepository(),
+		invitationRepo: repositories.NewInvitationRepository(),
+	}
+}
+
+func (s *InvitationMediaService) ListMedia(ctx context.Context, invitationID uint, status models.MediaStatus) ([]models.InvitationMedia, error) {
+	media, err := s.repo.ListByInvitation(ctx, invitationID, status)
+	if err != nil {
+		logconfig.Log.Error("Galeri fotoğrafları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("fotoğraflar getirilirken bir hata oluştu")
+	}
+	return media, nil
+}
+
+// Summary, durum sayılarını ve kalan kotayı döndürür; kalan kota yükleme
+// formunda ve yükleme öncesi dosya sayısı sınırında kullanılır.
+func (s *InvitationMediaService) Summary(ctx context.Context, invitationID uint) (*MediaSummary, error) {
+	counts, err := s.repo.CountByStatus(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Galeri fotoğraf sayıları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("fotoğraf sayıları getirilirken bir hata oluştu")
+	}
+
+	summary := &MediaSummary{Quota: InvitationMediaQuota()}
+	for _, status := range models.MediaStatuses {
+		summary.Statuses = append(summary.Statuses, MediaStatusCount{
+			Status: status,
+			Name:   status.Name(),
+			Count:  counts[status],
+		})
+		summary.Total += counts[status]
+	}
+	summary.Remaining = MaxMediaPerUpload
+	if summary.Quota > 0 {
+		summary.Remaining = min(MaxMediaPerUpload, max(0, summary.Quota-int(summary.Total)))
+	}
+	return summary, nil
+}
+
+// ApprovedMedia, davetiye sayfasında gösterilecek fotoğrafları döndürür. Hata
+// durumunda sayfa galerisiz gösterilir.
+func (s *InvitationMediaService) ApprovedMedia(ctx context.Context, invitationID uint) []models.InvitationMedia {
+	media, err := s.repo.ListApproved(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Warn("Yayındaki galeri fotoğrafları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil
+	}
+	return media
+}
+
+// CheckUploadCode, misafirin girdiği ortak kodu davetiyenin yükleme koduyla
+// karşılaştırır. Büyük/küçük harf ve boşluklar dikkate alınmaz.
+func (s *InvitationMediaService) CheckUploadCode(invitation *models.Invitation, code string) error {
+	if !invitation.GalleryGuestUploads || invitation.GalleryUploadCode == "" {
+		return ErrGuestUploadsOff
+	}
+	given := normalizeUploadCode(code)
+	if subtle.ConstantTimeCompare([]byte(given), []byte(invitation.GalleryUploadCode)) != 1 {
+		return ErrUploadCodeInvalid
+	}
+	return nil
+}
+
+// AddMedia, yüklenen dosyaları galeriye ekler. Misafir yüklemeleri onay
+// bekleyen olarak kaydedilir. Kayıt başarısız olursa dosyalar diskten silinir.
+func (s *InvitationMediaService) AddMedia(ctx context.Context, invitationID uint, upload MediaUpload) error {
+	status := models.MediaApproved
+	if upload.Source == models.MediaSourceGuest {
+		status = models.MediaPending
+	}
+
+	media := make([]models.InvitationMedia, 0, len(upload.FileNames))
+	for _, fileName := range upload.FileNames {
+		media = append(media, models.InvitationMedia{
+			FileName:     fileName,
+			Source:       upload.Source,
+			UploaderName: strings.TrimSpace(upload.UploaderName),
+			Status:       status,
+		})
+	}
+
+	if err := s.repo.CreateWithinQuota(ctx, invitationID, media, InvitationMediaQuota()); err != nil {
+		for _, fileName := range upload.FileNames {
+			filemanager.DeleteFile(MediaContentType, fileName)
+		}
+		switch {
+		case errors.Is(err, repositories.ErrMediaQuotaExceeded):
+			return ErrMediaQuotaExceeded
+		case errors.Is(err, repositories.ErrNotFound):
+			return ErrInvitationNotFound
+		}
+		logconfig.Log.Error("Galeri fotoğrafları kaydedilemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("fotoğraflar kaydedilirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationMediaService) UpdateMedia(ctx context.Context, invitationID, mediaID uint, caption string, sortOrder int) error {
+	if err := s.repo.Update(ctx, invitationID, mediaID, strings.TrimSpace(caption), sortOrder); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrMediaMissing
+		}
+		logconfig.Log.Error("Galeri fotoğrafı güncellenemedi", zap.Uint("media_id", mediaID), zap.Error(err))
+		return errors.New("fotoğraf güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationMediaService) SetStatus(ctx context.Context, invitationID, mediaID uint, status models.MediaStatus) error {
+	if !status.IsValid() {
+		return ErrMediaStatus
+	}
+	if err := s.repo.SetStatus(ctx, invitationID, mediaID, status); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrMediaMissing
+		}
+		logconfig.Log.Error("Galeri fotoğrafı durumu güncellenemedi", zap.Uint("media_id", mediaID), zap.Error(err))
+		return errors.New("fotoğraf güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+// DeleteMedia, fotoğrafı galeriden kaldırır ve dosyasını diskten siler.
+func (s *InvitationMediaService) DeleteMedia(ctx context.Context, invitationID, mediaID uint) error {
+	media, err := s.repo.FindInInvitation(ctx, invitationID, mediaID)
+	if err == nil {
+		err = s.repo.Delete(ctx, invitationID, mediaID)
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrMediaMissing
+		}
+		logconfig.Log.Error("Galeri fotoğrafı silinemedi", zap.Uint("media_id", mediaID), zap.Error(err))
+		return errors.New("fotoğraf silinirken bir hata oluştu")
+	}
+	filemanager.DeleteFile(MediaContentType, media.FileName)
+	return nil
+}
+
+// FileNames, galerideki dosya adlarını döndürür. Davetiye silinirken satırlar
+// silinmeden önce toplanır; dosyalar yalnızca silme başarılı olursa kaldırılır.
+func (s *InvitationMediaService) FileNames(ctx context.Context, invitationID uint) []string {
+	media, err := s.repo.ListByInvitation(ctx, invitationID, "")
+	if err != nil {
+		logconfig.Log.Warn("Galeri dosyaları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil
+	}
+	fileNames := make([]string, 0, len(media))
+	for _, m := range media {
+		fileNames = append(fileNames, m.FileName)
+	}
+	return fileNames
+}
+
+// UpdateSettings, misafir yüklemesini açar veya kapatır ve kullanılan ortak
+// kodu döndürür. Misafir yüklemesi açılırken kod boş bırakılırsa yeni bir kod
+// üretilir.
+func (s *InvitationMediaService) UpdateSettings(ctx context.Context, invitationID uint, guestUploads bool, uploadCode string) (string, error) {
+	code := normalizeUploadCode(uploadCode)
+	switch {
+	case code != "" && !uploadCodePattern.MatchString(code):
+		return "", ErrUploadCodeFormat
+	case code == "" && guestUploads:
+		generated, err := generateUploadCode()
+		if err != nil {
+			logconfig.Log.Error("Galeri yükleme kodu üretilemedi", zap.Error(err))
+			return "", errors.New("yükleme kodu üretilemedi")
+		}
+		code = generated
+	}
+
+	if err := s.invitationRepo.SetGallerySettings(ctx, invitationID, guestUploads, code); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return "", ErrInvitationNotFound
+		}
+		logconfig.Log.Error("Galeri ayarı güncellenemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return "", errors.New("galeri ayarı güncellenirken bir hata oluştu")
+	}
+	return code, nil
+}
+
+// WriteArchive, fotoğrafları galeri sırasıyla numaralandırarak ZIP arşivi
+// olarak w'ye yazar. Görseller zaten sıkıştırılmış olduğundan dosyalar
+// yeniden sıkıştırılmaz; diskte bulunmayan dosyalar atlanır.
+func (s *InvitationMediaService) WriteArchive(w io.Writer, media []models.InvitationMedia) error {
+	archive := zip.NewWriter(w)
+	for i, m := range media {
+		fileName := filepath.Base(m.FileName)
+		file, err := os.Open(filepath.Join(fileconfig.Config.GetPath(MediaContentType), fileName))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logconfig.Log.Warn("Galeri dosyası açılamadı", zap.String("file", fileName), zap.Error(err))
+			}
+			continue
+		}
+
+		entry, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     fmt.Sprintf("%03d-%s", i+1, fileName),
+			Method:   zip.Store,
+			Modified: m.CreatedAt,
+		})
+		if err == nil {
+			_, err = io.Copy(entry, file)
+		}
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func normalizeUploadCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+}
+
+func generateUploadCode() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i, v := range b {
+		b[i] = uploadCodeAlphabet[int(v)%len(uploadCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+var _ IInvitationMediaService = (*InvitationMediaService)(nil)
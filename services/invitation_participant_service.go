@@ -0,0 +1,478 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/secretconfig"
+	"zatrano/models"
+	"zatrano/pkg/phone"
+	"zatrano/pkg/signer"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxGuestImport, tek seferde içe aktarılabilecek misafir sayısıdır.
+	maxGuestImport = 1000
+	maxGuestCount  = 50
+	guestTokenPart = "guest"
+)
+
+type GuestInput struct {
+	Title       string
+	PhoneNumber string
+	GuestCount  int
+}
+
+type RSVPInput struct {
+	Title       string
+	PhoneNumber string
+	GuestCount  int
+	Attending   bool
+	Answers     models.ParticipantAnswers
+	// EventResponses, etkinlik bazında yanıtlardır; katılmayan misafirler
+	// için saklanmaz.
+	EventResponses []models.InvitationEventResponse
+}
+
+type ParticipantStatusCount struct {
+	Status models.ParticipantStatus
+	Name   string
+	Count  int64
+	Guests int64
+}
+
+// ParticipantSummary, katılımcıların duruma göre dağılımıdır; Statuses tüm
+// durumları (sayısı sıfır olsa da) sabit sırayla içerir.
+type ParticipantSummary struct {
+	Total          int64
+	AcceptedGuests int64
+	Statuses       []ParticipantStatusCount
+}
+
+type IInvitationParticipantService interface {
+	ListParticipants(ctx context.Context, invitationID uint, status models.ParticipantStatus) ([]models.InvitationParticipant, error)
+	Summary(ctx context.Context, invitationID uint) (*ParticipantSummary, error)
+	ParseGuestList(text string) ([]GuestInput, error)
+	AddGuests(ctx context.Context, invitationID uint, guests []GuestInput) (int, error)
+	DeleteParticipant(ctx context.Context, invitationID, participantID uint) error
+	GuestToken(invitation *models.Invitation, participantID uint) string
+	GuestLink(invitation *models.Invitation, participantID uint) string
+	ResolveGuest(ctx context.Context, invitation *models.Invitation, token string) (*models.InvitationParticipant, error)
+	RecordOpen(ctx context.Context, participant *models.InvitationParticipant)
+	Respond(ctx context.Context, invitation *models.Invitation, guest *models.InvitationParticipant, input RSVPInput) (*models.InvitationParticipant, error)
+	Promote(ctx context.Context, invitationID, participantID uint) error
+	SeatsLeft(ctx context.Context, invitation *models.Invitation) (int, bool)
+	ExportCSV(ctx context.Context, invitation *models.Invitation) ([]byte, error)
+}
+
+const (
+	ErrParticipantNotFound ServiceError = "katılımcı bulunamadı"
+	ErrGuestLinkInvalid    ServiceError = "kişiye özel bağlantı geçersiz"
+	ErrGuestListEmpty      ServiceError = "misafir listesi boş"
+	ErrGuestListTooLarge   ServiceError = "misafir listesi çok uzun"
+	ErrRSVPClosed          ServiceError = "bu davetiye için katılım yanıtı alınmıyor"
+	ErrRSVPDeadlinePassed  ServiceError = "katılım yanıtları için son tarih geçti"
+	ErrRSVPFull            ServiceError = "davetiyenin kontenjanı dolu"
+	ErrRSVPDuplicate       ServiceError = "bu telefon numarasıyla daha önce yanıt verilmiş; yanıtınızı değiştirmek için size gönderilen kişiye özel bağlantıyı kullanın"
+	ErrNotWaitlisted       ServiceError = "katılımcı yedek listede değil"
+)
+
+type InvitationParticipantService struct {
+	repo         repositories.IInvitationParticipantRepository
+	questionRepo repositories.IInvitationQuestionRepository
+	eventRepo    repositories.IInvitationEventRepository
+}
+
+func NewInvitationParticipantService() IInvitationParticipantService {
+	return &InvitationParticipantService{
+		repo:         repositories.NewInvitationParticipantRepository(),
+		questionRepo: repositories.NewInvitationQuestionRepository(),
+		eventRepo:    repositories.NewInvitationEventRepository(),
+	}
+}
+
+func (s *InvitationParticipantService) ListParticipants(ctx context.Context, invitationID uint, status models.ParticipantStatus) ([]models.InvitationParticipant, error) {
+	participants, err := s.repo.ListByInvitation(ctx, invitationID, status)
+	if err != nil {
+		logconfig.Log.Error("Katılımcılar alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("katılımcılar getirilirken bir hata oluştu")
+	}
+	return participants, nil
+}
+
+func (s *InvitationParticipantService) Summary(ctx context.Context, invitationID uint) (*ParticipantSummary, error) {
+	counts, err := s.repo.CountByStatus(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Katılımcı sayıları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("katılımcı sayıları getirilirken bir hata oluştu")
+	}
+
+	summary := &ParticipantSummary{AcceptedGuests: counts[models.ParticipantAccepted].Guests}
+	for _, status := range models.ParticipantStatuses {
+		summary.Statuses = append(summary.Statuses, ParticipantStatusCount{
+			Status: status,
+			Name:   status.Name(),
+			Count:  counts[status].Participants,
+			Guests: counts[status].Guests,
+		})
+		summary.Total += counts[status].Participants
+	}
+	return summary, nil
+}
+
+// ParseGuestList, her satırı "Ad Soyad; Telefon; Kişi sayısı" biçiminde okur.
+// Ayraç olarak noktalı virgül, virgül veya sekme kabul edilir; telefon ve
+// kişi sayısı isteğe bağlıdır. Boş satırlar ve # ile başlayanlar atlanır.
+func (s *InvitationParticipantService) ParseGuestList(text string) ([]GuestInput, error) {
+	var guests []GuestInput
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ';' || r == ',' || r == '\t'
+		})
+		for j := range fields {
+			fields[j] = strings.TrimSpace(fields[j])
+		}
+
+		guest := GuestInput{GuestCount: 1}
+		if len(fields) > 0 {
+			guest.Title = fields[0]
+		}
+		if len(fields) > 1 {
+			guest.PhoneNumber = fields[1]
+		}
+		if len(fields) > 2 && fields[2] != "" {
+			count, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("%d. satır: kişi sayısı sayı olmalıdır", i+1)
+			}
+			guest.GuestCount = count
+		}
+		if err := validateGuest(&guest); err != nil {
+			return nil, fmt.Errorf("%d. satır: %w", i+1, err)
+		}
+
+		guests = append(guests, guest)
+		if len(guests) > maxGuestImport {
+			return nil, ErrGuestListTooLarge
+		}
+	}
+
+	if len(guests) == 0 {
+		return nil, ErrGuestListEmpty
+	}
+	return guests, nil
+}
+
+// validateGuest, misafir bilgilerini doğrular ve telefon numarasını E.164
+// biçimine getirir.
+func validateGuest(guest *GuestInput) error {
+	if guest.PhoneNumber != "" {
+		normalized, err := phone.Parse(guest.PhoneNumber)
+		if err != nil {
+			return fmt.Errorf("telefon numarası geçersiz: %w", err)
+		}
+		guest.PhoneNumber = normalized
+	}
+	switch {
+	case utf8.RuneCountInString(guest.Title) < 2:
+		return errors.New("ad soyad en az 2 karakter olmalıdır")
+	case utf8.RuneCountInString(guest.Title) > 255:
+		return errors.New("ad soyad en fazla 255 karakter olabilir")
+	case guest.GuestCount < 1 || guest.GuestCount > maxGuestCount:
+		return fmt.Errorf("kişi sayısı 1 ile %d arasında olmalıdır", maxGuestCount)
+	}
+	return nil
+}
+
+func (s *InvitationParticipantService) AddGuests(ctx context.Context, invitationID uint, guests []GuestInput) (int, error) {
+	if len(guests) == 0 {
+		return 0, ErrGuestListEmpty
+	}
+	if len(guests) > maxGuestImport {
+		return 0, ErrGuestListTooLarge
+	}
+
+	participants := make([]models.InvitationParticipant, 0, len(guests))
+	for _, guest := range guests {
+		if err := validateGuest(&guest); err != nil {
+			return 0, err
+		}
+		participants = append(participants, models.InvitationParticipant{
+			Title:        guest.Title,
+			PhoneNumber:  guest.PhoneNumber,
+			GuestCount:   guest.GuestCount,
+			InvitationID: invitationID,
+			Status:       models.ParticipantInvited,
+		})
+	}
+
+	if err := s.repo.CreateMany(ctx, participants); err != nil {
+		logconfig.Log.Error("Misafirler eklenemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return 0, errors.New("misafirler eklenirken bir hata oluştu")
+	}
+	logconfig.Log.Info("Misafir listesi eklendi", zap.Uint("invitation_id", invitationID), zap.Int("count", len(participants)))
+	return len(participants), nil
+}
+
+func (s *InvitationParticipantService) DeleteParticipant(ctx context.Context, invitationID, participantID uint) error {
+	if err := s.repo.Delete(ctx, invitationID, participantID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrParticipantNotFound
+		}
+		logconfig.Log.Error("Katılımcı silinemedi", zap.Uint("participant_id", participantID), zap.Error(err))
+		return errors.New("katılımcı silinirken bir hata oluştu")
+	}
+	return nil
+}
+
+// GuestToken, katılımcı kimliğini davetiye anahtarına bağlı bir imzayla
+// birlikte döndürür. İmza başka bir davetiyede geçerli olmaz.
+func (s *InvitationParticipantService) GuestToken(invitation *models.Invitation, participantID uint) string {
+	id := strconv.FormatUint(uint64(participantID), 10)
+	return id + "." + signer.Sign(secretconfig.Key(), guestTokenPart, invitation.InvitationKey, id)
+}
+
+func (s *InvitationParticipantService) GuestLink(invitation *models.Invitation, participantID uint) string {
+	baseURL := strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")
+	return baseURL + "/" + invitation.InvitationKey + "?g=" + url.QueryEscape(s.GuestToken(invitation, participantID))
+}
+
+func (s *InvitationParticipantService) ResolveGuest(ctx context.Context, invitation *models.Invitation, token string) (*models.InvitationParticipant, error) {
+	id, signature, ok := strings.Cut(token, ".")
+	if !ok || !signer.Verify(secretconfig.Key(), signature, guestTokenPart, invitation.InvitationKey, id) {
+		return nil, ErrGuestLinkInvalid
+	}
+	participantID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, ErrGuestLinkInvalid
+	}
+
+	participant, err := s.repo.FindInInvitation(ctx, invitation.ID, uint(participantID))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrGuestLinkInvalid
+		}
+		logconfig.Log.Error("Misafir bağlantısı çözümlenemedi", zap.Uint64("participant_id", participantID), zap.Error(err))
+		return nil, err
+	}
+	return participant, nil
+}
+
+// RecordOpen, kişiye özel bağlantının açılışını kaydeder. Hata sayfanın
+// gösterilmesini engellemez.
+func (s *InvitationParticipantService) RecordOpen(ctx context.Context, participant *models.InvitationParticipant) {
+	now := time.Now()
+	if err := s.repo.MarkOpened(ctx, participant.ID, now); err != nil {
+		logconfig.Log.Warn("Davetiye açılışı kaydedilemedi", zap.Uint("participant_id", participant.ID), zap.Error(err))
+		return
+	}
+	if participant.FirstOpenedAt == nil {
+		participant.FirstOpenedAt = &now
+	}
+	participant.LastOpenedAt = &now
+	participant.OpenCount++
+	if participant.Status == models.ParticipantInvited {
+		participant.Status = models.ParticipantOpened
+	}
+}
+
+// Respond, katılım yanıtını kaydeder. Kişiye özel bağlantıyla gelen misafirin
+// kaydı güncellenir; bağlantısız yanıtlar telefon numarası misafir listesinde
+// yoksa yeni katılımcı olarak eklenir. Telefon numarası istek katmanında E.164
+// biçimine getirilmiş olmalıdır.
+// Kapasite kontrolü kayıtla aynı işlemde yapılır; kontenjan doluysa yanıt
+// yedek listeye alınabilir, bu durumda dönen katılımcının durumu
+// "waitlisted" olur.
+func (s *InvitationParticipantService) Respond(ctx context.Context, invitation *models.Invitation, guest *models.InvitationParticipant, input RSVPInput) (*models.InvitationParticipant, error) {
+	now := time.Now()
+	if invitation.RSVPDeadlinePassed(now) {
+		return nil, ErrRSVPDeadlinePassed
+	}
+	if !invitation.RSVPOpenAt(now) {
+		return nil, ErrRSVPClosed
+	}
+	if invitation.MaxGuestsPerRSVP > 0 && input.GuestCount > invitation.MaxGuestsPerRSVP {
+		return nil, fmt.Errorf("bir yanıtta en fazla %d kişi bildirilebilir", invitation.MaxGuestsPerRSVP)
+	}
+
+	status := models.ParticipantDeclined
+	if input.Attending {
+		status = models.ParticipantAccepted
+	}
+
+	participant := guest
+	if participant == nil {
+		participant = &models.InvitationParticipant{InvitationID: invitation.ID}
+	}
+	participant.Title = input.Title
+	participant.PhoneNumber = input.PhoneNumber
+	participant.GuestCount = input.GuestCount
+	participant.Status = status
+	participant.RespondedAt = &now
+	participant.Answers = input.Answers
+	participant.EventResponses = nil
+	if input.Attending {
+		participant.EventResponses = input.EventResponses
+	}
+
+	if err := s.repo.SaveResponse(ctx, participant); err != nil {
+		if errors.Is(err, repositories.ErrCapacityExceeded) {
+			return nil, ErrRSVPFull
+		}
+		if errors.Is(err, repositories.ErrAlreadyResponded) {
+			return nil, ErrRSVPDuplicate
+		}
+		logconfig.Log.Error("Katılım yanıtı kaydedilemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("yanıtınız kaydedilirken bir hata oluştu")
+	}
+	return participant, nil
+}
+
+func (s *InvitationParticipantService) Promote(ctx context.Context, invitationID, participantID uint) error {
+	if err := s.repo.Promote(ctx, invitationID, participantID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNotFound):
+			return ErrNotWaitlisted
+		case errors.Is(err, repositories.ErrCapacityExceeded):
+			return ErrRSVPFull
+		}
+		logconfig.Log.Error("Yedek listedeki katılımcı yükseltilemedi", zap.Uint("participant_id", participantID), zap.Error(err))
+		return errors.New("katılımcı yedek listeden alınırken bir hata oluştu")
+	}
+	return nil
+}
+
+// SeatsLeft, davetiyede kalan kişi kapasitesini döndürür; kapasite sınırı
+// yoksa ikinci değer false olur. Yalnızca bilgi amaçlıdır, sınır kayıt
+// sırasında ayrıca uygulanır.
+func (s *InvitationParticipantService) SeatsLeft(ctx context.Context, invitation *models.Invitation) (int, bool) {
+	if invitation.MaxGuests <= 0 {
+		return 0, false
+	}
+	used, err := s.repo.AcceptedGuests(ctx, invitation.ID)
+	if err != nil {
+		logconfig.Log.Warn("Davetiye doluluğu alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return 0, false
+	}
+	left := invitation.MaxGuests - int(used)
+	if left < 0 {
+		left = 0
+	}
+	return left, true
+}
+
+// ExportCSV, misafir listesini durum, açılma bilgisi, kişiye özel bağlantı ve
+// soru yanıtlarıyla birlikte CSV olarak döndürür. Türkçe Excel ayarlarında
+// doğrudan açılabilmesi için UTF-8 BOM ve noktalı virgül ayracı kullanılır.
+func (s *InvitationParticipantService) ExportCSV(ctx context.Context, invitation *models.Invitation) ([]byte, error) {
+	participants, err := s.repo.ListByInvitation(ctx, invitation.ID, "")
+	if err != nil {
+		logconfig.Log.Error("Dışa aktarılacak katılımcılar alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("katılımcılar getirilirken bir hata oluştu")
+	}
+	questions, err := s.questionRepo.ListByInvitation(ctx, invitation.ID)
+	if err != nil {
+		logconfig.Log.Error("Dışa aktarılacak sorular alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("sorular getirilirken bir hata oluştu")
+	}
+	events, err := s.eventRepo.ListByInvitation(ctx, invitation.ID)
+	if err != nil {
+		logconfig.Log.Error("Dışa aktarılacak etkinlikler alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("etkinlikler getirilirken bir hata oluştu")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+
+	header := []string{"Ad Soyad", "Telefon", "Kişi Sayısı", "Durum", "Yanıt Zamanı", "İlk Açılış", "Son Açılış", "Açılma Sayısı", "Kişiye Özel Bağlantı"}
+	for _, event := range events {
+		if event.RSVPEnabled {
+			header = append(header, event.Name)
+		}
+	}
+	for _, question := range questions {
+		header = append(header, question.Label)
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	loc := invitation.TimeLocation()
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.In(loc).Format("02.01.2006 15:04")
+	}
+	for i := range participants {
+		p := &participants[i]
+		row := []string{
+			p.Title,
+			phone.Format(p.PhoneNumber),
+			strconv.Itoa(p.GuestCount),
+			p.StatusName(),
+			formatTime(p.RespondedAt),
+			formatTime(p.FirstOpenedAt),
+			formatTime(p.LastOpenedAt),
+			strconv.Itoa(p.OpenCount),
+			s.GuestLink(invitation, p.ID),
+		}
+		for _, event := range events {
+			if event.RSVPEnabled {
+				row = append(row, p.EventAnswer(event.ID))
+			}
+		}
+		for _, question := range questions {
+			row = append(row, p.AnswerFor(question))
+		}
+		for j := range row {
+			row[j] = csvSafe(row[j])
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// csvPlainNumber, formül olarak yorumlanamayan telefon ve sayı değerleridir.
+var csvPlainNumber = regexp.MustCompile(`^\+?[0-9 ]+$`)
+
+// csvSafe, misafirlerin girdiği metinlerin tablolama programlarında formül
+// olarak çalışmasını önler. Yalnızca tamamı "+90 532..." gibi telefon veya
+// sayı olan değerler değiştirilmez; "-1+1+cmd|..." gibi değerler kaçırılır.
+func csvSafe(value string) string {
+	if value == "" || csvPlainNumber.MatchString(value) {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
+
+var _ IInvitationParticipantService = (*InvitationParticipantService)(nil)
@@ -0,0 +1,34 @@
+package services
+
+import "testing"
+
+func TestCSVSafe(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"boş", "", ""},
+		{"düz metin", "Ayşe Yılmaz", "Ayşe Yılmaz"},
+		{"E.164 telefon", "+905321234567", "+905321234567"},
+		{"boşluklu telefon", "+90 532 123 45 67", "+90 532 123 45 67"},
+		{"sayı", "3", "3"},
+		{"eşittir", "=SUM(A1:A2)", "'=SUM(A1:A2)"},
+		{"artı formül", "+1+HYPERLINK(\"http://kotu.example\")", "'+1+HYPERLINK(\"http://kotu.example\")"},
+		{"eksi formül", "-1+1+cmd|' /C calc'!A0", "'-1+1+cmd|' /C calc'!A0"},
+		{"artı harf", "+cmd", "'+cmd"},
+		{"eksi sayı", "-5", "'-5"},
+		{"yalnız artı", "+", "'+"},
+		{"at", "@SUM(1)", "'@SUM(1)"},
+		{"sekme", "\t=1", "'\t=1"},
+		{"satır başı", "\r=1", "'\r=1"},
+		{"ortada eşittir", "Ayşe = Ali", "Ayşe = Ali"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := csvSafe(tt.input); got != tt.want {
+				t.Errorf("csvSafe(%q) = %q, beklenen %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	maxQuestionsPerInvitation = 20
+	maxQuestionOptions        = 20
+	maxQuestionOptionLength   = 100
+	maxTextAnswerLength       = 1000
+)
+
+type QuestionInput struct {
+	Label      string
+	Type       models.QuestionType
+	Options    string
+	IsRequired bool
+	SortOrder  int
+}
+
+// QuestionField, katılım formunda bir sorunun mevcut yanıtla birlikte
+// gösterilmesi için kullanılır.
+type QuestionField struct {
+	models.InvitationQuestion
+	Value    string
+	Selected map[string]bool
+}
+
+type IInvitationQuestionService interface {
+	ListQuestions(ctx context.Context, invitationID uint) ([]models.InvitationQuestion, error)
+	GetQuestion(ctx context.Context, invitationID, questionID uint) (*models.InvitationQuestion, error)
+	CreateQuestion(ctx context.Context, invitationID uint, input QuestionInput) error
+	UpdateQuestion(ctx context.Context, invitationID, questionID uint, input QuestionInput) error
+	DeleteQuestion(ctx context.Context, invitationID, questionID uint) error
+	ValidateAnswers(questions []models.InvitationQuestion, raw map[string][]string, enforceRequired bool) (models.ParticipantAnswers, error)
+	FormFields(questions []models.InvitationQuestion, answers models.ParticipantAnswers) []QuestionField
+}
+
+const (
+	ErrQuestionNotFound     ServiceError = "soru bulunamadı"
+	ErrQuestionLimitReached ServiceError = "bir davetiyeye en fazla 20 soru eklenebilir"
+)
+
+type InvitationQuestionService struct {
+	repo repositories.IInvitationQuestionRepository
+}
+
+func NewInvitationQuestionService() IInvitationQuestionService {
+	return &InvitationQuestionService{repo: repositories.NewInvitationQuestionRepository()}
+}
+
+func (s *InvitationQuestionService) ListQuestions(ctx context.Context, invitationID uint) ([]models.InvitationQuestion, error) {
+	questions, err := s.repo.ListByInvitation(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Davetiye soruları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("sorular getirilirken bir hata oluştu")
+	}
+	return questions, nil
+}
+
+func (s *InvitationQuestionService) GetQuestion(ctx context.Context, invitationID, questionID uint) (*models.InvitationQuestion, error) {
+	question, err := s.repo.FindInInvitation(ctx, invitationID, questionID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrQuestionNotFound
+		}
+		logconfig.Log.Error("Davetiye sorusu alınamadı", zap.Uint("question_id", questionID), zap.Error(err))
+		return nil, errors.New("soru getirilirken bir hata oluştu")
+	}
+	return question, nil
+}
+
+func (s *InvitationQuestionService) CreateQuestion(ctx context.Context, invitationID uint, input QuestionInput) error {
+	count, err := s.repo.CountByInvitation(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Davetiye soruları sayılamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("soru eklenirken bir hata oluştu")
+	}
+	if count >= maxQuestionsPerInvitation {
+		return ErrQuestionLimitReached
+	}
+
+	question := &models.InvitationQuestion{InvitationID: invitationID}
+	if err := applyQuestionInput(question, input); err != nil {
+		return err
+	}
+	if err := s.repo.Create(ctx, question); err != nil {
+		logconfig.Log.Error("Davetiye sorusu eklenemedi", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("soru eklenirken bir hata oluştu")
+	}
+	return nil
+}
+
+// UpdateQuestion, soruyu günceller. Verilmiş yanıtlar silinmez; tipi
+// değişen sorularda eski yanıtlar olduğu gibi gösterilmeye devam eder.
+func (s *InvitationQuestionService) UpdateQuestion(ctx context.Context, invitationID, questionID uint, input QuestionInput) error {
+	question, err := s.GetQuestion(ctx, invitationID, questionID)
+	if err != nil {
+		return err
+	}
+	if err := applyQuestionInput(question, input); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, question); err != nil {
+		logconfig.Log.Error("Davetiye sorusu güncellenemedi", zap.Uint("question_id", questionID), zap.Error(err))
+		return errors.New("soru güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationQuestionService) DeleteQuestion(ctx context.Context, invitationID, questionID uint) error {
+	if err := s.repo.Delete(ctx, invitationID, questionID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrQuestionNotFound
+		}
+		logconfig.Log.Error("Davetiye sorusu silinemedi", zap.Uint("question_id", questionID), zap.Error(err))
+		return errors.New("soru silinirken bir hata oluştu")
+	}
+	return nil
+}
+
+// applyQuestionInput, seçenekleri satır satır ayırır; yalnızca seçimli
+// sorularda saklanır ve en az iki farklı seçenek gerekir.
+func applyQuestionInput(question *models.InvitationQuestion, input QuestionInput) error {
+	var options models.StringList
+	if input.Type.HasOptions() {
+		seen := make(map[string]bool)
+		for _, line := range strings.Split(input.Options, "\n") {
+			option := strings.TrimSpace(line)
+			if option == "" || seen[option] {
+				continue
+			}
+			if utf8.RuneCountInString(option) > maxQuestionOptionLength {
+				return fmt.Errorf("seçenekler en fazla %d karakter olabilir", maxQuestionOptionLength)
+			}
+			seen[option] = true
+			options = append(options, option)
+		}
+		if len(options) < 2 {
+			return errors.New("seçimli sorular için en az iki seçenek girilmelidir")
+		}
+		if len(options) > maxQuestionOptions {
+			return fmt.Errorf("bir soruya en fazla %d seçenek eklenebilir", maxQuestionOptions)
+		}
+	}
+
+	question.Label = strings.TrimSpace(input.Label)
+	question.Type = input.Type
+	question.Options = options
+	question.IsRequired = input.IsRequired
+	question.SortOrder = input.SortOrder
+	return nil
+}
+
+// ValidateAnswers, formdan gelen ham değerleri (anahtar soru ID'si) soru
+// tiplerine göre doğrular ve saklanacak yanıtları döndürür. enforceRequired
+// false ise (ör. katılmayacağını bildiren misafir) zorunlu sorular boş
+// bırakılabilir. Boş yanıtlar saklanmaz.
+func (s *InvitationQuestionService) ValidateAnswers(questions []models.InvitationQuestion, raw map[string][]string, enforceRequired bool) (models.ParticipantAnswers, error) {
+	answers := make(models.ParticipantAnswers)
+	for _, question := range questions {
+		var values []string
+		for _, value := range raw[question.AnswerKey()] {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+
+		if len(values) == 0 {
+			if question.IsRequired && enforceRequired {
+				return nil, fmt.Errorf("%q sorusu zorunludur", question.Label)
+			}
+			continue
+		}
+
+		answer, err := parseAnswer(question, values)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", question.Label, err)
+		}
+		answers[question.AnswerKey()] = answer
+	}
+	return answers, nil
+}
+
+func parseAnswer(question models.InvitationQuestion, values []string) (interface{}, error) {
+	switch question.Type {
+	case models.QuestionText:
+		if utf8.RuneCountInString(values[0]) > maxTextAnswerLength {
+			return nil, fmt.Errorf("yanıt en fazla %d karakter olabilir", maxTextAnswerLength)
+		}
+		return values[0], nil
+	case models.QuestionNumber:
+		number, err := strconv.ParseFloat(strings.ReplaceAll(values[0], ",", "."), 64)
+		if err != nil {
+			return nil, errors.New("yanıt bir sayı olmalıdır")
+		}
+		return number, nil
+	case models.QuestionBoolean:
+		switch values[0] {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, errors.New("geçersiz bir değer seçildi")
+	case models.QuestionSingleChoice:
+		if !hasOption(question.Options, values[0]) {
+			return nil, errors.New("geçersiz bir seçenek seçildi")
+		}
+		return values[0], nil
+	case models.QuestionMultiChoice:
+		// Seçimler soru seçeneklerinin sırasıyla saklanır.
+		chosen := make(map[string]bool, len(values))
+		for _, value := range values {
+			if !hasOption(question.Options, value) {
+				return nil, errors.New("geçersiz bir seçenek seçildi")
+			}
+			chosen[value] = true
+		}
+		selected := make([]string, 0, len(chosen))
+		for _, option := range question.Options {
+			if chosen[option] {
+				selected = append(selected, option)
+			}
+		}
+		return selected, nil
+	default:
+		return nil, errors.New("desteklenmeyen soru tipi")
+	}
+}
+
+func hasOption(options models.StringList, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *InvitationQuestionService) FormFields(questions []models.InvitationQuestion, answers models.ParticipantAnswers) []QuestionField {
+	fields := make([]QuestionField, 0, len(questions))
+	for _, question := range questions {
+		field := QuestionField{InvitationQuestion: question, Selected: make(map[string]bool)}
+		switch value := answers[question.AnswerKey()].(type) {
+		case nil:
+		case bool:
+			field.Value = strconv.FormatBool(value)
+		case []interface{}:
+			for _, item := range value {
+				if option, ok := item.(string); ok {
+					field.Selected[option] = true
+				}
+			}
+		case []string:
+			for _, option := range value {
+				field.Selected[option] = true
+			}
+		default:
+			field.Value = question.FormatAnswer(value)
+		}
+		if question.Type == models.QuestionSingleChoice && field.Value != "" {
+			field.Selected[field.Value] = true
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+var _ IInvitationQuestionService = (*InvitationQuestionService)(nil)
@@ -4,6 +4,9 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"time"
+
+	"zatrano/configs/envconfig"
 	"zatrano/configs/logconfig"
 	"zatrano/models"
 	"zatrano/pkg/queryparams"
@@ -15,11 +18,24 @@ import (
 type IInvitationService interface {
 	GetAllInvitations(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
 	GetInvitationByID(id uint) (*models.Invitation, error)
+	GetInvitationForUser(id, userID uint) (*models.Invitation, error)
 	GetInvitationByKey(ctx context.Context, key string) (*models.Invitation, error) // YENİ METOT
 	CreateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error
 	UpdateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error
 	DeleteInvitationWithRelations(ctx context.Context, id uint) error
 	GetInvitationCount() (int64, error)
+	GetPublicInvitation(ctx context.Context, key string) (*models.Invitation, models.InvitationState, error)
+	ArchiveEnded(ctx context.Context) (int64, error)
+}
+
+const (
+	ErrInvitationNotFound ServiceError = "davetiye bulunamadı"
+)
+
+// InvitationArchiveAfter, etkinlikten kaç gün sonra davetiyenin arşivleneceğini
+// belirtir.
+func InvitationArchiveAfter() time.Duration {
+	return time.Duration(envconfig.GetEnvAsInt("INVITATION_ARCHIVE_AFTER_DAYS", 30)) * 24 * time.Hour
 }
 
 type InvitationService struct {
@@ -52,6 +68,24 @@ func (s *InvitationService) GetInvitationByID(id uint) (*models.Invitation, erro
 	return invitation, nil
 }
 
+// GetInvitationForUser, davetiyeyi yalnızca sahibine döndürür. Başka
+// kullanıcının davetiyesi de ErrInvitationNotFound ile reddedilir.
+func (s *InvitationService) GetInvitationForUser(id, userID uint) (*models.Invitation, error) {
+	if userID == 0 {
+		return nil, ErrInvitationNotFound
+	}
+	invitation, err := s.repo.GetInvitationByIDForUser(id, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Warn("Davetiye kullanıcıya ait değil veya bulunamadı", zap.Uint("id", id), zap.Uint("user_id", userID))
+			return nil, ErrInvitationNotFound
+		}
+		logconfig.Log.Error("Davetiye alınamadı", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.New("davetiye getirilirken bir veritabanı hatası oluştu")
+	}
+	return invitation, nil
+}
+
 func (s *InvitationService) GetInvitationByKey(ctx context.Context, key string) (*models.Invitation, error) {
 	invitation, err := s.repo.GetByInvitationKey(ctx, key)
 	if err != nil {
@@ -89,6 +123,37 @@ func (s *InvitationService) GetInvitationCount() (int64, error) {
 	return s.repo.GetInvitationCount()
 }
 
+// GetPublicInvitation, herkese açık sayfa için davetiyeyi ve yayın durumunu
+// döndürür. Yayın zamanı gelmemiş davetiyeler bulunamadı sayılır.
+func (s *InvitationService) GetPublicInvitation(ctx context.Context, key string) (*models.Invitation, models.InvitationState, error) {
+	invitation, err := s.repo.GetByInvitationKey(ctx, key)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Error("Davetiye sorgulanamadı", zap.String("key", key), zap.Error(err))
+		}
+		return nil, "", ErrInvitationNotFound
+	}
+
+	state := invitation.StateAt(time.Now())
+	if state == models.InvitationScheduled {
+		return nil, state, ErrInvitationNotFound
+	}
+	return invitation, state, nil
+}
+
+func (s *InvitationService) ArchiveEnded(ctx context.Context) (int64, error) {
+	now := time.Now().UTC()
+	count, err := s.repo.ArchiveEndedBefore(ctx, now.Add(-InvitationArchiveAfter()), now)
+	if err != nil {
+		logconfig.Log.Error("Sona eren davetiyeler arşivlenemedi", zap.Error(err))
+		return 0, err
+	}
+	if count > 0 {
+		logconfig.Log.Info("Sona eren davetiyeler arşivlendi", zap.Int64("count", count))
+	}
+	return count, nil
+}
+
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
 func generateInvitationKey(n int) string {
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrAccountLocked    ServiceError = "hesap geçici olarak kilitlendi"
+	ErrLoginThrottled   ServiceError = "çok fazla başarısız giriş denemesi"
+	ErrTooManyRequests  ServiceError = "çok fazla istek gönderildi"
+	ErrThrottleNotFound ServiceError = "kilit kaydı bulunamadı"
+)
+
+const maxLoginThrottleStep = 16
+
+type ILoginProtectionService interface {
+	CheckLogin(email, ip string) error
+	RegisterLoginFailure(email, ip string)
+	RegisterLoginSuccess(email string)
+	CheckTwoFactor(email string) error
+	RegisterTwoFactorFailure(email string)
+	RegisterTwoFactorSuccess(email string)
+	ThrottleMail(scope models.AuthThrottleScope, email string) error
+	GetLockedEntries() ([]models.AuthThrottle, error)
+	Unlock(ctx context.Context, id uint) error
+}
+
+type loginProtectionPolicy struct {
+	freeAttempts    int
+	baseDelay       time.Duration
+	maxDelay        time.Duration
+	window          time.Duration
+	lockThreshold   int
+	lockDuration    time.Duration
+	ipLockThreshold int
+	mailMax         int
+	mailWindow      time.Duration
+	// İki adımlı doğrulama sayacı parolalı girişte sıfırlanmadığından daha
+	// uzun bir pencerede tutulur.
+	twoFactorLockThreshold int
+	twoFactorWindow        time.Duration
+}
+
+func loadLoginProtectionPolicy() loginProtectionPolicy {
+	return loginProtectionPolicy{
+		freeAttempts:    envconfig.GetEnvAsInt("LOGIN_FREE_ATTEMPTS", 3),
+		baseDelay:       time.Duration(envconfig.GetEnvAsInt("LOGIN_BASE_DELAY_SECONDS", 2)) * time.Second,
+		maxDelay:        time.Duration(envconfig.GetEnvAsInt("LOGIN_MAX_DELAY_SECONDS", 300)) * time.Second,
+		window:          time.Duration(envconfig.GetEnvAsInt("LOGIN_ATTEMPT_WINDOW_MINUTES", 15)) * time.Minute,
+		lockThreshold:   envconfig.GetEnvAsInt("LOGIN_LOCKOUT_THRESHOLD", 10),
+		lockDuration:    time.Duration(envconfig.GetEnvAsInt("LOGIN_LOCKOUT_MINUTES", 30)) * time.Minute,
+		ipLockThreshold: envconfig.GetEnvAsInt("LOGIN_IP_LOCKOUT_THRESHOLD", 50),
+		mailMax:         envconfig.GetEnvAsInt("AUTH_MAIL_THROTTLE_MAX", 3),
+		mailWindow:      time.Duration(envconfig.GetEnvAsInt("AUTH_MAIL_THROTTLE_WINDOW_MINUTES", 15)) * time.Minute,
+
+		twoFactorLockThreshold: envconfig.GetEnvAsInt("TWO_FACTOR_LOCKOUT_THRESHOLD", 5),
+		twoFactorWindow:        time.Duration(envconfig.GetEnvAsInt("TWO_FACTOR_ATTEMPT_WINDOW_MINUTES", 1440)) * time.Minute,
+	}
+}
+
+// delayFor, ücretsiz deneme hakkı aşıldıktan sonra her başarısız denemede
+// ikiye katlanan bekleme süresini döner.
+func (p loginProtectionPolicy) delayFor(attempts int) time.Duration {
+	step := attempts - p.freeAttempts
+	if step <= 0 {
+		return 0
+	}
+	if step > maxLoginThrottleStep {
+		step = maxLoginThrottleStep
+	}
+	delay := p.baseDelay << (step - 1)
+	if delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+	return delay
+}
+
+type LoginProtectionService struct {
+	repo        repositories.IAuthThrottleRepository
+	authRepo    repositories.IAuthRepository
+	mailService IMailService
+	policy      loginProtectionPolicy
+}
+
+func NewLoginProtectionService() ILoginProtectionService {
+	return &LoginProtectionService{
+		repo:        repositories.NewAuthThrottleRepository(),
+		authRepo:    repositories.NewAuthRepository(),
+		mailService: NewMailService(),
+		policy:      loadLoginProtectionPolicy(),
+	}
+}
+
+func normalizeThrottleEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (s *LoginProtectionService) find(scope models.AuthThrottleScope, identifier string) (*models.AuthThrottle, error) {
+	throttle, err := s.repo.Find(scope, identifier)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return throttle, nil
+}
+
+func (s *LoginProtectionService) CheckLogin(email, ip string) error {
+	now := time.Now()
+
+	throttle, err := s.find(models.ThrottleScopeLoginEmail, normalizeThrottleEmail(email))
+	if err != nil {
+		return ErrAuthGeneric
+	}
+	if throttle != nil {
+		if throttle.IsLocked(now) {
+			return ErrAccountLocked
+		}
+		if now.Before(throttle.LastAttemptAt.Add(s.policy.delayFor(throttle.Attempts))) {
+			return ErrLoginThrottled
+		}
+	}
+
+	if err := s.CheckTwoFactor(email); err != nil {
+		return err
+	}
+
+	if ip == "" {
+		return nil
+	}
+	throttle, err = s.find(models.ThrottleScopeLoginIP, ip)
+	if err != nil {
+		return ErrAuthGeneric
+	}
+	if throttle != nil && throttle.IsLocked(now) {
+		s.logWarn("IP adresi kilitli olduğu için giriş reddedildi", zap.String("ip", ip))
+		return ErrLoginThrottled
+	}
+	return nil
+}
+
+func (s *LoginProtectionService) RegisterLoginFailure(email, ip string) {
+	ctx := context.Background()
+	now := time.Now()
+	identifier := normalizeThrottleEmail(email)
+
+	throttle, err := s.repo.Hit(ctx, models.ThrottleScopeLoginEmail, identifier, now, s.policy.window)
+	if err == nil && throttle.Attempts >= s.policy.lockThreshold && throttle.LockedUntil == nil {
+		lockedUntil := now.Add(s.policy.lockDuration)
+		if err := s.repo.Lock(ctx, throttle.ID, lockedUntil); err == nil {
+			s.logWarn("Hesap geçici olarak kilitlendi",
+				zap.String("email", identifier),
+				zap.Int("attempts", throttle.Attempts),
+				zap.Time("locked_until", lockedUntil),
+			)
+			s.sendLockoutNotice(email, lockedUntil)
+		}
+	}
+
+	if ip == "" {
+		return
+	}
+	throttle, err = s.repo.Hit(ctx, models.ThrottleScopeLoginIP, ip, now, s.policy.window)
+	if err == nil && throttle.Attempts >= s.policy.ipLockThreshold && throttle.LockedUntil == nil {
+		lockedUntil := now.Add(s.policy.lockDuration)
+		if err := s.repo.Lock(ctx, throttle.ID, lockedUntil); err == nil {
+			s.logWarn("IP adresi geçici olarak kilitlendi",
+				zap.String("ip", ip),
+				zap.Int("attempts", throttle.Attempts),
+				zap.Time("locked_until", lockedUntil),
+			)
+		}
+	}
+}
+
+func (s *LoginProtectionService) RegisterLoginSuccess(email string) {
+	_ = s.repo.Reset(context.Background(), models.ThrottleScopeLoginEmail, normalizeThrottleEmail(email))
+}
+
+// CheckTwoFactor, hesabın hatalı iki adımlı doğrulama kodları nedeniyle
+// kilitli olup olmadığını kontrol eder. Kilit sürerken parolalı giriş de
+// reddedilir.
+func (s *LoginProtectionService) CheckTwoFactor(email string) error {
+	throttle, err := s.find(models.ThrottleScopeTwoFactor, normalizeThrottleEmail(email))
+	if err != nil {
+		return ErrAuthGeneric
+	}
+	if throttle != nil && throttle.IsLocked(time.Now()) {
+		return ErrAccountLocked
+	}
+	return nil
+}
+
+// RegisterTwoFactorFailure, hatalı iki adımlı doğrulama kodunu hesap bazında
+// sayar. Sayaç yeni parolalı girişte sıfırlanmaz; böylece parolayı bilen biri
+// tekrar giriş yaparak deneme hakkını yenileyemez.
+func (s *LoginProtectionService) RegisterTwoFactorFailure(email string) {
+	ctx := context.Background()
+	now := time.Now()
+	identifier := normalizeThrottleEmail(email)
+
+	throttle, err := s.repo.Hit(ctx, models.ThrottleScopeTwoFactor, identifier, now, s.policy.twoFactorWindow)
+	if err != nil || throttle.Attempts < s.policy.twoFactorLockThreshold || throttle.LockedUntil != nil {
+		return
+	}
+	lockedUntil := now.Add(s.policy.lockDuration)
+	if err := s.repo.Lock(ctx, throttle.ID, lockedUntil); err == nil {
+		s.logWarn("Hatalı iki adımlı doğrulama kodları nedeniyle hesap kilitlendi",
+			zap.String("email", identifier),
+			zap.Int("attempts", throttle.Attempts),
+			zap.Time("locked_until", lockedUntil),
+		)
+		s.sendTwoFactorLockoutNotice(email, lockedUntil)
+	}
+}
+
+// RegisterTwoFactorSuccess, doğru TOTP kodundan sonra sayacı sıfırlar.
+func (s *LoginProtectionService) RegisterTwoFactorSuccess(email string) {
+	_ = s.repo.Reset(context.Background(), models.ThrottleScopeTwoFactor, normalizeThrottleEmail(email))
+}
+
+func (s *LoginProtectionService) ThrottleMail(scope models.AuthThrottleScope, email string) error {
+	email = normalizeThrottleEmail(email)
+	throttle, err := s.repo.Hit(context.Background(), scope, email, time.Now(), s.policy.mailWindow)
+	if err != nil {
+		return ErrAuthGeneric
+	}
+	if throttle.Attempts > s.policy.mailMax {
+		s.logWarn("Email gönderim sınırı aşıldı",
+			zap.String("scope", string(scope)),
+			zap.String("email", email),
+		)
+		return ErrTooManyRequests
+	}
+	return nil
+}
+
+func (s *LoginProtectionService) GetLockedEntries() ([]models.AuthThrottle, error) {
+	return s.repo.FindLocked(time.Now())
+}
+
+func (s *LoginProtectionService) Unlock(ctx context.Context, id uint) error {
+	throttle, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrThrottleNotFound
+		}
+		return err
+	}
+	if err := s.repo.DeleteByID(ctx, throttle.ID); err != nil {
+		return err
+	}
+	logconfig.Log.Info("Kilit kaldırıldı",
+		zap.String("scope", string(throttle.Scope)),
+		zap.String("identifier", throttle.Identifier),
+	)
+	return nil
+}
+
+func (s *LoginProtectionService) sendLockoutNotice(email string, lockedUntil time.Time) {
+	user, err := s.authRepo.FindUserByEmail(email)
+	if err != nil {
+		return
+	}
+
+	body := fmt.Sprintf(
+		"Hesabınıza art arda çok sayıda başarısız giriş denemesi yapıldığı için hesabınız %s saatine kadar kilitlendi. "+
+			"Bu denemeleri siz yapmadıysanız şifrenizi sıfırlamanızı öneririz.",
+		lockedUntil.Format("02.01.2006 15:04"),
+	)
+	if err := s.mailService.SendMail(user.Email, "Hesabınız Geçici Olarak Kilitlendi", body); err != nil {
+		s.logWarn("Kilit bildirim emaili gönderilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+}
+
+func (s *LoginProtectionService) sendTwoFactorLockoutNotice(email string, lockedUntil time.Time) {
+	user, err := s.authRepo.FindUserByEmail(email)
+	if err != nil {
+		return
+	}
+
+	body := fmt.Sprintf(
+		"Hesabınıza doğru şifreyle giriş yapıldı ancak iki adımlı doğrulama kodu art arda hatalı girildi. "+
+			"Hesabınız %s saatine kadar kilitlendi. Bu denemeleri siz yapmadıysanız şifreniz başkasının elinde olabilir; "+
+			"lütfen şifrenizi hemen değiştirin.",
+		lockedUntil.Format("02.01.2006 15:04"),
+	)
+	if err := s.mailService.SendMail(user.Email, "Hesabınız Geçici Olarak Kilitlendi", body); err != nil {
+		s.logWarn("Kilit bildirim emaili gönderilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+}
+
+func (s *LoginProtectionService) logWarn(action string, fields ...zap.Field) {
+	logconfig.Log.Warn(action, fields...)
+}
+
+var _ ILoginProtectionService = (*LoginProtectionService)(nil)
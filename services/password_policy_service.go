@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/passwordconfig"
+	"zatrano/models"
+	"zatrano/pkg/passwordpolicy"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordPolicyError, şifre politikası ihlallerini kullanıcıya gösterilecek
+// mesajlarla birlikte taşır.
+type PasswordPolicyError struct {
+	Violations []passwordpolicy.Violation
+	Messages   []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return strings.Join(e.Messages, " ")
+}
+
+type IPasswordPolicyService interface {
+	Validate(user *models.User, password string) error
+	Remember(ctx context.Context, userID uint, passwordHash string) error
+	Requirements() []string
+}
+
+type PasswordPolicyService struct {
+	repo   repositories.IPasswordHistoryRepository
+	policy func() passwordpolicy.Policy
+}
+
+func NewPasswordPolicyService() IPasswordPolicyService {
+	return &PasswordPolicyService{
+		repo:   repositories.NewPasswordHistoryRepository(),
+		policy: passwordconfig.GetPolicy,
+	}
+}
+
+// Validate, şifreyi politika kurallarına göre denetler. Kayıtlı kullanıcılar
+// için mevcut şifre ve son HistorySize şifre ile aynı olmaması da aranır.
+func (s *PasswordPolicyService) Validate(user *models.User, password string) error {
+	policy := s.policy()
+	violations := policy.Check(password, user.Email, user.Name)
+
+	if len(violations) == 0 && user.ID != 0 {
+		if v, ok := s.checkReuse(policy, user, password); ok {
+			violations = append(violations, v)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(violations))
+	for _, v := range violations {
+		messages = append(messages, policy.Message(v))
+	}
+	logconfig.Log.Info("Şifre politikası ihlali",
+		zap.Uint("user_id", user.ID),
+		zap.Any("violations", violations),
+	)
+	return &PasswordPolicyError{Violations: violations, Messages: messages}
+}
+
+func (s *PasswordPolicyService) checkReuse(policy passwordpolicy.Policy, user *models.User, password string) (passwordpolicy.Violation, bool) {
+	if user.Password != "" && bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) == nil {
+		return passwordpolicy.ViolationSameAsCurrent, true
+	}
+	if policy.HistorySize <= 0 {
+		return "", false
+	}
+
+	entries, err := s.repo.ListRecent(user.ID, policy.HistorySize)
+	if err != nil {
+		return "", false
+	}
+	for _, entry := range entries {
+		if bcrypt.CompareHashAndPassword([]byte(entry.PasswordHash), []byte(password)) == nil {
+			return passwordpolicy.ViolationRecentlyUsed, true
+		}
+	}
+	return "", false
+}
+
+// Remember, yeni belirlenen şifrenin özetini geçmişe ekler ve politika
+// sınırını aşan eski kayıtları siler.
+func (s *PasswordPolicyService) Remember(ctx context.Context, userID uint, passwordHash string) error {
+	policy := s.policy()
+	if policy.HistorySize <= 0 || passwordHash == "" {
+		return nil
+	}
+
+	if err := s.repo.Create(ctx, &models.PasswordHistory{UserID: userID, PasswordHash: passwordHash}); err != nil {
+		return err
+	}
+	return s.repo.Prune(ctx, userID, policy.HistorySize)
+}
+
+func (s *PasswordPolicyService) Requirements() []string {
+	return s.policy().Requirements()
+}
+
+var _ IPasswordPolicyService = (*PasswordPolicyService)(nil)
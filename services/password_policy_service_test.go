@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/passwordpolicy"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakePasswordHistoryRepository, şifre geçmişini eskiden yeniye bellekte tutar.
+type fakePasswordHistoryRepository struct {
+	entries []models.PasswordHistory
+}
+
+func (r *fakePasswordHistoryRepository) Create(_ context.Context, entry *models.PasswordHistory) error {
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *fakePasswordHistoryRepository) ListRecent(userID uint, limit int) ([]models.PasswordHistory, error) {
+	var recent []models.PasswordHistory
+	for i := len(r.entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		if r.entries[i].UserID == userID {
+			recent = append(recent, r.entries[i])
+		}
+	}
+	return recent, nil
+}
+
+func (r *fakePasswordHistoryRepository) Prune(_ context.Context, userID uint, keep int) error {
+	recent, _ := r.ListRecent(userID, keep)
+	kept := make([]models.PasswordHistory, 0, len(r.entries))
+	for _, entry := range r.entries {
+		if entry.UserID != userID {
+			kept = append(kept, entry)
+		}
+	}
+	for i := len(recent) - 1; i >= 0; i-- {
+		kept = append(kept, recent[i])
+	}
+	r.entries = kept
+	return nil
+}
+
+func testPasswordHash(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt hata = %v", err)
+	}
+	return string(hash)
+}
+
+func newTestPasswordPolicyService(repo *fakePasswordHistoryRepository, historySize int) *PasswordPolicyService {
+	policy := passwordpolicy.Policy{MinLength: 8, RequireDigit: true, ForbidPersonalInfo: true, CheckCommon: true, HistorySize: historySize}
+	return &PasswordPolicyService{
+		repo:   repo,
+		policy: func() passwordpolicy.Policy { return policy },
+	}
+}
+
+func policyViolations(err error) []passwordpolicy.Violation {
+	var policyErr *PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return policyErr.Violations
+	}
+	return nil
+}
+
+func TestPasswordPolicyServiceHistoryReuse(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakePasswordHistoryRepository{}
+	service := newTestPasswordPolicyService(repo, 3)
+	for _, password := range []string{"eskisifre1", "eskisifre2", "eskisifre3", "eskisifre4"} {
+		_ = service.Remember(ctx, 1, testPasswordHash(t, password))
+	}
+	_ = service.Remember(ctx, 2, testPasswordHash(t, "baskasinin9"))
+	user := &models.User{Name: "Ayşe Yılmaz", Email: "ayse@example.com", Password: testPasswordHash(t, "mevcutsifre5")}
+	user.ID = 1
+
+	if len(repo.entries) != 4 {
+		t.Fatalf("geçmişte %d kayıt var, beklenen 4 (3 + başka kullanıcı)", len(repo.entries))
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		want     []passwordpolicy.Violation
+	}{
+		{"mevcut şifre", "mevcutsifre5", []passwordpolicy.Violation{passwordpolicy.ViolationSameAsCurrent}},
+		{"son şifre", "eskisifre4", []passwordpolicy.Violation{passwordpolicy.ViolationRecentlyUsed}},
+		{"geçmişteki en eski", "eskisifre2", []passwordpolicy.Violation{passwordpolicy.ViolationRecentlyUsed}},
+		{"geçmişten düşmüş", "eskisifre1", nil},
+		{"başka kullanıcının geçmişi", "baskasinin9", nil},
+		{"yeni şifre", "yepyenisifre6", nil},
+		{"kişisel bilgi", "kaya7yılmaz", []passwordpolicy.Violation{passwordpolicy.ViolationPersonalInfo}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.Validate(user, tt.password)
+			if got := policyViolations(err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%q) ihlaller = %v (%v), beklenen %v", tt.password, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyServiceHistoryDisabled(t *testing.T) {
+	repo := &fakePasswordHistoryRepository{}
+	service := newTestPasswordPolicyService(repo, 0)
+	_ = service.Remember(context.Background(), 1, testPasswordHash(t, "eskisifre1"))
+	if len(repo.entries) != 0 {
+		t.Fatalf("geçmiş kapalıyken %d kayıt eklendi", len(repo.entries))
+	}
+
+	user := &models.User{Password: testPasswordHash(t, "mevcutsifre5")}
+	user.ID = 1
+	if err := service.Validate(user, "eskisifre1"); err != nil {
+		t.Errorf("geçmiş kapalıyken eski şifre reddedildi: %v", err)
+	}
+	// Mevcut şifre kontrolü geçmiş ayarından bağımsızdır.
+	if got := policyViolations(service.Validate(user, "mevcutsifre5")); !reflect.DeepEqual(got, []passwordpolicy.Violation{passwordpolicy.ViolationSameAsCurrent}) {
+		t.Errorf("mevcut şifre ihlalleri = %v, beklenen [%s]", got, passwordpolicy.ViolationSameAsCurrent)
+	}
+	// Henüz kaydedilmemiş kullanıcıda tekrar kullanım aranmaz.
+	newUser := &models.User{Password: user.Password}
+	if err := service.Validate(newUser, "mevcutsifre5"); err != nil {
+		t.Errorf("yeni kullanıcı için tekrar kullanım kontrol edildi: %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrRememberTokenInvalid ServiceError = "geçersiz veya süresi dolmuş hatırlama tokenı"
+	ErrRememberTokenReused  ServiceError = "hatırlama tokenı yeniden kullanıldı"
+)
+
+// Tarayıcı aynı anda birkaç istek gönderdiğinde hepsi eski tokenı taşır; bu
+// süre içindeki tekrar kullanım çalınma sayılmaz.
+const rememberReuseGrace = 30 * time.Second
+
+func RememberMeTTL() time.Duration {
+	return time.Duration(envconfig.GetEnvAsInt("REMEMBER_ME_DAYS", 30)) * 24 * time.Hour
+}
+
+// RememberedLogin, tokenla yeniden kurulan girişi taşır. Token boşsa çerez
+// değiştirilmemelidir (eşzamanlı istekte yeni tokenı diğer istek almıştır).
+type RememberedLogin struct {
+	User      *models.User
+	Token     string
+	ExpiresAt time.Time
+}
+
+type IRememberMeService interface {
+	Issue(ctx context.Context, userID uint, ip, userAgent string) (string, time.Time, error)
+	Consume(ctx context.Context, rawToken, ip, userAgent string) (*RememberedLogin, error)
+	Revoke(ctx context.Context, rawToken string)
+	RevokeAll(ctx context.Context, userID uint, exceptRawToken string) error
+}
+
+type RememberMeService struct {
+	repo     repositories.IRememberTokenRepository
+	users    repositories.IAuthRepository
+	sessions ISessionService
+	now      func() time.Time
+}
+
+func NewRememberMeService() IRememberMeService {
+	return &RememberMeService{
+		repo:     repositories.NewRememberTokenRepository(),
+		users:    repositories.NewAuthRepository(),
+		sessions: NewSessionService(),
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (s *RememberMeService) create(ctx context.Context, userID uint, familyID, ip, userAgent string) (string, time.Time, error) {
+	selector, err := randomHex(tokenSelectorBytes)
+	if err != nil {
+		return "", time.Time{}, ErrTokenGeneration
+	}
+	verifier, err := randomHex(tokenVerifierBytes)
+	if err != nil {
+		return "", time.Time{}, ErrTokenGeneration
+	}
+
+	expiresAt := s.now().Add(RememberMeTTL())
+	token := &models.RememberToken{
+		UserID:       userID,
+		FamilyID:     familyID,
+		Selector:     selector,
+		VerifierHash: hashVerifier(verifier),
+		ExpiresAt:    expiresAt,
+		IPAddress:    ip,
+		UserAgent:    truncate(userAgent, 512),
+	}
+	if err := s.repo.Create(ctx, token); err != nil {
+		return "", time.Time{}, ErrDatabaseUpdateFailed
+	}
+	return selector + verifier, expiresAt, nil
+}
+
+// Issue, başarılı girişte yeni bir token ailesi başlatır.
+func (s *RememberMeService) Issue(ctx context.Context, userID uint, ip, userAgent string) (string, time.Time, error) {
+	if err := s.repo.DeleteExpired(s.now()); err != nil {
+		logconfig.Log.Warn("Süresi dolmuş hatırlama tokenları temizlenemedi", zap.Error(err))
+	}
+
+	familyID, err := randomHex(16)
+	if err != nil {
+		return "", time.Time{}, ErrTokenGeneration
+	}
+	return s.create(ctx, userID, familyID, ip, userAgent)
+}
+
+func (s *RememberMeService) find(rawToken string) (*models.RememberToken, error) {
+	selector, verifier, ok := splitToken(rawToken)
+	if !ok {
+		return nil, ErrRememberTokenInvalid
+	}
+
+	token, err := s.repo.FindBySelector(selector)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRememberTokenInvalid
+		}
+		return nil, ErrAuthGeneric
+	}
+
+	expected, _ := hex.DecodeString(token.VerifierHash)
+	actual := sha256.Sum256([]byte(verifier))
+	if subtle.ConstantTimeCompare(expected, actual[:]) != 1 {
+		logconfig.Log.Warn("Hatırlama tokenı doğrulanamadı", zap.Uint("token_id", token.ID))
+		return nil, ErrRememberTokenInvalid
+	}
+	return token, nil
+}
+
+// Consume, tokenı doğrular ve aynı ailede yenisiyle değiştirir. Süre aşımı
+// dışında zaten döndürülmüş bir tokenın gelmesi, çerezin kopyalandığını
+// gösterir; bu durumda aile ve kullanıcının tüm oturumları sonlandırılır.
+func (s *RememberMeService) Consume(ctx context.Context, rawToken, ip, userAgent string) (*RememberedLogin, error) {
+	token, err := s.find(rawToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+		return nil, ErrRememberTokenInvalid
+	}
+
+	rotated := false
+	if token.RotatedAt == nil {
+		rotated, err = s.repo.MarkRotated(ctx, token.ID, now)
+		if err != nil {
+			return nil, ErrAuthGeneric
+		}
+		if !rotated {
+			// Eşzamanlı bir istek tokenı az önce döndürdü.
+			token.RotatedAt = &now
+		}
+	}
+
+	if !rotated && now.Sub(*token.RotatedAt) > rememberReuseGrace {
+		logconfig.Log.Warn("Döndürülmüş hatırlama tokenı tekrar kullanıldı, aile iptal ediliyor",
+			zap.Uint("user_id", token.UserID),
+			zap.String("family_id", token.FamilyID),
+			zap.String("ip", ip),
+		)
+		_ = s.repo.RevokeFamily(ctx, token.FamilyID, now)
+		if err := s.sessions.RevokeAll(ctx, token.UserID); err != nil {
+			logconfig.Log.Error("Oturumlar sonlandırılamadı", zap.Uint("user_id", token.UserID), zap.Error(err))
+		}
+		return nil, ErrRememberTokenReused
+	}
+
+	user, err := s.users.FindUserByID(token.UserID)
+	if err != nil {
+		return nil, ErrRememberTokenInvalid
+	}
+	if !user.Status {
+		return nil, ErrUserInactive
+	}
+
+	login := &RememberedLogin{User: user}
+	if rotated {
+		login.Token, login.ExpiresAt, err = s.create(ctx, user.ID, token.FamilyID, ip, userAgent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logconfig.Log.Info("Hatırlama tokenı ile oturum yenilendi", zap.Uint("user_id", user.ID))
+	return login, nil
+}
+
+func (s *RememberMeService) Revoke(ctx context.Context, rawToken string) {
+	token, err := s.find(rawToken)
+	if err != nil {
+		return
+	}
+	_ = s.repo.RevokeFamily(ctx, token.FamilyID, s.now())
+}
+
+// RevokeAll, kullanıcının tüm hatırlama tokenlarını iptal eder; exceptRawToken
+// verilmişse o tokenın ailesi (mevcut cihaz) korunur.
+func (s *RememberMeService) RevokeAll(ctx context.Context, userID uint, exceptRawToken string) error {
+	var exceptFamilyID string
+	if exceptRawToken != "" {
+		if token, err := s.find(exceptRawToken); err == nil && token.UserID == userID {
+			exceptFamilyID = token.FamilyID
+		}
+	}
+	if err := s.repo.RevokeByUser(ctx, userID, exceptFamilyID, s.now()); err != nil {
+		return ErrDatabaseUpdateFailed
+	}
+	return nil
+}
+
+var _ IRememberMeService = (*RememberMeService)(nil)
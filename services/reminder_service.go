@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/configs/notifierconfig"
+	"zatrano/models"
+	"zatrano/pkg/notifier"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	reminderMinLeadHours = 1
+	reminderMaxLeadHours = 168
+)
+
+// ReminderChannels, davetiye sahibinin seçebileceği hatırlatma kanallarıdır.
+var ReminderChannels = []notifier.Channel{notifier.ChannelSMS, notifier.ChannelWhatsApp}
+
+func DefaultReminderLeadHours() int {
+	return ClampReminderLeadHours(envconfig.GetEnvAsInt("REMINDER_DEFAULT_LEAD_HOURS", 24))
+}
+
+func ClampReminderLeadHours(hours int) int {
+	if hours < reminderMinLeadHours {
+		return reminderMinLeadHours
+	}
+	if hours > reminderMaxLeadHours {
+		return reminderMaxLeadHours
+	}
+	return hours
+}
+
+type IReminderService interface {
+	Preview(invitation *models.Invitation) notifier.Message
+	SendDue(ctx context.Context) int
+}
+
+type ReminderService struct {
+	repo        repositories.IReminderRepository
+	notifier    func() notifier.Notifier
+	now         func() time.Time
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+func NewReminderService() IReminderService {
+	return &ReminderService{
+		repo:        repositories.NewReminderRepository(),
+		notifier:    notifierconfig.Get,
+		now:         func() time.Time { return time.Now().UTC() },
+		maxAttempts: max(1, envconfig.GetEnvAsInt("REMINDER_MAX_ATTEMPTS", 3)),
+		retryDelay:  time.Duration(envconfig.GetEnvAsInt("REMINDER_RETRY_MINUTES", 15)) * time.Minute,
+	}
+}
+
+// Preview, davetiye sahibine gösterilecek örnek hatırlatma mesajını üretir.
+func (s *ReminderService) Preview(invitation *models.Invitation) notifier.Message {
+	return buildReminderMessage(invitation, &models.InvitationParticipant{Title: "Ayşe Yılmaz"})
+}
+
+// SendDue, hatırlatma zamanı gelen katılımcılara mesaj gönderir ve gönderilen
+// mesaj sayısını döndürür. Katılımcı gönderimden önce işaretlenir; gönderim
+// başarısız olursa işaret kaldırılır ve her denemede iki katına çıkan bir
+// beklemeden sonra, en fazla maxAttempts kez tekrar denenir.
+func (s *ReminderService) SendDue(ctx context.Context) int {
+	now := s.now()
+	invitations, err := s.repo.ListDueInvitations(now, s.maxAttempts)
+	if err != nil {
+		return 0
+	}
+
+	n := s.notifier()
+	sent := 0
+	for i := range invitations {
+		invitation := &invitations[i]
+		if !invitation.ReminderDueAt(now) {
+			continue
+		}
+		for j := range invitation.Participants {
+			if ctx.Err() != nil {
+				return sent
+			}
+			participant := &invitation.Participants[j]
+			if participant.ReminderAttempts >= s.maxAttempts ||
+				(participant.ReminderRetryAt != nil && participant.ReminderRetryAt.After(now)) {
+				continue
+			}
+
+			claimed, err := s.repo.MarkSent(ctx, participant.ID, now)
+			if err != nil || !claimed {
+				continue
+			}
+
+			if err := n.Send(ctx, buildReminderMessage(invitation, participant)); err != nil {
+				attempts := participant.ReminderAttempts + 1
+				logconfig.Log.Warn("Hatırlatma gönderilemedi",
+					zap.Uint("invitation_id", invitation.ID),
+					zap.Uint("participant_id", participant.ID),
+					zap.String("channel", invitation.ReminderChannel),
+					zap.Int("attempts", attempts),
+					zap.Bool("gave_up", attempts >= s.maxAttempts),
+					zap.Error(err),
+				)
+				retryAt := now.Add(s.retryDelay << (attempts - 1))
+				if failErr := s.repo.RecordFailure(ctx, participant.ID, retryAt); failErr != nil {
+					logconfig.Log.Error("Hatırlatma denemesi kaydedilemedi", zap.Uint("participant_id", participant.ID), zap.Error(failErr))
+				}
+				continue
+			}
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		logconfig.Log.Info("Etkinlik hatırlatmaları gönderildi", zap.Int("count", sent))
+	}
+	return sent
+}
+
+func buildReminderMessage(invitation *models.Invitation, participant *models.InvitationParticipant) notifier.Message {
+	channel := notifier.Channel(invitation.ReminderChannel)
+	if channel == "" {
+		channel = notifier.ChannelSMS
+	}
+
+	var b strings.Builder
+	if name := strings.TrimSpace(participant.Title); name != "" {
+		fmt.Fprintf(&b, "Merhaba %s, ", name)
+	} else {
+		b.WriteString("Merhaba, ")
+	}
+
+	fmt.Fprintf(&b, "etkinlik hatırlatması: %s, %s", invitation.EventTitle(), invitation.LocalEventAt().Format("02.01.2006 15:04"))
+	if invitation.Venue != "" {
+		fmt.Fprintf(&b, ", %s", invitation.Venue)
+	}
+	b.WriteString(". Sizi aramızda görmekten mutluluk duyarız.")
+
+	if baseURL := strings.TrimRight(os.Getenv("APP_BASE_URL"), "/"); baseURL != "" && invitation.InvitationKey != "" {
+		fmt.Fprintf(&b, " Davetiye: %s/%s", baseURL, invitation.InvitationKey)
+	}
+
+	return notifier.Message{
+		Channel: channel,
+		To:      participant.PhoneNumber,
+		Subject: "Etkinlik Hatırlatması",
+		Body:    b.String(),
+	}
+}
+
+var _ IReminderService = (*ReminderService)(nil)
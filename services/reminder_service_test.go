@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/notifier"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logconfig.Log = zap.NewNop()
+	logconfig.SLog = logconfig.Log.Sugar()
+	os.Exit(m.Run())
+}
+
+// fakeReminderRepository, reminder_sent_at ve deneme sütunlarını bellekte tutar.
+// Davetiyeleri pencereye, deneme sınırına ve bekleme süresine bakmadan,
+// gönderilmiş katılımcıları da içerecek şekilde döndürür; böylece servisin
+// kendi kontrolleri ayrıca sınanır.
+type fakeReminderRepository struct {
+	mu          sync.Mutex
+	invitations []models.Invitation
+	sentAt      map[uint]time.Time
+	attempts    map[uint]int
+	retryAt     map[uint]time.Time
+}
+
+func newFakeReminderRepository(invitations ...models.Invitation) *fakeReminderRepository {
+	return &fakeReminderRepository{
+		invitations: invitations,
+		sentAt:      make(map[uint]time.Time),
+		attempts:    make(map[uint]int),
+		retryAt:     make(map[uint]time.Time),
+	}
+}
+
+func (r *fakeReminderRepository) ListDueInvitations(time.Time, int) ([]models.Invitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	invitations := make([]models.Invitation, len(r.invitations))
+	for i, invitation := range r.invitations {
+		invitation.Participants = append([]models.InvitationParticipant(nil), invitation.Participants...)
+		for j := range invitation.Participants {
+			participant := &invitation.Participants[j]
+			participant.ReminderAttempts = r.attempts[participant.ID]
+			if retryAt, ok := r.retryAt[participant.ID]; ok {
+				participant.ReminderRetryAt = &retryAt
+			}
+		}
+		invitations[i] = invitation
+	}
+	return invitations, nil
+}
+
+func (r *fakeReminderRepository) MarkSent(_ context.Context, participantID uint, sentAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sentAt[participantID]; ok {
+		return false, nil
+	}
+	r.sentAt[participantID] = sentAt
+	return true, nil
+}
+
+func (r *fakeReminderRepository) RecordFailure(_ context.Context, participantID uint, retryAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sentAt, participantID)
+	r.attempts[participantID]++
+	r.retryAt[participantID] = retryAt
+	return nil
+}
+
+func newTestReminderService(repo *fakeReminderRepository, n notifier.Notifier, now time.Time) *ReminderService {
+	return &ReminderService{
+		repo:        repo,
+		notifier:    func() notifier.Notifier { return n },
+		now:         func() time.Time { return now },
+		maxAttempts: 3,
+		retryDelay:  15 * time.Minute,
+	}
+}
+
+func reminderInvitation(id uint, eventAt time.Time, leadHours int, phones ...string) models.Invitation {
+	invitation := models.Invitation{
+		EventAt:           eventAt,
+		Timezone:          "Europe/Istanbul",
+		ReminderEnabled:   true,
+		ReminderLeadHours: leadHours,
+		ReminderChannel:   string(notifier.ChannelSMS),
+		InvitationDetail:  &models.InvitationDetail{Title: "Düğün"},
+	}
+	invitation.ID = id
+	for i, phone := range phones {
+		participant := models.InvitationParticipant{Title: "Misafir", PhoneNumber: phone, InvitationID: id, Status: models.ParticipantAccepted}
+		participant.ID = id*100 + uint(i)
+		invitation.Participants = append(invitation.Participants, participant)
+	}
+	return invitation
+}
+
+func sentRecipients(fake *notifier.Fake) []string {
+	var recipients []string
+	for _, msg := range fake.Sent() {
+		recipients = append(recipients, msg.To)
+	}
+	sort.Strings(recipients)
+	return recipients
+}
+
+func TestReminderServiceSendDueWindow(t *testing.T) {
+	t.Setenv("APP_BASE_URL", "")
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		invitation models.Invitation
+		want       bool
+	}{
+		{"pencere içinde", reminderInvitation(1, now.Add(10*time.Hour), 24, "+905320000001"), true},
+		{"pencere tam başlıyor", reminderInvitation(2, now.Add(24*time.Hour), 24, "+905320000002"), true},
+		{"etkinliğe bir dakika var", reminderInvitation(3, now.Add(time.Minute), 1, "+905320000003"), true},
+		{"pencere henüz açılmadı", reminderInvitation(4, now.Add(24*time.Hour+time.Minute), 24, "+905320000004"), false},
+		{"kısa süre önce", reminderInvitation(5, now.Add(2*time.Hour), 1, "+905320000005"), false},
+		{"etkinlik başladı", reminderInvitation(6, now, 24, "+905320000006"), false},
+		{"etkinlik geçti", reminderInvitation(7, now.Add(-time.Minute), 24, "+905320000007"), false},
+		{"hatırlatma kapalı", func() models.Invitation {
+			invitation := reminderInvitation(8, now.Add(time.Hour), 24, "+905320000008")
+			invitation.ReminderEnabled = false
+			return invitation
+		}(), false},
+		{"arşivlenmiş", func() models.Invitation {
+			invitation := reminderInvitation(9, now.Add(time.Hour), 24, "+905320000009")
+			invitation.ArchivedAt = &past
+			return invitation
+		}(), false},
+		{"süresi dolmuş", func() models.Invitation {
+			invitation := reminderInvitation(10, now.Add(2*time.Hour), 24, "+905320000010")
+			invitation.ExpireAt = &past
+			return invitation
+		}(), false},
+		{"süresi henüz dolmamış", func() models.Invitation {
+			invitation := reminderInvitation(11, now.Add(2*time.Hour), 24, "+905320000011")
+			invitation.ExpireAt = &future
+			return invitation
+		}(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := notifier.NewFake()
+			repo := newFakeReminderRepository(tt.invitation)
+			sent := newTestReminderService(repo, fake, now).SendDue(context.Background())
+
+			want := 0
+			if tt.want {
+				want = 1
+			}
+			if sent != want || len(fake.Sent()) != want {
+				t.Fatalf("SendDue = %d, gönderilen %d mesaj, beklenen %d", sent, len(fake.Sent()), want)
+			}
+			if !tt.want {
+				if len(repo.sentAt) != 0 {
+					t.Errorf("pencere dışındaki katılımcı işaretlenmiş: %v", repo.sentAt)
+				}
+				return
+			}
+
+			msg := fake.Sent()[0]
+			if msg.To != tt.invitation.Participants[0].PhoneNumber || msg.Channel != notifier.ChannelSMS {
+				t.Errorf("mesaj = %+v, beklenen alıcı %s", msg, tt.invitation.Participants[0].PhoneNumber)
+			}
+			if !strings.Contains(msg.Body, "Merhaba Misafir") || !strings.Contains(msg.Body, "Düğün") {
+				t.Errorf("mesaj metni beklenmiyor: %q", msg.Body)
+			}
+			if sentAt := repo.sentAt[tt.invitation.Participants[0].ID]; !sentAt.Equal(now) {
+				t.Errorf("reminder_sent_at = %v, beklenen %v", sentAt, now)
+			}
+		})
+	}
+}
+
+func TestReminderServiceSendDueOncePerGuest(t *testing.T) {
+	t.Setenv("APP_BASE_URL", "")
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	invitation := reminderInvitation(1, now.Add(3*time.Hour), 24, "+905320000001", "+905320000002")
+	// Aynı katılımcı listede iki kez görünse de tek mesaj almalıdır.
+	invitation.Participants = append(invitation.Participants, invitation.Participants[0])
+
+	fake := notifier.NewFake()
+	repo := newFakeReminderRepository(invitation)
+	first := newTestReminderService(repo, fake, now)
+	// İkinci örnek, aynı veritabanını kullanan başka bir uygulama örneğidir.
+	second := newTestReminderService(repo, fake, now.Add(time.Minute))
+
+	if sent := first.SendDue(context.Background()); sent != 2 {
+		t.Fatalf("ilk çalıştırma %d mesaj gönderdi, beklenen 2", sent)
+	}
+	if sent := first.SendDue(context.Background()); sent != 0 {
+		t.Errorf("tekrar çalıştırma %d mesaj gönderdi, beklenen 0", sent)
+	}
+	if sent := second.SendDue(context.Background()); sent != 0 {
+		t.Errorf("ikinci örnek %d mesaj gönderdi, beklenen 0", sent)
+	}
+
+	got := sentRecipients(fake)
+	want := []string{"+905320000001", "+905320000002"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("alıcılar = %v, beklenen %v", got, want)
+	}
+}
+
+func TestReminderServiceSendDueRetriesFailedSend(t *testing.T) {
+	t.Setenv("APP_BASE_URL", "")
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	invitation := reminderInvitation(1, now.Add(3*time.Hour), 24, "+905320000001")
+
+	fake := notifier.NewFake()
+	fake.Err = errors.New("sağlayıcı yanıt vermedi")
+	repo := newFakeReminderRepository(invitation)
+	service := newTestReminderService(repo, fake, now)
+
+	if sent := service.SendDue(context.Background()); sent != 0 {
+		t.Fatalf("başarısız gönderim %d sayıldı, beklenen 0", sent)
+	}
+	id := invitation.Participants[0].ID
+	if _, ok := repo.sentAt[id]; ok {
+		t.Fatal("başarısız gönderimden sonra işaret kaldırılmadı (RecordFailure)")
+	}
+	if repo.attempts[id] != 1 || !repo.retryAt[id].Equal(now.Add(15*time.Minute)) {
+		t.Fatalf("deneme = %d, sonraki deneme = %v; beklenen 1, %v", repo.attempts[id], repo.retryAt[id], now.Add(15*time.Minute))
+	}
+
+	fake.Err = nil
+	if sent := service.SendDue(context.Background()); sent != 0 {
+		t.Fatalf("bekleme süresi dolmadan %d mesaj gönderildi, beklenen 0", sent)
+	}
+
+	later := newTestReminderService(repo, fake, now.Add(15*time.Minute))
+	if sent := later.SendDue(context.Background()); sent != 1 {
+		t.Fatalf("yeniden deneme %d mesaj gönderdi, beklenen 1", sent)
+	}
+	if sent := later.SendDue(context.Background()); sent != 0 {
+		t.Errorf("başarılı gönderimden sonra %d mesaj daha gönderildi, beklenen 0", sent)
+	}
+	if got := sentRecipients(fake); len(got) != 1 {
+		t.Errorf("gönderilen mesajlar = %v, beklenen 1 mesaj", got)
+	}
+}
+
+// TestReminderServiceSendDueGivesUp, sürekli hata veren sağlayıcının her
+// çalıştırmada değil, artan aralıklarla ve en fazla maxAttempts kez
+// denendiğini doğrular.
+func TestReminderServiceSendDueGivesUp(t *testing.T) {
+	t.Setenv("APP_BASE_URL", "")
+	start := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	invitation := reminderInvitation(1, start.Add(20*time.Hour), 24, "+905320000001")
+	repo := newFakeReminderRepository(invitation)
+
+	calls := 0
+	failing := notifier.Func(func(context.Context, notifier.Message) error {
+		calls++
+		return errors.New("sağlayıcı kalıcı olarak reddetti")
+	})
+
+	// 5 dakikalık zamanlayıcı adımlarıyla dört saat boyunca çalıştırılır.
+	var attemptedAt []time.Duration
+	for elapsed := time.Duration(0); elapsed <= 4*time.Hour; elapsed += 5 * time.Minute {
+		before := calls
+		newTestReminderService(repo, failing, start.Add(elapsed)).SendDue(context.Background())
+		if calls > before {
+			attemptedAt = append(attemptedAt, elapsed)
+		}
+	}
+
+	want := []time.Duration{0, 15 * time.Minute, 45 * time.Minute}
+	if len(attemptedAt) != len(want) {
+		t.Fatalf("deneme zamanları = %v, beklenen %v", attemptedAt, want)
+	}
+	for i := range want {
+		if attemptedAt[i] != want[i] {
+			t.Errorf("%d. deneme %v, beklenen %v", i+1, attemptedAt[i], want[i])
+		}
+	}
+	if _, ok := repo.sentAt[invitation.Participants[0].ID]; ok {
+		t.Error("vazgeçilen katılımcı gönderilmiş olarak işaretlenmiş")
+	}
+}
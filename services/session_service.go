@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/models"
+	"zatrano/pkg/useragent"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+// Son görülme zamanı en fazla bu aralıkla güncellenir.
+const sessionTouchInterval = time.Minute
+
+type ActiveSession struct {
+	ID         uint
+	Device     string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	Current    bool
+}
+
+type ISessionService interface {
+	Register(ctx context.Context, userID uint, sessionID, ip, userAgent string) error
+	Touch(sessionID, ip, userAgent string)
+	Forget(sessionID string)
+	ListActive(userID uint, currentSessionID string) ([]ActiveSession, error)
+	RevokeOthers(ctx context.Context, userID uint, currentSessionID string) (int, error)
+	RevokeAll(ctx context.Context, userID uint) error
+}
+
+type SessionService struct {
+	repo repositories.IUserSessionRepository
+}
+
+func NewSessionService() ISessionService {
+	return &SessionService{repo: repositories.NewUserSessionRepository()}
+}
+
+func (s *SessionService) Register(ctx context.Context, userID uint, sessionID, ip, userAgent string) error {
+	now := time.Now()
+	if err := s.repo.DeleteInactive(now.Add(-sessionconfig.Expiration())); err != nil {
+		logconfig.Log.Warn("Süresi dolmuş oturum kayıtları temizlenemedi", zap.Error(err))
+	}
+
+	return s.repo.Upsert(ctx, &models.UserSession{
+		SessionID:  sessionID,
+		UserID:     userID,
+		IPAddress:  ip,
+		UserAgent:  truncate(userAgent, 512),
+		LastSeenAt: now,
+	})
+}
+
+func (s *SessionService) Touch(sessionID, ip, userAgent string) {
+	now := time.Now()
+	_ = s.repo.Touch(sessionID, ip, truncate(userAgent, 512), now, now.Add(-sessionTouchInterval))
+}
+
+func (s *SessionService) Forget(sessionID string) {
+	if err := s.repo.DeleteBySessionID(sessionID); err != nil {
+		logconfig.Log.Warn("Oturum kaydı silinemedi", zap.Error(err))
+	}
+}
+
+func (s *SessionService) ListActive(userID uint, currentSessionID string) ([]ActiveSession, error) {
+	sessions, err := s.repo.ListByUser(userID, time.Now().Add(-sessionconfig.Expiration()))
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]ActiveSession, 0, len(sessions))
+	for _, session := range sessions {
+		active = append(active, ActiveSession{
+			ID:         session.ID,
+			Device:     useragent.Parse(session.UserAgent).String(),
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.SessionID == currentSessionID,
+		})
+	}
+	return active, nil
+}
+
+func (s *SessionService) revoke(ctx context.Context, userID uint, exceptSessionID string) (int, error) {
+	ids, err := s.repo.DeleteByUser(ctx, userID, exceptSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if err := sessionconfig.DeleteSessionByID(id); err != nil {
+			logconfig.Log.Warn("Oturum depodan silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+		}
+	}
+
+	if len(ids) > 0 {
+		logconfig.Log.Info("Kullanıcı oturumları sonlandırıldı",
+			zap.Uint("user_id", userID),
+			zap.Int("count", len(ids)),
+		)
+	}
+	return len(ids), nil
+}
+
+func (s *SessionService) RevokeOthers(ctx context.Context, userID uint, currentSessionID string) (int, error) {
+	return s.revoke(ctx, userID, currentSessionID)
+}
+
+func (s *SessionService) RevokeAll(ctx context.Context, userID uint) error {
+	_, err := s.revoke(ctx, userID, "")
+	return err
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
+
+var _ ISessionService = (*SessionService)(nil)
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/configs/secretconfig"
+	"zatrano/models"
+	"zatrano/pkg/secretbox"
+	"zatrano/pkg/totp"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ErrTwoFactorInvalidCode     ServiceError = "geçersiz doğrulama kodu"
+	ErrTwoFactorAlreadyEnabled  ServiceError = "iki adımlı doğrulama zaten etkin"
+	ErrTwoFactorNotEnabled      ServiceError = "iki adımlı doğrulama etkin değil"
+	ErrTwoFactorSecretGenerate  ServiceError = "iki adımlı doğrulama anahtarı oluşturulamadı"
+	ErrTwoFactorRecoveryGenFail ServiceError = "kurtarma kodları oluşturulamadı"
+	ErrTwoFactorRequired        ServiceError = "bu hesap için iki adımlı doğrulama zorunludur"
+)
+
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	totpAllowedSkew    = 1
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type TwoFactorEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type ITwoFactorService interface {
+	NewEnrollment(user *models.User) (*TwoFactorEnrollment, error)
+	EnrollmentFor(user *models.User, secret string) *TwoFactorEnrollment
+	Enable(ctx context.Context, userID uint, secret, code string) ([]string, error)
+	Disable(ctx context.Context, userID uint, code string) error
+	Verify(ctx context.Context, userID uint, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error)
+	RemainingRecoveryCodes(userID uint) (int64, error)
+	IsRequiredFor(user *models.User) bool
+}
+
+type TwoFactorService struct {
+	repo       repositories.ITwoFactorRepository
+	authRepo   repositories.IAuthRepository
+	protection ILoginProtectionService
+}
+
+func NewTwoFactorService() ITwoFactorService {
+	return &TwoFactorService{
+		repo:       repositories.NewTwoFactorRepository(),
+		authRepo:   repositories.NewAuthRepository(),
+		protection: NewLoginProtectionService(),
+	}
+}
+
+// TWO_FACTOR_REQUIRED_FOR_DASHBOARD=true iken Dashboard kullanıcıları
+// iki adımlı doğrulamayı etkinleştirmeden yönetim ekranlarına erişemez.
+func (s *TwoFactorService) IsRequiredFor(user *models.User) bool {
+	if user == nil || user.Type != models.Dashboard {
+		return false
+	}
+	return envconfig.GetEnvWithDefault("TWO_FACTOR_REQUIRED_FOR_DASHBOARD", "false") == "true"
+}
+
+func (s *TwoFactorService) NewEnrollment(user *models.User) (*TwoFactorEnrollment, error) {
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		logconfig.Log.Error("TOTP anahtarı oluşturulamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrTwoFactorSecretGenerate
+	}
+
+	return s.EnrollmentFor(user, secret), nil
+}
+
+func (s *TwoFactorService) EnrollmentFor(user *models.User, secret string) *TwoFactorEnrollment {
+	issuer := envconfig.GetEnvWithDefault("TWO_FACTOR_ISSUER", "zatrano")
+	return &TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.ProvisioningURI(issuer, user.Email, secret),
+	}
+}
+
+func (s *TwoFactorService) Enable(ctx context.Context, userID uint, secret, code string) ([]string, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now(), totpAllowedSkew)
+	if !ok {
+		return nil, ErrTwoFactorInvalidCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := secretbox.Seal(secretconfig.Key(), models.TwoFactorSecretPurpose, secret)
+	if err != nil {
+		logconfig.Log.Error("TOTP anahtarı şifrelenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrTwoFactorSecretGenerate
+	}
+
+	if err := s.repo.Enable(ctx, userID, sealed, step, hashes); err != nil {
+		return nil, ErrDatabaseUpdateFailed
+	}
+
+	logconfig.Log.Info("İki adımlı doğrulama etkinleştirildi", zap.Uint("user_id", userID))
+	return codes, nil
+}
+
+func (s *TwoFactorService) Disable(ctx context.Context, userID uint, code string) error {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+	if s.IsRequiredFor(user) {
+		return ErrTwoFactorRequired
+	}
+
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+	if err := s.repo.Disable(ctx, userID); err != nil {
+		return ErrDatabaseUpdateFailed
+	}
+
+	logconfig.Log.Info("İki adımlı doğrulama kapatıldı", zap.Uint("user_id", userID))
+	return nil
+}
+
+// Verify, altı haneli TOTP kodunu ya da tek kullanımlık bir kurtarma kodunu
+// kabul eder. Hatalı kodlar hesap bazında sayılır; sınır aşılınca hesap
+// kilitlenir ve kilit sürerken doğru kod da reddedilir.
+func (s *TwoFactorService) Verify(ctx context.Context, userID uint, code string) error {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+	if err := s.protection.CheckTwoFactor(user.Email); err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		secret, err := openTwoFactorSecret(user)
+		if err != nil {
+			return ErrAuthGeneric
+		}
+		step, ok := totp.Validate(secret, code, time.Now(), totpAllowedSkew)
+		if !ok {
+			s.protection.RegisterTwoFactorFailure(user.Email)
+			return ErrTwoFactorInvalidCode
+		}
+		advanced, err := s.repo.AdvanceLastStep(ctx, userID, step)
+		if err != nil {
+			return ErrAuthGeneric
+		}
+		if !advanced {
+			logconfig.Log.Warn("Daha önce kullanılmış TOTP kodu reddedildi", zap.Uint("user_id", userID))
+			s.protection.RegisterTwoFactorFailure(user.Email)
+			return ErrTwoFactorInvalidCode
+		}
+		s.protection.RegisterTwoFactorSuccess(user.Email)
+		return nil
+	}
+
+	consumed, err := s.repo.ConsumeRecoveryCode(ctx, userID, hashRecoveryCode(code), time.Now())
+	if err != nil {
+		return ErrAuthGeneric
+	}
+	if !consumed {
+		s.protection.RegisterTwoFactorFailure(user.Email)
+		return ErrTwoFactorInvalidCode
+	}
+
+	logconfig.Log.Info("Kurtarma kodu kullanıldı", zap.Uint("user_id", userID))
+	return nil
+}
+
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error) {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, ErrDatabaseUpdateFailed
+	}
+	return codes, nil
+}
+
+func (s *TwoFactorService) RemainingRecoveryCodes(userID uint) (int64, error) {
+	return s.repo.CountRecoveryCodes(userID)
+}
+
+func (s *TwoFactorService) getUser(userID uint) (*models.User, error) {
+	user, err := s.authRepo.FindUserByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// openTwoFactorSecret, saklanan TOTP anahtarını çözer. Şifreleme öncesinden
+// kalan ve migrasyonla henüz dönüştürülmemiş düz metin anahtarlar olduğu gibi
+// kullanılır.
+func openTwoFactorSecret(user *models.User) (string, error) {
+	secret, err := secretbox.Open(secretconfig.Key(), models.TwoFactorSecretPurpose, user.TwoFactorSecret)
+	if errors.Is(err, secretbox.ErrNotSealed) {
+		return user.TwoFactorSecret, nil
+	}
+	if err != nil {
+		logconfig.Log.Error("TOTP anahtarı çözülemedi; APP_SECRET değişmiş olabilir",
+			zap.Uint("user_id", user.ID), zap.Error(err))
+		return "", err
+	}
+	return secret, nil
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	buf := make([]byte, recoveryCodeLength)
+	for i := 0; i < recoveryCodeCount; i++ {
+		if _, err := rand.Read(buf); err != nil {
+			logconfig.Log.Error("Kurtarma kodu oluşturulamadı", zap.Error(err))
+			return nil, nil, ErrTwoFactorRecoveryGenFail
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))[:recoveryCodeLength]
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ ITwoFactorService = (*TwoFactorService)(nil)
@@ -22,11 +22,19 @@ type IUserService interface {
 }
 
 type UserService struct {
-	repo repositories.IUserRepository
+	repo      repositories.IUserRepository
+	passwords IPasswordPolicyService
+	sessions  ISessionService
+	remember  IRememberMeService
 }
 
 func NewUserService() IUserService {
-	return &UserService{repo: repositories.NewUserRepository()}
+	return &UserService{
+		repo:      repositories.NewUserRepository(),
+		passwords: NewPasswordPolicyService(),
+		sessions:  NewSessionService(),
+		remember:  NewRememberMeService(),
+	}
 }
 
 func (s *UserService) GetAllUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
@@ -61,40 +69,69 @@ func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
 	if user.Password == "" {
 		return errors.New("şifre alanı boş olamaz")
 	}
+	if err := s.passwords.Validate(user, user.Password); err != nil {
+		return err
+	}
 	if err := user.SetPassword(user.Password); err != nil {
 		logconfig.Log.Error("Şifre oluşturulamadı", zap.Error(err))
 		return errors.New("şifre oluşturulurken hata oluştu")
 	}
-	return s.repo.CreateUser(ctx, user)
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		return err
+	}
+	_ = s.passwords.Remember(ctx, user.ID, user.Password)
+	return nil
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.User, updatedBy uint) error {
-	_, err := s.repo.GetUserByID(id)
+	existing, err := s.repo.GetUserByID(id)
 	if err != nil {
 		return errors.New("kullanıcı bulunamadı")
 	}
 
 	updateData := map[string]interface{}{
-		"name":               userData.Name,
-		"email":              userData.Email,
-		"status":             userData.Status,
-		"type":               userData.Type,
-		"reset_token":        userData.ResetToken,
-		"email_verified":     userData.EmailVerified,
-		"verification_token": userData.VerificationToken,
-		"provider":           userData.Provider,
-		"provider_id":        userData.ProviderID,
+		"name":           userData.Name,
+		"email":          userData.Email,
+		"status":         userData.Status,
+		"type":           userData.Type,
+		"email_verified": userData.EmailVerified,
+		"provider":       userData.Provider,
+		"provider_id":    userData.ProviderID,
 	}
 
+	var newHash string
 	if userData.Password != "" {
+		candidate := *existing
+		candidate.Name = userData.Name
+		candidate.Email = userData.Email
+		if err := s.passwords.Validate(&candidate, userData.Password); err != nil {
+			return err
+		}
+
 		hashed := models.User{}
 		if err := hashed.SetPassword(userData.Password); err != nil {
 			return errors.New("şifre oluşturulurken hata oluştu")
 		}
 		updateData["password"] = hashed.Password
+		newHash = hashed.Password
 	}
 
-	return s.repo.UpdateUser(ctx, id, updateData, updatedBy)
+	if err := s.repo.UpdateUser(ctx, id, updateData, updatedBy); err != nil {
+		return err
+	}
+	if newHash != "" {
+		_ = s.passwords.Remember(ctx, id, newHash)
+
+		// Yönetici parolayı değiştirdiğinde kullanıcının açık oturumları da
+		// kapanmalıdır.
+		if err := s.sessions.RevokeAll(ctx, id); err != nil {
+			logconfig.Log.Error("Oturumlar sonlandırılamadı", zap.Uint("user_id", id), zap.Error(err))
+		}
+		if err := s.remember.RevokeAll(ctx, id, ""); err != nil {
+			logconfig.Log.Error("Hatırlama tokenları iptal edilemedi", zap.Uint("user_id", id), zap.Error(err))
+		}
+	}
+	return nil
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
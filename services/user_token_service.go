@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrTokenInvalid    ServiceError = "geçersiz veya süresi dolmuş bağlantı"
+	ErrTokenGeneration ServiceError = "güvenlik anahtarı oluşturulamadı"
+)
+
+const (
+	tokenSelectorBytes   = 12
+	tokenVerifierBytes   = 32
+	tokenSelectorHexSize = tokenSelectorBytes * 2
+)
+
+type IUserTokenService interface {
+	Issue(ctx context.Context, userID uint, purpose models.UserTokenPurpose, ttl time.Duration) (string, error)
+	Peek(purpose models.UserTokenPurpose, rawToken string) (*models.UserToken, error)
+	Consume(ctx context.Context, purpose models.UserTokenPurpose, rawToken string) (*models.UserToken, error)
+	Revoke(ctx context.Context, userID uint, purpose models.UserTokenPurpose) error
+}
+
+type UserTokenService struct {
+	repo repositories.IUserTokenRepository
+	now  func() time.Time
+}
+
+func NewUserTokenService() IUserTokenService {
+	return &UserTokenService{
+		repo: repositories.NewUserTokenRepository(),
+		now:  func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Issue, kullanıcı için yeni bir token üretir ve aynı amaçla daha önce verilmiş
+// kullanılmamış tokenları geçersiz kılar. Dönen ham değer yalnızca e-posta
+// bağlantısında kullanılmalı; veritabanında sadece verifier özeti tutulur.
+func (s *UserTokenService) Issue(ctx context.Context, userID uint, purpose models.UserTokenPurpose, ttl time.Duration) (string, error) {
+	selector, err := randomHex(tokenSelectorBytes)
+	if err != nil {
+		logconfig.Log.Error("Token selector üretilemedi", zap.Error(err))
+		return "", ErrTokenGeneration
+	}
+	verifier, err := randomHex(tokenVerifierBytes)
+	if err != nil {
+		logconfig.Log.Error("Token verifier üretilemedi", zap.Error(err))
+		return "", ErrTokenGeneration
+	}
+
+	now := s.now()
+	if err := s.repo.InvalidateUserTokens(ctx, userID, purpose, now); err != nil {
+		return "", ErrDatabaseUpdateFailed
+	}
+
+	token := &models.UserToken{
+		UserID:       userID,
+		Purpose:      purpose,
+		Selector:     selector,
+		VerifierHash: hashVerifier(verifier),
+		ExpiresAt:    now.Add(ttl),
+	}
+	if err := s.repo.CreateToken(ctx, token); err != nil {
+		return "", ErrDatabaseUpdateFailed
+	}
+
+	return selector + verifier, nil
+}
+
+// Peek, ham tokenı tüketmeden doğrular; işlemin geri kalanı (ör. şifre
+// politikası) başarısız olursa bağlantı tekrar kullanılabilir kalır.
+func (s *UserTokenService) Peek(purpose models.UserTokenPurpose, rawToken string) (*models.UserToken, error) {
+	selector, verifier, ok := splitToken(rawToken)
+	if !ok {
+		return nil, ErrTokenInvalid
+	}
+
+	token, err := s.repo.FindBySelector(purpose, selector)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTokenInvalid
+		}
+		return nil, ErrAuthGeneric
+	}
+
+	expected, _ := hex.DecodeString(token.VerifierHash)
+	actual := sha256.Sum256([]byte(verifier))
+	if subtle.ConstantTimeCompare(expected, actual[:]) != 1 {
+		logconfig.Log.Warn("Token doğrulaması başarısız",
+			zap.Uint("token_id", token.ID),
+			zap.String("purpose", string(purpose)),
+		)
+		return nil, ErrTokenInvalid
+	}
+
+	if !token.IsUsable(s.now()) {
+		return nil, ErrTokenInvalid
+	}
+	return token, nil
+}
+
+// Consume, ham tokenı doğrular ve tek kullanımlık olarak işaretler.
+func (s *UserTokenService) Consume(ctx context.Context, purpose models.UserTokenPurpose, rawToken string) (*models.UserToken, error) {
+	token, err := s.Peek(purpose, rawToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	marked, err := s.repo.MarkUsed(ctx, token.ID, now)
+	if err != nil {
+		return nil, ErrDatabaseUpdateFailed
+	}
+	if !marked {
+		return nil, ErrTokenInvalid
+	}
+	token.UsedAt = &now
+	return token, nil
+}
+
+func (s *UserTokenService) Revoke(ctx context.Context, userID uint, purpose models.UserTokenPurpose) error {
+	if err := s.repo.InvalidateUserTokens(ctx, userID, purpose, s.now()); err != nil {
+		return ErrDatabaseUpdateFailed
+	}
+	return nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashVerifier(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return hex.EncodeToString(sum[:])
+}
+
+func splitToken(rawToken string) (selector, verifier string, ok bool) {
+	rawToken = strings.TrimSpace(rawToken)
+	if len(rawToken) != tokenSelectorHexSize+tokenVerifierBytes*2 {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(rawToken); err != nil {
+		return "", "", false
+	}
+	return rawToken[:tokenSelectorHexSize], rawToken[tokenSelectorHexSize:], true
+}
+
+var _ IUserTokenService = (*UserTokenService)(nil)
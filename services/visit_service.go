@@ -0,0 +1,420 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/useragent"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// Tampon bu boyuta ulaşırsa yazılana kadar yeni olaylar düşürülür.
+	visitBufferMaxKeys  = 50000
+	visitLabelMaxLength = 100
+
+	VisitReferrerDirect   = "direct"
+	VisitReferrerInternal = "internal"
+)
+
+// VisitClickKinds, herkese açık sayfalardan kabul edilen tıklama türleridir.
+var VisitClickKinds = map[string]string{
+	"iban":     "IBAN kopyalama",
+	"social":   "Sosyal medya",
+	"website":  "Web sitesi",
+	"phone":    "Telefon",
+	"email":    "E-posta",
+	"map":      "Harita",
+	"calendar": "Takvime ekleme",
+	"vcard":    "Rehbere ekleme",
+	"gallery":  "Galeri fotoğrafı",
+}
+
+// visitClickLabels, sayfalarda sabit yazılan tıklama etiketleridir. Etiket
+// serbest metin olarak gelir; bilinmeyen etiketler istatistikleri
+// kirletmemesi için atılır.
+var visitClickLabels = map[string][]string{
+	"phone":    {"WhatsApp"},
+	"website":  {"Mağaza"},
+	"calendar": {"Google", "Outlook", "ics"},
+}
+
+// ClickLabels, hedefin kendi kayıtlarından gelen etiketlerdir (ör. kartvizitteki
+// sosyal medya ve banka adları); tıklama türüne göre gruplanır.
+type ClickLabels map[string][]string
+
+// VisitRequest, ziyaretin HTTP isteğinden alınan bilgileridir. IP ve
+// User-Agent yalnızca günlük tuzla özetlenir, saklanmaz.
+type VisitRequest struct {
+	IP        string
+	UserAgent string
+	Referrer  string
+	Host      string
+}
+
+type VisitClick struct {
+	Kind     string
+	KindName string
+	Label    string
+	Count    int64
+}
+
+type VisitStats struct {
+	Days          []repositories.VisitDay
+	TotalViews    int64
+	TotalUniques  int64
+	MaxDailyViews int64
+	Referrers     []repositories.VisitDimension
+	Devices       []repositories.VisitDimension
+	Clicks        []VisitClick
+}
+
+type VisitOverview struct {
+	Days           int
+	Cards          repositories.VisitTotals
+	Invitations    repositories.VisitTotals
+	TopCards       []repositories.VisitTargetTotal
+	TopInvitations []repositories.VisitTargetTotal
+}
+
+type IVisitService interface {
+	TrackView(ctx context.Context, targetType models.VisitTargetType, targetID uint, req VisitRequest)
+	TrackClick(targetType models.VisitTargetType, targetID uint, kind, label string, known ClickLabels) bool
+	Flush(ctx context.Context) error
+	Cleanup(ctx context.Context) error
+	Stats(ctx context.Context, targetType models.VisitTargetType, targetID uint, days int) (*VisitStats, error)
+	Overview(ctx context.Context, days int) (*VisitOverview, error)
+}
+
+type visitCounterKey struct {
+	targetType models.VisitTargetType
+	targetID   uint
+	day        time.Time
+	metric     models.VisitMetric
+	dimension  string
+}
+
+type visitBuffer struct {
+	mu       sync.Mutex
+	counters map[visitCounterKey]int64
+	uniques  map[models.VisitUnique]struct{}
+}
+
+type visitSalt struct {
+	mu   sync.Mutex
+	day  time.Time
+	salt string
+}
+
+// Görüntülemeler istek sırasında veritabanına yazılmaz; tüm handler'lar aynı
+// tamponu paylaşır ve zamanlanmış iş Flush ile toplu yazar.
+var (
+	sharedVisitBuffer = &visitBuffer{
+		counters: make(map[visitCounterKey]int64),
+		uniques:  make(map[models.VisitUnique]struct{}),
+	}
+	sharedVisitSalt = &visitSalt{}
+)
+
+type VisitService struct {
+	repo   repositories.IVisitRepository
+	buffer *visitBuffer
+	salt   *visitSalt
+	now    func() time.Time
+}
+
+func NewVisitService() IVisitService {
+	return &VisitService{
+		repo:   repositories.NewVisitRepository(),
+		buffer: sharedVisitBuffer,
+		salt:   sharedVisitSalt,
+		now:    time.Now,
+	}
+}
+
+// VisitStatsDays, istatistik sayfalarında gösterilecek gün sayısıdır.
+func VisitStatsDays() int {
+	days := envconfig.GetEnvAsInt("ANALYTICS_STATS_DAYS", 30)
+	if days < 1 {
+		return 30
+	}
+	return days
+}
+
+func visitLocation() *time.Location {
+	if loc, err := time.LoadLocation(envconfig.GetEnvWithDefault("ANALYTICS_TIMEZONE", models.DefaultInvitationTimezone)); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// visitDay, anı istatistik saat dilimindeki takvim gününe çevirir; date
+// sütunuyla karşılaştırılabilmesi için UTC gece yarısı olarak döner.
+func visitDay(t time.Time) time.Time {
+	y, m, d := t.In(visitLocation()).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *VisitService) TrackView(ctx context.Context, targetType models.VisitTargetType, targetID uint, req VisitRequest) {
+	device := useragent.Parse(req.UserAgent).Device
+	if device == useragent.DeviceBot {
+		return
+	}
+
+	day := visitDay(s.now())
+	hash := s.visitorHash(ctx, day, req.IP, req.UserAgent)
+
+	s.buffer.mu.Lock()
+	defer s.buffer.mu.Unlock()
+	if !s.buffer.hasRoom() {
+		return
+	}
+	s.buffer.add(visitCounterKey{targetType, targetID, day, models.VisitMetricView, ""}, 1)
+	s.buffer.add(visitCounterKey{targetType, targetID, day, models.VisitMetricDevice, string(device)}, 1)
+	s.buffer.add(visitCounterKey{targetType, targetID, day, models.VisitMetricReferrer, referrerHost(req.Referrer, req.Host)}, 1)
+	if hash != "" {
+		s.buffer.uniques[models.VisitUnique{TargetType: targetType, TargetID: targetID, Day: day, VisitorHash: hash}] = struct{}{}
+	}
+}
+
+// TrackClick, desteklenen bir tıklama türüyse olayı tampona ekler. Etiket
+// yalnızca sabit etiketlerden veya hedefin known etiketlerinden biriyse
+// saklanır; aksi halde tıklama etiketsiz sayılır.
+func (s *VisitService) TrackClick(targetType models.VisitTargetType, targetID uint, kind, label string, known ClickLabels) bool {
+	if _, ok := VisitClickKinds[kind]; !ok {
+		return false
+	}
+	dimension := kind
+	if label = strings.TrimSpace(label); label != "" && isKnownClickLabel(label, visitClickLabels[kind], known[kind]) {
+		dimension += ":" + truncateRunes(label, visitLabelMaxLength)
+	}
+
+	s.buffer.mu.Lock()
+	defer s.buffer.mu.Unlock()
+	if !s.buffer.hasRoom() {
+		return false
+	}
+	s.buffer.add(visitCounterKey{targetType, targetID, visitDay(s.now()), models.VisitMetricClick, dimension}, 1)
+	return true
+}
+
+func isKnownClickLabel(label string, lists ...[]string) bool {
+	for _, list := range lists {
+		for _, known := range list {
+			if label == known {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Flush, tampondaki olayları veritabanına yazar. Yazma başarısız olursa olaylar
+// bir sonraki denemede tekrar yazılmak üzere tampona geri eklenir.
+func (s *VisitService) Flush(ctx context.Context) error {
+	s.buffer.mu.Lock()
+	counters, uniques := s.buffer.counters, s.buffer.uniques
+	s.buffer.counters = make(map[visitCounterKey]int64)
+	s.buffer.uniques = make(map[models.VisitUnique]struct{})
+	s.buffer.mu.Unlock()
+
+	if len(counters) == 0 && len(uniques) == 0 {
+		return nil
+	}
+
+	now := s.now()
+	rows := make([]models.VisitCounter, 0, len(counters))
+	for key, count := range counters {
+		rows = append(rows, models.VisitCounter{
+			TargetType: key.targetType,
+			TargetID:   key.targetID,
+			Day:        key.day,
+			Metric:     key.metric,
+			Dimension:  key.dimension,
+			Count:      count,
+			UpdatedAt:  now,
+		})
+	}
+	uniqueRows := make([]models.VisitUnique, 0, len(uniques))
+	for u := range uniques {
+		uniqueRows = append(uniqueRows, u)
+	}
+
+	if err := s.repo.Flush(ctx, rows, uniqueRows); err != nil {
+		logconfig.Log.Error("Ziyaret istatistikleri yazılamadı", zap.Int("counters", len(rows)), zap.Error(err))
+		s.buffer.mu.Lock()
+		for key, count := range counters {
+			if s.buffer.hasRoom() {
+				s.buffer.add(key, count)
+			}
+		}
+		for u := range uniques {
+			s.buffer.uniques[u] = struct{}{}
+		}
+		s.buffer.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Cleanup, önceki günlere ait tuzları ve ziyaretçi özetlerini siler.
+func (s *VisitService) Cleanup(ctx context.Context) error {
+	if err := s.repo.DeleteBefore(ctx, visitDay(s.now())); err != nil {
+		logconfig.Log.Error("Eski ziyaretçi özetleri silinemedi", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s *VisitService) Stats(ctx context.Context, targetType models.VisitTargetType, targetID uint, days int) (*VisitStats, error) {
+	today := visitDay(s.now())
+	from := today.AddDate(0, 0, -(days - 1))
+
+	daily, err := s.repo.DailyTotals(ctx, targetType, targetID, from)
+	if err != nil {
+		logconfig.Log.Error("Ziyaret istatistikleri alınamadı",
+			zap.String("target_type", string(targetType)),
+			zap.Uint("target_id", targetID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	byDay := make(map[time.Time]repositories.VisitDay, len(daily))
+	for _, d := range daily {
+		y, m, dd := d.Day.Date()
+		byDay[time.Date(y, m, dd, 0, 0, 0, 0, time.UTC)] = d
+	}
+
+	stats := &VisitStats{}
+	for day := from; !day.After(today); day = day.AddDate(0, 0, 1) {
+		d := byDay[day]
+		d.Day = day
+		stats.Days = append(stats.Days, d)
+		stats.TotalViews += d.Views
+		stats.TotalUniques += d.Uniques
+		if d.Views > stats.MaxDailyViews {
+			stats.MaxDailyViews = d.Views
+		}
+	}
+
+	if stats.Referrers, err = s.repo.TopDimensions(ctx, targetType, targetID, models.VisitMetricReferrer, from, 10); err != nil {
+		return nil, err
+	}
+	if stats.Devices, err = s.repo.TopDimensions(ctx, targetType, targetID, models.VisitMetricDevice, from, 10); err != nil {
+		return nil, err
+	}
+	clicks, err := s.repo.TopDimensions(ctx, targetType, targetID, models.VisitMetricClick, from, 20)
+	if err != nil {
+		return nil, err
+	}
+	for _, click := range clicks {
+		kind, label, _ := strings.Cut(click.Dimension, ":")
+		stats.Clicks = append(stats.Clicks, VisitClick{Kind: kind, KindName: VisitClickKinds[kind], Label: label, Count: click.Count})
+	}
+	return stats, nil
+}
+
+func (s *VisitService) Overview(ctx context.Context, days int) (*VisitOverview, error) {
+	from := visitDay(s.now()).AddDate(0, 0, -(days - 1))
+	overview := &VisitOverview{Days: days}
+
+	var err error
+	if overview.Cards, err = s.repo.Totals(ctx, models.VisitTargetCard, from); err != nil {
+		logconfig.Log.Error("Ziyaret özeti alınamadı", zap.Error(err))
+		return nil, err
+	}
+	if overview.Invitations, err = s.repo.Totals(ctx, models.VisitTargetInvitation, from); err != nil {
+		return nil, err
+	}
+	if overview.TopCards, err = s.repo.TopTargets(ctx, models.VisitTargetCard, from, 5); err != nil {
+		return nil, err
+	}
+	if overview.TopInvitations, err = s.repo.TopTargets(ctx, models.VisitTargetInvitation, from, 5); err != nil {
+		return nil, err
+	}
+	return overview, nil
+}
+
+// visitorHash, IP ve User-Agent'ı günün tuzuyla SHA-256 ile özetler.
+func (s *VisitService) visitorHash(ctx context.Context, day time.Time, ip, ua string) string {
+	salt := s.saltFor(ctx, day)
+	if salt == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(salt + "|" + ip + "|" + ua))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *VisitService) saltFor(ctx context.Context, day time.Time) string {
+	s.salt.mu.Lock()
+	defer s.salt.mu.Unlock()
+	if s.salt.day.Equal(day) && s.salt.salt != "" {
+		return s.salt.salt
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return ""
+	}
+	candidate := hex.EncodeToString(raw)
+	salt, err := s.repo.SaltForDay(ctx, day, candidate)
+	if err != nil {
+		// Veritabanına ulaşılamazsa gün boyunca yerel tuz kullanılır; birden
+		// fazla sunucuda tekil sayılar bir miktar yüksek çıkabilir.
+		logconfig.Log.Warn("Günlük ziyaret tuzu alınamadı, yerel tuz kullanılıyor", zap.Error(err))
+		salt = candidate
+	}
+	s.salt.day, s.salt.salt = day, salt
+	return salt
+}
+
+func (b *visitBuffer) hasRoom() bool {
+	return len(b.counters)+len(b.uniques) < visitBufferMaxKeys
+}
+
+func (b *visitBuffer) add(key visitCounterKey, count int64) {
+	b.counters[key] += count
+}
+
+// referrerHost, yönlendiren adresin yalnızca alan adını tutar; boş başlık
+// doğrudan ziyaret, sitenin kendi alan adı site içi gezinme sayılır.
+func referrerHost(referrer, ownHost string) string {
+	if referrer == "" {
+		return VisitReferrerDirect
+	}
+	u, err := url.Parse(referrer)
+	if err != nil || u.Hostname() == "" {
+		return VisitReferrerDirect
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	own := strings.ToLower(ownHost)
+	if i := strings.LastIndex(own, ":"); i >= 0 && !strings.Contains(own[i:], "]") {
+		own = own[:i]
+	}
+	if host == strings.TrimPrefix(own, "www.") {
+		return VisitReferrerInternal
+	}
+	return truncateRunes(host, 255)
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
+
+var _ IVisitService = (*VisitService)(nil)